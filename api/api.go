@@ -1,6 +1,6 @@
 /*
  *
- * Copyright © 2020-2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+ * Copyright © 2020-2026 Dell Inc. or its subsidiaries. All Rights Reserved.
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -29,8 +29,6 @@ import (
 	"io"
 	"log"
 	"net/http"
-	"net/http/cookiejar"
-	"net/http/httputil"
 	"net/url"
 	"os"
 	"path"
@@ -39,13 +37,19 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
-	debug              = false
+	// debug enables request dumps and debug logs, it is read from GOPOWERSTORE_DEBUG when a client is created
+	debug              atomic.Bool
 	systemCertPoolFunc = x509.SystemCertPool
 	errSysCerts        = errors.New("unable to initialize certificate pool from system")
+	// errDefaultTimeout is cause of the context of requests which exceeded default timeout of the client
+	errDefaultTimeout = fmt.Errorf("default timeout of the client exceeded: %w", context.DeadlineExceeded)
 )
 
 const (
@@ -69,6 +73,12 @@ type RequestConfig struct {
 	QueryParams QueryParamsEncoder
 	// request body
 	Body interface{}
+	// headers sent only with this request, they replace custom headers with the same name
+	Headers http.Header
+	// priority of the request in the rate limiter, the one from context is used if not set
+	Priority RequestPriority
+	// allow the client RetryPolicy to retry this request even if its method is not idempotent
+	Retryable bool
 }
 
 // RenderRequestConfig is RequestConfigRenderer implementation
@@ -138,6 +148,9 @@ type Client interface {
 	GetCustomHTTPHeaders() http.Header
 	SetCustomHTTPHeaders(headers http.Header)
 	SetLogger(logger Logger)
+	Use(middlewares ...Middleware)
+	Ping(ctx context.Context) error
+	Close(ctx context.Context) error
 }
 
 // FieldProvider provide method which return required fields list
@@ -145,61 +158,117 @@ type FieldProvider interface {
 	Fields() []string
 }
 
+// VersionedFieldProvider is FieldProvider which fields depend on the array version,
+// FieldsForVersion returns Fields when the version is unknown (zero)
+type VersionedFieldProvider interface {
+	FieldProvider
+	FieldsForVersion(version ArrayVersion) []string
+}
+
 // ClientIMPL struct holds API client settings
 type ClientIMPL struct {
-	apiURL            string
-	insecure          bool
-	username          string
-	password          string
-	httpClient        *http.Client
-	defaultTimeout    time.Duration
-	requestIDKey      ContextKey
-	customHTTPHeaders *SafeHeader
-	logger            Logger
-	apiThrottle       TimeoutSemaphoreInterface
-	loginMutex        sync.Mutex
-	token             string
+	apiURL             string
+	apiURLs            []string
+	endpointMutex      sync.RWMutex
+	failoverMutex      sync.Mutex
+	cluster            clusterIdentity
+	insecure           bool
+	credentialProvider CredentialProvider
+	credentials        Credentials
+	httpClient         *http.Client
+	defaultTimeout     time.Duration
+	requestIDKey       ContextKey
+	customHTTPHeaders  *SafeHeader
+	logger             Logger
+	apiThrottle        TimeoutSemaphoreInterface
+	loginMutex         sync.Mutex
+	sessionMutex       sync.RWMutex
+	sessionJar         *sessionJar
+	token              string
+	lastLogin          time.Time
+	lastUsed           time.Time
+	idleTimeout        time.Duration
+	retryPolicy        *RetryPolicy
+	rateLimiter        RateLimiterInterface
+	arrayVersion       atomic.Pointer[ArrayVersion]
+	middlewareMutex    sync.RWMutex
+	middlewares        []Middleware
+	tracerProvider     trace.TracerProvider
+	metricsHook        MetricsHook
+	coalescing         atomic.Bool
+	dryRun             atomic.Pointer[DryRunPlan]
+	auditMutex         sync.Mutex
+	auditSink          AuditSink
+	auditClusterMutex  sync.Mutex
+	breaker            atomic.Pointer[circuitBreaker]
+	inFlightMutex      sync.Mutex
+	inFlight           map[string]*inFlightCall
 }
 
 // New creates and initialize API client
 func New(apiURL string, username string,
 	password string, insecure bool, defaultTimeout time.Duration, rateLimit int, requestIDKey ContextKey,
 ) (*ClientIMPL, error) {
-	debug, _ = strconv.ParseBool(os.Getenv("GOPOWERSTORE_DEBUG"))
-	if apiURL == "" || username == "" || password == "" {
+	return NewWithConfig(Config{
+		APIURL:         apiURL,
+		Username:       username,
+		Password:       password,
+		DefaultTimeout: defaultTimeout,
+		RateLimit:      rateLimit,
+		RequestIDKey:   requestIDKey,
+		Transport:      TransportOptions{Insecure: insecure},
+	})
+}
+
+// NewWithConfig creates and initialize API client using provided config,
+// login errors are ignored and reported by the first request
+func NewWithConfig(cfg Config) (*ClientIMPL, error) {
+	clientImpl, err := newClientIMPL(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a login session after the client is initialized
+	clientImpl.login(context.Background()) // #nosec G104
+
+	return clientImpl, nil
+}
+
+// NewWithContext creates API client and logs in, returns AuthError if credentials are rejected
+func NewWithContext(ctx context.Context, cfg Config) (*ClientIMPL, error) {
+	clientImpl, err := newClientIMPL(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := clientImpl.login(ctx); err != nil {
+		return nil, newAuthError(err)
+	}
+	return clientImpl, nil
+}
+
+func setDebugFromEnv() {
+	enabled, _ := strconv.ParseBool(os.Getenv("GOPOWERSTORE_DEBUG"))
+	debug.Store(enabled)
+}
+
+func newClientIMPL(cfg Config) (*ClientIMPL, error) {
+	setDebugFromEnv()
+	credentials := cfg.Credentials
+	if credentials == nil && cfg.Username != "" && cfg.Password != "" {
+		credentials = StaticCredentials{Username: cfg.Username, Password: cfg.Password}
+	}
+	if cfg.APIURL == "" || credentials == nil {
 		return nil, errors.New("API ApiClient can't be initialized: " +
 			"Missing endpoint, username, or password param")
 	}
 
-	var client *http.Client
-	if insecure {
-		client = &http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: true, // #nosec G402
-				},
-			},
-		}
-	} else {
-		pool, err := systemCertPoolFunc()
-		if err != nil {
-			log.Fatalf("failed to get system cert pool: %v", err)
-			return nil, fmt.Errorf("failed to get system cert pool: %w", err)
-		}
-		client = &http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					RootCAs:            pool,
-					InsecureSkipVerify: false,
-					CipherSuites:       GetSecuredCipherSuites(),
-					MinVersion:         tls.VersionTLS12,
-				},
-			},
-		}
+	client, err := NewHTTPClient(cfg.Transport)
+	if err != nil {
+		return nil, fmt.Errorf("API ApiClient can't be initialized: %w", err)
 	}
 
 	// Set cookie jar to enable session management via auth_cookie
-	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: nil})
+	jar, err := newSessionJar()
 	if err != nil {
 		log.Printf("Failed to set cookie jar. error: %s", err)
 		log.Print("Session management is disabled.")
@@ -208,33 +277,35 @@ func New(apiURL string, username string,
 		log.Print("Session management is enabled.")
 	}
 
-	throttle := NewTimeoutSemaphore(defaultTimeout, rateLimit, &defaultLogger{})
+	throttle := newThrottle(cfg.DefaultTimeout, cfg.RateLimit, &defaultLogger{})
 
 	clientImpl := &ClientIMPL{
-		apiURL:            apiURL,
-		insecure:          insecure,
-		username:          username,
-		password:          password,
-		httpClient:        client,
-		defaultTimeout:    defaultTimeout,
-		requestIDKey:      requestIDKey,
-		logger:            &defaultLogger{},
-		apiThrottle:       throttle,
-		customHTTPHeaders: NewSafeHeader(),
+		apiURL:             cfg.APIURL,
+		apiURLs:            append([]string{cfg.APIURL}, cfg.FailoverURLs...),
+		insecure:           cfg.Transport.Insecure,
+		credentialProvider: credentials,
+		httpClient:         client,
+		defaultTimeout:     cfg.DefaultTimeout,
+		requestIDKey:       cfg.RequestIDKey,
+		logger:             &defaultLogger{},
+		apiThrottle:        throttle,
+		customHTTPHeaders:  NewSafeHeader(),
+		sessionJar:         jar,
+		tracerProvider:     cfg.TracerProvider,
+		metricsHook:        cfg.Metrics,
+	}
+	if cfg.Configure != nil {
+		cfg.Configure(clientImpl)
 	}
-
-	// Create a login session after the client is initialized
-	clientImpl.login(context.Background()) // #nosec G104
-
 	return clientImpl, nil
 }
 
 // MockClient returns default client for testing purposes
 func MockClient(defaultTimeout time.Duration, rateLimit int, requestIDKey ContextKey,
 ) *ClientIMPL {
-	debug, _ = strconv.ParseBool(os.Getenv("GOPOWERSTORE_DEBUG"))
+	setDebugFromEnv()
 	client := &http.Client{}
-	throttle := NewTimeoutSemaphore(defaultTimeout, rateLimit, &defaultLogger{})
+	throttle := newThrottle(defaultTimeout, rateLimit, &defaultLogger{})
 	clientImpl := &ClientIMPL{
 		httpClient:        client,
 		defaultTimeout:    defaultTimeout,
@@ -249,19 +320,30 @@ func MockClient(defaultTimeout time.Duration, rateLimit int, requestIDKey Contex
 const errorSeverity = "Error"
 
 type apiErrorMsg struct {
-	Messages *[]ErrorMsg `json:"messages"`
+	Messages *[]ErrorMessage `json:"messages"`
 }
 
 // ErrorMsg is internal error representation
 type ErrorMsg struct {
 	StatusCode int `json:"-"`
-	Severity   string
-	Message    string `json:"message_l10n"`
-	Arguments  []string
+	// PowerStore error code of the first message
+	Code      string `json:"code"`
+	Severity  string
+	Message   string `json:"message_l10n"`
+	Arguments []string
+	// all messages returned by PowerStore, the first one is also stored in the fields above
+	Messages []ErrorMessage `json:"-"`
 }
 
 func (err *ErrorMsg) Error() string {
-	return err.Message
+	if len(err.Messages) < 2 {
+		return err.Message
+	}
+	msgs := make([]string, 0, len(err.Messages))
+	for _, m := range err.Messages {
+		msgs = append(msgs, m.Message)
+	}
+	return strings.Join(msgs, "; ")
 }
 
 func buildError(r *http.Response) *ErrorMsg {
@@ -269,7 +351,7 @@ func buildError(r *http.Response) *ErrorMsg {
 
 	dec := json.NewDecoder(r.Body)
 	err := dec.Decode(&apiErrorMsg)
-	if err != nil || apiErrorMsg.Messages == nil {
+	if err != nil || apiErrorMsg.Messages == nil || len(*apiErrorMsg.Messages) == 0 {
 		errMsg := "Unknown error"
 		buf := new(bytes.Buffer)
 		if _, err := buf.ReadFrom(dec.Buffered()); err == nil {
@@ -281,9 +363,15 @@ func buildError(r *http.Response) *ErrorMsg {
 			Message: errMsg,
 		}
 	}
-	firstErrMsg := (*apiErrorMsg.Messages)[0]
-	firstErrMsg.StatusCode = r.StatusCode
-	return &firstErrMsg
+	msgs := *apiErrorMsg.Messages
+	return &ErrorMsg{
+		StatusCode: r.StatusCode,
+		Code:       msgs[0].Code,
+		Severity:   msgs[0].Severity,
+		Message:    msgs[0].Message,
+		Arguments:  msgs[0].Arguments,
+		Messages:   msgs,
+	}
 }
 
 // GetCustomHTTPHeaders method retrieves http headers
@@ -300,6 +388,22 @@ func (c *ClientIMPL) SetCustomHTTPHeaders(headers http.Header) {
 func (c *ClientIMPL) SetLogger(logger Logger) {
 	c.logger = logger
 	c.apiThrottle.SetLogger(logger)
+	if c.rateLimiter != nil {
+		c.rateLimiter.SetLogger(logger)
+	}
+}
+
+// SetRateLimiter sets limiter of requests per second, it is applied before the concurrency semaphore
+func (c *ClientIMPL) SetRateLimiter(limiter RateLimiterInterface) {
+	if limiter != nil {
+		limiter.SetLogger(c.logger)
+	}
+	c.rateLimiter = limiter
+}
+
+// SetRetryPolicy sets policy used to retry failed requests, nil disables retries
+func (c *ClientIMPL) SetRetryPolicy(policy *RetryPolicy) {
+	c.retryPolicy = policy
 }
 
 // Query method do http request and reads response to provided struct
@@ -309,7 +413,25 @@ func (c *ClientIMPL) Query(
 	resp interface{},
 ) (RespMeta, error) {
 	config := cfg.RenderRequestConfig()
-	meta := RespMeta{}
+	if plan := c.dryRun.Load(); plan != nil && isMutatingMethod(config.Method) {
+		return c.dryRunQuery(ctx, plan, config, resp)
+	}
+	if c.coalescing.Load() && config.Method == http.MethodGet {
+		return c.coalescedQuery(ctx, config, resp)
+	}
+	meta, err := c.query(ctx, config, resp)
+	if isMutatingMethod(config.Method) {
+		c.audit(ctx, config, resp, meta, err)
+	}
+	return meta, err
+}
+
+// query sends request with retries
+func (c *ClientIMPL) query(
+	ctx context.Context,
+	config RequestConfig,
+	resp interface{},
+) (RespMeta, error) {
 	var cancelFuncPtr *func()
 	ctx, cancelFuncPtr = c.setupContext(ctx)
 	if cancelFuncPtr != nil {
@@ -317,81 +439,69 @@ func (c *ClientIMPL) Query(
 	}
 
 	traceMsg := c.prepareTraceMsg(ctx)
-
-	requestURL, err := c.prepareRequestURL(config.Endpoint, config.ID, config.Action, config.QueryParams)
-	if err != nil {
-		return meta, err
+	ctx, span := c.startQuerySpan(ctx, config)
+
+	for attempt := 1; ; attempt++ {
+		meta, retryAfter, err := c.queryWithFailover(ctx, config, traceMsg, resp)
+		if !c.retryPolicy.shouldRetry(ctx, config, attempt, err) {
+			c.observeTimeout(config, err)
+			endQuerySpan(span, meta, err)
+			return meta, err
+		}
+		delay := max(c.retryPolicy.Backoff(attempt), retryAfter)
+		span.AddEvent("retry", trace.WithAttributes(attrAttempt.Int(attempt)))
+		c.logger.Info(ctx, "%sattempt %d of %d for API [%s %s] failed: %s, retrying in %s\n",
+			traceMsg, attempt, c.retryPolicy.MaxAttempts, config.Method, config.Endpoint, err.Error(), delay)
+		if !waitForRetry(ctx, delay) {
+			c.observeTimeout(config, err)
+			endQuerySpan(span, meta, err)
+			return meta, err
+		}
 	}
+}
 
-	req, err := c.prepareRequest(ctx, config.Method, requestURL, traceMsg, config.Body)
+// queryOnce sends a single request without any retries
+func (c *ClientIMPL) queryOnce(
+	ctx context.Context,
+	config RequestConfig,
+	resp interface{},
+) (RespMeta, time.Duration, error) {
+	meta := RespMeta{}
+	requestURL, err := c.prepareRequestURLAt(c.requestAPIURL(ctx), config.Endpoint, config.ID, config.Action,
+		config.QueryParams)
 	if err != nil {
-		return meta, err
+		return meta, 0, err
 	}
 
-	c.logger.Debug(ctx, "Requesting a lock for API : [%s %s]\n", config.Method, requestURL)
-	if err := c.apiThrottle.Acquire(ctx); err != nil {
-		return meta, err
+	req, err := c.prepareRequest(withRequestConfig(ctx, config), config.Method, requestURL, config.Body, config.Headers)
+	if err != nil {
+		return meta, 0, err
 	}
-	defer c.apiThrottle.Release(ctx)
 
-	r, err := c.httpClient.Do(req)
+	r, err := c.handler()(req)
 	if err != nil {
-		return meta, err
+		return meta, 0, err
+	}
+	if r.Body == nil {
+		// synthetic responses of middlewares may have no body
+		r.Body = http.NoBody
 	}
 	defer r.Body.Close() // #nosec G307
 
-	if debug {
-		dump, _ := httputil.DumpResponse(r, true)
-		replacedHeader := prepareHTTPDump(dump) // Replace sensitive parts of response headers
-		c.logger.Debug(ctx, "%sRESPONSE: %v\n", traceMsg, replacedHeader)
-	}
 	meta.Status = r.StatusCode
 	switch {
 	case resp == nil:
-		return meta, nil
+		return meta, 0, nil
 	case r.StatusCode >= 200 && r.StatusCode < 300:
-		// Save DELL-EMC-TOKEN if it was a successful response.
-		token := r.Header.Get(dellEmcToken)
-		if len(token) != 0 {
-			c.token = token
-		}
-
 		c.updatePaginationInfoInMeta(&meta, r)
 		err = json.NewDecoder(r.Body).Decode(resp)
 		if err == io.EOF {
-			return meta, nil
-		}
-		return meta, err
-	case r.StatusCode == http.StatusForbidden:
-		loginResp, err := c.login(ctx)
-		// Invalid credentials - No need to retry if response of login api was 401 Unauthorized.
-		if err != nil || loginResp.Status == http.StatusUnauthorized {
-			return meta, buildError(r)
+			return meta, 0, nil
 		}
-
-		// login successful - resend the failed request
-		return c.Query(ctx, cfg, resp)
+		return meta, 0, err
 	default:
-		return meta, buildError(r)
-	}
-}
-
-func (c *ClientIMPL) login(ctx context.Context) (RespMeta, error) {
-	c.loginMutex.Lock()
-	defer c.loginMutex.Unlock()
-
-	type loginDetails []struct {
-		ID string `json:"id"`
+		return meta, parseRetryAfter(r.Header), buildError(r)
 	}
-	var login loginDetails
-
-	resp, err := c.Query(ctx,
-		RequestConfig{
-			Method:   "GET",
-			Endpoint: "login_session",
-		}, &login)
-
-	return resp, err
 }
 
 func addMetaData(req *http.Request, body interface{}) {
@@ -417,15 +527,56 @@ func (c *ClientIMPL) QueryParams() QueryParamsEncoder {
 	return &QueryParams{}
 }
 
-// QueryParamsWithFields method returns QueryParamsEncoder with configured select values
+// QueryParamsWithFields method returns QueryParamsEncoder with configured select values,
+// fields which the array version doesn't support are pruned, see SetVersion
 func (c *ClientIMPL) QueryParamsWithFields(fp FieldProvider) QueryParamsEncoder {
-	return c.QueryParams().Select(fp.Fields()...)
+	if vfp, ok := fp.(VersionedFieldProvider); ok {
+		return c.QueryParams().Select(vfp.FieldsForVersion(c.Version())...)
+	}
+	return c.QueryParams().Select(PruneFieldsForArrayVersion(fp, fp.Fields(), c.Version())...)
+}
+
+// SetArrayVersion sets major.minor version of the connected array, e.g. 3.6
+//
+// Deprecated: use SetVersion
+func (c *ClientIMPL) SetArrayVersion(version float32) {
+	c.SetVersion(ArrayVersionFromFloat(version))
+}
+
+// ArrayVersion returns major.minor version of the connected array or 0 if it is unknown
+//
+// Deprecated: use Version
+func (c *ClientIMPL) ArrayVersion() float32 {
+	v := c.Version()
+	if v.IsZero() {
+		return 0
+	}
+	return v.MajorMinor()
+}
+
+// SetVersion sets version of the connected array
+func (c *ClientIMPL) SetVersion(version ArrayVersion) {
+	c.arrayVersion.Store(&version)
+}
+
+// Version returns version of the connected array, zero version if it is unknown
+func (c *ClientIMPL) Version() ArrayVersion {
+	if v := c.arrayVersion.Load(); v != nil {
+		return *v
+	}
+	return ArrayVersion{}
 }
 
 func (c *ClientIMPL) prepareRequestURL(endpoint, id string, action string,
 	queryParams QueryParamsEncoder,
 ) (string, error) {
-	requestURL, err := url.Parse(c.apiURL)
+	return c.prepareRequestURLAt(c.APIURL(), endpoint, id, action, queryParams)
+}
+
+func (c *ClientIMPL) prepareRequestURLAt(apiURL, endpoint, id string, action string,
+	queryParams QueryParamsEncoder,
+) (string, error) {
+	requestURL, err := url.Parse(apiURL)
 	if err != nil {
 		return "", err
 	}
@@ -445,8 +596,8 @@ func (c *ClientIMPL) prepareRequestURL(endpoint, id string, action string,
 	return requestURL.String(), nil
 }
 
-func (c *ClientIMPL) prepareRequest(ctx context.Context, method, requestURL, traceMsg string,
-	body interface{},
+func (c *ClientIMPL) prepareRequest(ctx context.Context, method, requestURL string,
+	body interface{}, headers http.Header,
 ) (*http.Request, error) {
 	var req *http.Request
 	var err error
@@ -466,21 +617,18 @@ func (c *ClientIMPL) prepareRequest(ctx context.Context, method, requestURL, tra
 		}
 	}
 	req = req.WithContext(ctx)
-	req.SetBasicAuth(c.username, c.password)
-	if len(c.token) != 0 {
-		req.Header.Add(dellEmcToken, c.token)
-	}
 	for key, values := range c.customHTTPHeaders.GetHeader() {
 		for _, elem := range values {
 			req.Header.Add(key, elem)
 		}
 	}
-	addMetaData(req, body)
-	if debug {
-		if requestData, err := httputil.DumpRequest(req, true); err == nil {
-			c.logger.Debug(ctx, "%sREQUEST: %s", traceMsg, prepareHTTPDump(requestData))
+	for key, values := range headers {
+		req.Header.Del(key)
+		for _, elem := range values {
+			req.Header.Add(key, elem)
 		}
 	}
+	addMetaData(req, body)
 	return req, nil
 }
 
@@ -499,7 +647,7 @@ func (c *ClientIMPL) setupContext(ctx context.Context) (context.Context, *func()
 	_, timeoutIsSet := ctx.Deadline()
 	if !timeoutIsSet {
 		var f func()
-		ctx, f = context.WithTimeout(ctx, c.defaultTimeout)
+		ctx, f = context.WithTimeoutCause(ctx, c.defaultTimeout, errDefaultTimeout)
 		return ctx, &f
 	}
 	return ctx, nil
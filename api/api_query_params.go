@@ -32,6 +32,7 @@ type QueryParamsEncoder interface {
 	Limit(int) QueryParamsEncoder
 	Offset(int) QueryParamsEncoder
 	Async(bool) QueryParamsEncoder
+	Filter(...Filter) QueryParamsEncoder
 	Encode() string
 }
 
@@ -70,6 +71,10 @@ type QueryParams struct {
 	// <boolean>
 	// Example: true
 	asyncParam *bool
+	// filterArgs GET only
+	// Conditions added with Filter, a property may have several conditions
+	// Example: size=gte.1024&size=lte.4096
+	filterArgs url.Values
 }
 
 func (qp *QueryParams) addTo(attr **[]string, fields []string) {
@@ -119,6 +124,17 @@ func (qp *QueryParams) Async(value bool) QueryParamsEncoder {
 	return qp
 }
 
+// Filter adds conditions which instances must match, all filters must be satisfied
+func (qp *QueryParams) Filter(filters ...Filter) QueryParamsEncoder {
+	if qp.filterArgs == nil {
+		qp.filterArgs = make(url.Values)
+	}
+	for _, f := range filters {
+		f.addTo(qp.filterArgs)
+	}
+	return qp
+}
+
 // Encode encodes the values into “URL encoded” form
 // ("bar=baz&foo=quux") sorted by key.
 func (qp *QueryParams) Encode() string {
@@ -144,5 +160,10 @@ func (qp *QueryParams) Encode() string {
 			q.Set(k, v)
 		}
 	}
+	for k, values := range qp.filterArgs {
+		for _, v := range values {
+			q.Add(k, v)
+		}
+	}
 	return q.Encode()
 }
@@ -278,6 +278,35 @@ func TestClientIMPL_GetCustomHTTPHeaders(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestClient_Query_RequestHeaders(t *testing.T) {
+	apiURL := "https://foo"
+	testURL := "mock"
+	c := testClient(t, apiURL)
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	custom := http.Header{}
+	custom.Set("Foo", "global")
+	custom.Set("Bar", "global")
+	c.SetCustomHTTPHeaders(custom)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, testURL),
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, []string{"request"}, req.Header.Values("Foo"))
+			assert.Equal(t, "global", req.Header.Get("Bar"))
+			assert.Equal(t, "Internal", req.Header.Get("Dell-Visibility"))
+			return httpmock.NewStringResponse(http.StatusOK, `{"name": "Foo"}`), nil
+		})
+
+	headers := http.Header{}
+	headers.Set("Foo", "request")
+	headers.Set("DELL-VISIBILITY", "Internal")
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: testURL, Headers: headers}, &testResp{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+	assert.Equal(t, custom, c.GetCustomHTTPHeaders())
+}
+
 type qpTest struct{}
 
 func (qp *qpTest) Fields() []string {
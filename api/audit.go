@@ -0,0 +1,202 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"time"
+)
+
+// AuditRecord describes a mutating request sent to the array
+type AuditRecord struct {
+	Time time.Time `json:"time"`
+	// trace id set by SetTraceID
+	TraceID string `json:"trace_id,omitempty"`
+	// global id of the cluster, or its management address if the cluster can't be identified
+	ArrayID    string `json:"array_id"`
+	Method     string `json:"method"`
+	Endpoint   string `json:"endpoint"`
+	ResourceID string `json:"resource_id,omitempty"`
+	Action     string `json:"action,omitempty"`
+	// request body with values of sensitive fields such as passwords replaced
+	Body json.RawMessage `json:"body,omitempty"`
+	// http status, 0 if the array didn't respond
+	Status int `json:"status"`
+	// id of the created object if the response has one
+	ResultID string `json:"result_id,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// AuditSink receives a record for every mutating request, requests recorded in dry-run mode are not audited.
+// The record is written after the response is received, errors of the sink are logged and don't fail the request
+type AuditSink interface {
+	WriteAuditRecord(ctx context.Context, record AuditRecord) error
+}
+
+// SetAuditSink sets receiver of audit records, nil disables auditing
+func (c *ClientIMPL) SetAuditSink(sink AuditSink) {
+	c.auditMutex.Lock()
+	defer c.auditMutex.Unlock()
+	c.auditSink = sink
+}
+
+// auditClusterTimeout bounds the query of the cluster identity, records of requests sent while
+// the cluster can't be identified carry its management address
+var auditClusterTimeout = 10 * time.Second
+
+// audit writes record of the mutating request to the audit sink if it is set
+func (c *ClientIMPL) audit(ctx context.Context, config RequestConfig, resp interface{}, meta RespMeta, err error) {
+	c.auditMutex.Lock()
+	sink := c.auditSink
+	c.auditMutex.Unlock()
+	if sink == nil {
+		return
+	}
+	// the record is written even if the caller has given up waiting for the response
+	ctx = context.WithoutCancel(ctx)
+	record := AuditRecord{
+		Time:       time.Now().UTC(),
+		TraceID:    c.TraceID(ctx),
+		ArrayID:    c.auditArrayID(ctx),
+		Method:     config.Method,
+		Endpoint:   config.Endpoint,
+		ResourceID: config.ID,
+		Action:     config.Action,
+		Body:       redactAuditBody(config.Body),
+		Status:     meta.Status,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	} else {
+		record.ResultID = responseID(resp)
+	}
+	// records are written one at a time, the lock is not held while the cluster is queried
+	c.auditMutex.Lock()
+	defer c.auditMutex.Unlock()
+	if werr := sink.WriteAuditRecord(ctx, record); werr != nil {
+		c.logger.Error(ctx, "%sfailed to write audit record of API call [%s %s]: %s",
+			c.prepareTraceMsg(ctx), config.Method, config.Endpoint, werr.Error())
+	}
+}
+
+// auditArrayID returns global id of the cluster, it is queried once if it isn't known yet.
+// Failed queries are retried by the next record.
+func (c *ClientIMPL) auditArrayID(ctx context.Context) string {
+	if cluster, ok := c.knownCluster(); ok {
+		return cluster.arrayID()
+	}
+	// one query at a time, records which waited for it use its result
+	c.auditClusterMutex.Lock()
+	defer c.auditClusterMutex.Unlock()
+	if cluster, ok := c.knownCluster(); ok {
+		return cluster.arrayID()
+	}
+	ctx, cancel := context.WithTimeout(ctx, auditClusterTimeout)
+	defer cancel()
+	cluster, _, err := c.queryCluster(ctx)
+	if err != nil {
+		c.logger.Error(ctx, "failed to read identity of the cluster at %s: %s", c.APIURL(), err.Error())
+		return c.APIURL()
+	}
+	c.endpointMutex.Lock()
+	if !c.cluster.known() {
+		c.cluster = cluster
+	}
+	cluster = c.cluster
+	c.endpointMutex.Unlock()
+	return cluster.arrayID()
+}
+
+// knownCluster returns identity of the cluster if it has been read
+func (c *ClientIMPL) knownCluster() (clusterIdentity, bool) {
+	c.endpointMutex.RLock()
+	defer c.endpointMutex.RUnlock()
+	return c.cluster, c.cluster.known()
+}
+
+// arrayID returns global id of the cluster or its id if the global id is unknown
+func (ci clusterIdentity) arrayID() string {
+	if ci.GlobalID != "" {
+		return ci.GlobalID
+	}
+	return ci.ID
+}
+
+// sensitiveFieldRegexp matches names of request body fields which are not written to audit records
+var sensitiveFieldRegexp = regexp.MustCompile(`(?i)password|passphrase|secret|token|credential|private_key`)
+
+const redactedValue = "******"
+
+// redactAuditBody encodes request body as JSON with values of sensitive fields replaced
+func redactAuditBody(body interface{}) json.RawMessage {
+	if body == nil {
+		return nil
+	}
+	data, err := json.Marshal(body)
+	if err != nil || string(data) == "null" {
+		return nil
+	}
+	var decoded interface{}
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+	data, err = json.Marshal(redactValue(decoded))
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, item := range value {
+			if sensitiveFieldRegexp.MatchString(k) {
+				value[k] = redactedValue
+			} else {
+				value[k] = redactValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item)
+		}
+	}
+	return v
+}
+
+// responseID returns "id" field of the decoded response if it has one
+func responseID(resp interface{}) string {
+	if resp == nil {
+		return ""
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return ""
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(data, &created) != nil {
+		return ""
+	}
+	return created.ID
+}
@@ -0,0 +1,199 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryAuditSink struct {
+	mu      sync.Mutex
+	records []AuditRecord
+	err     error
+}
+
+func (s *memoryAuditSink) WriteAuditRecord(_ context.Context, record AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+	return s.err
+}
+
+func TestClientIMPL_Audit(t *testing.T) {
+	c := testClient(t, "https://foo")
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://foo/cluster",
+		httpmock.NewStringResponder(http.StatusOK, `[{"id": "0", "global_id": "PS1"}]`))
+	httpmock.RegisterResponder("GET", "https://foo/host",
+		httpmock.NewStringResponder(http.StatusOK, `[]`))
+	httpmock.RegisterResponder("POST", "https://foo/host",
+		httpmock.NewStringResponder(http.StatusCreated, `{"id": "h1"}`))
+	httpmock.RegisterResponder("DELETE", "https://foo/host/h2",
+		httpmock.NewStringResponder(http.StatusBadRequest, `{"messages": [{"code": "0xE04040010005", "severity": "Error", "message_l10n": "host not found"}]}`))
+
+	sink := &memoryAuditSink{}
+	c.SetAuditSink(sink)
+	ctx := c.SetTraceID(context.Background(), "trace-1")
+
+	_, err := c.Query(ctx, RequestConfig{Method: "GET", Endpoint: "host"}, &[]testResp{})
+	require.NoError(t, err)
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	body := map[string]any{
+		"name": "host",
+		"initiators": []any{map[string]any{
+			"port_name": "iqn.1", "chap_single_password": "secret1", "chap_mutual_password": "secret2",
+		}},
+	}
+	_, err = c.Query(ctx, RequestConfig{Method: "POST", Endpoint: "host", Body: body}, &created)
+	require.NoError(t, err)
+	var empty string
+	_, err = c.Query(ctx, RequestConfig{Method: "DELETE", Endpoint: "host", ID: "h2"}, &empty)
+	require.Error(t, err)
+
+	require.Len(t, sink.records, 2)
+	rec := sink.records[0]
+	assert.False(t, rec.Time.IsZero())
+	assert.Equal(t, "trace-1", rec.TraceID)
+	assert.Equal(t, "PS1", rec.ArrayID)
+	assert.Equal(t, "POST", rec.Method)
+	assert.Equal(t, "host", rec.Endpoint)
+	assert.Equal(t, http.StatusCreated, rec.Status)
+	assert.Equal(t, "h1", rec.ResultID)
+	assert.JSONEq(t, `{"name": "host", "initiators": [
+		{"port_name": "iqn.1", "chap_single_password": "******", "chap_mutual_password": "******"}]}`, string(rec.Body))
+	assert.NotContains(t, string(rec.Body), "secret1")
+	// the caller's body is not changed
+	assert.Equal(t, "secret1", body["initiators"].([]any)[0].(map[string]any)["chap_single_password"])
+
+	rec = sink.records[1]
+	assert.Equal(t, "DELETE", rec.Method)
+	assert.Equal(t, "h2", rec.ResourceID)
+	assert.Equal(t, http.StatusBadRequest, rec.Status)
+	assert.Equal(t, "host not found", rec.Error)
+	assert.Empty(t, rec.Body)
+	assert.Empty(t, rec.ResultID)
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["GET https://foo/cluster"])
+
+	// requests recorded by dry-run mode are not sent, so they are not audited
+	c.SetDryRun(NewDryRunPlan())
+	_, err = c.Query(ctx, RequestConfig{Method: "POST", Endpoint: "host", Body: body}, &created)
+	require.NoError(t, err)
+	assert.Len(t, sink.records, 2)
+}
+
+func TestClientIMPL_Audit_Errors(t *testing.T) {
+	c := testClient(t, "https://foo")
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://foo/cluster", httpmock.NewStringResponder(http.StatusInternalServerError, ""))
+	httpmock.RegisterResponder("PATCH", "https://foo/volume/1", httpmock.NewStringResponder(http.StatusNoContent, ""))
+	logger := &failoverLogger{}
+	c.SetLogger(logger)
+
+	sink := &memoryAuditSink{err: errors.New("disk is full")}
+	c.SetAuditSink(sink)
+	_, err := c.Query(context.Background(), RequestConfig{Method: "PATCH", Endpoint: "volume", ID: "1"}, nil)
+	assert.NoError(t, err)
+	require.Len(t, sink.records, 1)
+	assert.Equal(t, "https://foo", sink.records[0].ArrayID)
+	assert.Contains(t, logger.messages, "failed to write audit record of API call [PATCH volume]: disk is full")
+
+	c.SetAuditSink(nil)
+	_, err = c.Query(context.Background(), RequestConfig{Method: "PATCH", Endpoint: "volume", ID: "1"}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, sink.records, 1)
+}
+
+func TestClientIMPL_Audit_BlockedCluster(t *testing.T) {
+	defer func(timeout time.Duration) { auditClusterTimeout = timeout }(auditClusterTimeout)
+	auditClusterTimeout = 100 * time.Millisecond
+
+	c := testClient(t, "https://foo")
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+	blocked := make(chan struct{})
+	var unblocked atomic.Bool
+	httpmock.RegisterResponder("GET", "https://foo/cluster", func(req *http.Request) (*http.Response, error) {
+		if !unblocked.Load() {
+			close(blocked)
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		}
+		return httpmock.NewStringResponse(http.StatusOK, `[{"id": "0", "global_id": "PS1"}]`), nil
+	})
+	httpmock.RegisterResponder("PATCH", "https://foo/volume/1", httpmock.NewStringResponder(http.StatusNoContent, ""))
+	logger := &failoverLogger{}
+	c.SetLogger(logger)
+	sink := &memoryAuditSink{}
+	c.SetAuditSink(sink)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := c.Query(context.Background(), RequestConfig{Method: "PATCH", Endpoint: "volume", ID: "1"}, nil)
+		assert.NoError(t, err)
+	}()
+	<-blocked
+	// the audit lock is not held while the cluster is queried
+	start := time.Now()
+	c.SetAuditSink(sink)
+	assert.Less(t, time.Since(start), auditClusterTimeout)
+
+	// the query is bounded by the timeout, the record carries the management address
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("audit is not bounded by timeout")
+	}
+	require.Len(t, sink.records, 1)
+	assert.Equal(t, "https://foo", sink.records[0].ArrayID)
+	assert.Contains(t, strings.Join(logger.messages, "\n"), "failed to read identity of the cluster")
+
+	// the failed query is retried by the next record
+	unblocked.Store(true)
+	_, err := c.Query(context.Background(), RequestConfig{Method: "PATCH", Endpoint: "volume", ID: "1"}, nil)
+	require.NoError(t, err)
+	require.Len(t, sink.records, 2)
+	assert.Equal(t, "PS1", sink.records[1].ArrayID)
+}
+
+func TestRedactAuditBody(t *testing.T) {
+	assert.Nil(t, redactAuditBody(nil))
+	assert.Nil(t, redactAuditBody((*testResp)(nil)))
+	assert.Nil(t, redactAuditBody(make(chan int)))
+	assert.Equal(t, json.RawMessage(`{"Name":"vol"}`), redactAuditBody(testResp{Name: "vol"}))
+	assert.JSONEq(t, `{"token":"******","nested":{"client_secret":"******","size":1}}`,
+		string(redactAuditBody(map[string]any{"token": "t", "nested": map[string]any{"client_secret": "s", "size": 1}})))
+}
@@ -0,0 +1,263 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// CircuitBreakerConfig defaults
+const (
+	circuitBreakerDefaultFailureThreshold = 5
+	circuitBreakerDefaultOpenTimeout      = 30 * time.Second
+	circuitBreakerDefaultProbeTimeout     = 10 * time.Second
+)
+
+// CircuitState is state of the circuit breaker
+type CircuitState int
+
+const (
+	// CircuitClosed lets requests through and counts their failures
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects requests with ErrCircuitOpen
+	CircuitOpen
+	// CircuitHalfOpen probes the array, other requests are rejected until the probe finishes
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// ErrCircuitOpen is matched by errors.Is for every CircuitOpenError
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is returned without sending the request while the array is considered unhealthy
+type CircuitOpenError struct {
+	APIURL string
+	// time the array is probed again
+	RetryAt time.Time
+}
+
+func (err *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker of %s is open, requests are rejected until %s",
+		err.APIURL, err.RetryAt.Format(time.RFC3339))
+}
+
+// Is makes errors.Is(err, ErrCircuitOpen) true
+func (err *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitBreakerConfig describes when the circuit breaker opens and how it recovers
+type CircuitBreakerConfig struct {
+	// number of consecutive connection errors, timeouts of the client or failure responses which opens
+	// the circuit, 5 if zero
+	FailureThreshold int
+	// http statuses of failure responses, any 5xx if empty
+	FailureStatuses []int
+	// time the circuit stays open before the array is probed, 30s if zero
+	OpenTimeout time.Duration
+	// timeout of the probe request, 10s if zero
+	ProbeTimeout time.Duration
+}
+
+// circuitBreaker keeps state of the circuit of the array, it is shared by all management addresses
+type circuitBreaker struct {
+	config   CircuitBreakerConfig
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+}
+
+func newCircuitBreaker(config CircuitBreakerConfig) *circuitBreaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = circuitBreakerDefaultFailureThreshold
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = circuitBreakerDefaultOpenTimeout
+	}
+	if config.ProbeTimeout <= 0 {
+		config.ProbeTimeout = circuitBreakerDefaultProbeTimeout
+	}
+	config.FailureStatuses = slices.Clone(config.FailureStatuses)
+	return &circuitBreaker{config: config}
+}
+
+// SetCircuitBreaker enables circuit breaker which rejects requests with ErrCircuitOpen after
+// consecutive failures instead of letting every caller wait for its timeout, nil disables it
+func (c *ClientIMPL) SetCircuitBreaker(config *CircuitBreakerConfig) {
+	if config == nil {
+		c.breaker.Store(nil)
+		return
+	}
+	c.breaker.Store(newCircuitBreaker(*config))
+}
+
+// CircuitState returns state of the circuit breaker, CircuitClosed if it is disabled
+func (c *ClientIMPL) CircuitState() CircuitState {
+	cb := c.breaker.Load()
+	if cb == nil {
+		return CircuitClosed
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+// circuitBreakerMiddleware rejects requests while the circuit is open and counts failures of the others
+func (c *ClientIMPL) circuitBreakerMiddleware(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		cb := c.breaker.Load()
+		if cb == nil {
+			return next(req)
+		}
+		if err := c.admitRequest(req.Context(), cb); err != nil {
+			return nil, err
+		}
+		r, err := next(req)
+		c.recordRequestResult(req.Context(), cb, r, err)
+		return r, err
+	}
+}
+
+// admitRequest returns CircuitOpenError if the request must be rejected, the first request after
+// OpenTimeout starts probe of the array in background and is rejected as well
+func (c *ClientIMPL) admitRequest(ctx context.Context, cb *circuitBreaker) error {
+	cb.mu.Lock()
+	state, retryAt := cb.state, cb.openedAt.Add(cb.config.OpenTimeout)
+	if state == CircuitClosed {
+		cb.mu.Unlock()
+		return nil
+	}
+	if state == CircuitHalfOpen || time.Now().Before(retryAt) {
+		cb.mu.Unlock()
+		return &CircuitOpenError{APIURL: c.requestAPIURL(ctx), RetryAt: retryAt}
+	}
+	cb.state = CircuitHalfOpen
+	cb.mu.Unlock()
+	c.circuitStateChanged(ctx, CircuitOpen, CircuitHalfOpen)
+
+	apiURL := c.requestAPIURL(ctx)
+	go c.probeCircuit(context.WithoutCancel(ctx), cb, apiURL)
+	return &CircuitOpenError{APIURL: apiURL, RetryAt: time.Now().Add(cb.config.ProbeTimeout)}
+}
+
+// probeCircuit closes the circuit if the array answers the probe, otherwise it opens the circuit again
+func (c *ClientIMPL) probeCircuit(ctx context.Context, cb *circuitBreaker, apiURL string) {
+	healthy := c.probeArray(ctx, apiURL, cb.config.ProbeTimeout)
+	cb.mu.Lock()
+	if healthy {
+		cb.state, cb.failures = CircuitClosed, 0
+	} else {
+		cb.state, cb.openedAt = CircuitOpen, time.Now()
+	}
+	state := cb.state
+	cb.mu.Unlock()
+	c.circuitStateChanged(ctx, CircuitHalfOpen, state)
+}
+
+// recordRequestResult counts consecutive failures and opens the circuit once they reach the threshold
+func (c *ClientIMPL) recordRequestResult(ctx context.Context, cb *circuitBreaker, r *http.Response, err error) {
+	if err != nil && !isCircuitFailureError(ctx, err) {
+		// e.g. request canceled by the caller tells nothing about the array
+		return
+	}
+	failed := err != nil || cb.isFailureStatus(r.StatusCode)
+	cb.mu.Lock()
+	if cb.state != CircuitClosed {
+		cb.mu.Unlock()
+		return
+	}
+	if !failed {
+		cb.failures = 0
+		cb.mu.Unlock()
+		return
+	}
+	cb.failures++
+	if cb.failures < cb.config.FailureThreshold {
+		cb.mu.Unlock()
+		return
+	}
+	cb.state, cb.openedAt = CircuitOpen, time.Now()
+	cb.mu.Unlock()
+	c.circuitStateChanged(ctx, CircuitClosed, CircuitOpen)
+}
+
+// isCircuitFailureError returns true for connection errors and timeouts of the client, requests canceled
+// by the caller or past deadline of the caller tell nothing about the array
+func isCircuitFailureError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return errors.Is(context.Cause(ctx), errDefaultTimeout)
+	}
+	// ctx is alive, so the deadline is the timeout of the transport
+	return isNetworkError(err) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func (cb *circuitBreaker) isFailureStatus(status int) bool {
+	if len(cb.config.FailureStatuses) == 0 {
+		return status >= http.StatusInternalServerError
+	}
+	return slices.Contains(cb.config.FailureStatuses, status)
+}
+
+// probeArray sends a lightweight request without credentials to the management address, 2xx or 401 response
+// shows the array is back
+func (c *ClientIMPL) probeArray(ctx context.Context, apiURL string, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	probeURL, err := c.prepareRequestURLAt(apiURL, clusterURL, "", "", c.QueryParams().Select("id"))
+	if err != nil {
+		return false
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL, nil)
+	if err != nil {
+		return false
+	}
+	r, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.Info(ctx, "probe of %s failed: %s", apiURL, err.Error())
+		return false
+	}
+	r.Body.Close()
+	// the probe carries no credentials, 401 is the answer of the array serving the REST API
+	return r.StatusCode == http.StatusUnauthorized ||
+		(r.StatusCode >= http.StatusOK && r.StatusCode < http.StatusMultipleChoices)
+}
+
+func (c *ClientIMPL) circuitStateChanged(ctx context.Context, from, to CircuitState) {
+	c.logger.Info(ctx, "circuit breaker of %s changed state from %s to %s", c.APIURL(), from, to)
+	c.metrics().CircuitStateChanged(from, to)
+}
@@ -0,0 +1,249 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func circuitBreakerClient(t *testing.T, config CircuitBreakerConfig) (*ClientIMPL, *failoverLogger, *recordingMetrics) {
+	c := testClient(t, "https://foo")
+	httpmock.ActivateNonDefault(c.httpClient)
+	logger := &failoverLogger{}
+	c.SetLogger(logger)
+	hook := &recordingMetrics{}
+	c.SetMetrics(hook)
+	c.SetCircuitBreaker(&config)
+	return c, logger, hook
+}
+
+func getVolume(c *ClientIMPL) error {
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume", ID: "1"}, &testResp{})
+	return err
+}
+
+func TestClientIMPL_CircuitBreaker_Opens(t *testing.T) {
+	c, logger, hook := circuitBreakerClient(t, CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour})
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://foo/volume/1", httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+	httpmock.RegisterResponder("GET", "https://foo/volume/2", httpmock.NewStringResponder(http.StatusOK, `{"name": "vol"}`))
+
+	// success resets the consecutive failures
+	assert.Error(t, getVolume(c))
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume", ID: "2"}, &testResp{})
+	require.NoError(t, err)
+	assert.Error(t, getVolume(c))
+	assert.Equal(t, CircuitClosed, c.CircuitState())
+
+	assert.Error(t, getVolume(c))
+	assert.Equal(t, CircuitOpen, c.CircuitState())
+
+	err = getVolume(c)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	var openErr *CircuitOpenError
+	require.True(t, errors.As(err, &openErr))
+	assert.Equal(t, "https://foo", openErr.APIURL)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), openErr.RetryAt, time.Minute)
+	assert.Equal(t, 3, httpmock.GetCallCountInfo()["GET https://foo/volume/1"])
+
+	assert.Contains(t, logger.messages, "circuit breaker of https://foo changed state from closed to open")
+	assert.Contains(t, hook.events, "circuit closed -> open")
+}
+
+func TestClientIMPL_CircuitBreaker_ConnectionErrors(t *testing.T) {
+	c, _, _ := circuitBreakerClient(t, CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour})
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://foo/volume/1", httpmock.NewErrorResponder(errDial))
+
+	// requests canceled by the caller are not counted
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 3; i++ {
+		_, err := c.Query(ctx, RequestConfig{Method: "GET", Endpoint: "volume", ID: "1"}, &testResp{})
+		assert.Error(t, err)
+	}
+	assert.Equal(t, CircuitClosed, c.CircuitState())
+
+	assert.Error(t, getVolume(c))
+	assert.Error(t, getVolume(c))
+	assert.Equal(t, CircuitOpen, c.CircuitState())
+	assert.ErrorIs(t, getVolume(c), ErrCircuitOpen)
+}
+
+func TestClientIMPL_CircuitBreaker_Probe(t *testing.T) {
+	c, logger, hook := circuitBreakerClient(t, CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: 20 * time.Millisecond})
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://foo/volume/1", httpmock.NewStringResponder(http.StatusGatewayTimeout, ""))
+	httpmock.RegisterResponder("GET", "https://foo/cluster", httpmock.NewStringResponder(http.StatusBadGateway, ""))
+
+	assert.Error(t, getVolume(c))
+	assert.Equal(t, CircuitOpen, c.CircuitState())
+
+	// the request which starts the probe is rejected without waiting for it, the failed probe opens
+	// the circuit again
+	time.Sleep(30 * time.Millisecond)
+	assert.ErrorIs(t, getVolume(c), ErrCircuitOpen)
+	waitCircuitEvents(t, hook, 3)
+	assert.Equal(t, CircuitOpen, c.CircuitState())
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["GET https://foo/cluster"])
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["GET https://foo/volume/1"])
+
+	// response of something else than the REST API of the array keeps the circuit open
+	httpmock.RegisterResponder("GET", "https://foo/cluster", httpmock.NewStringResponder(http.StatusNotFound, ""))
+	time.Sleep(30 * time.Millisecond)
+	assert.ErrorIs(t, getVolume(c), ErrCircuitOpen)
+	waitCircuitEvents(t, hook, 5)
+	assert.Equal(t, CircuitOpen, c.CircuitState())
+
+	// 401 closes the circuit, the probe is sent without credentials
+	httpmock.RegisterResponder("GET", "https://foo/cluster", func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "id", req.URL.Query().Get("select"))
+		assert.Empty(t, req.Header.Get("Authorization"))
+		return httpmock.NewStringResponse(http.StatusUnauthorized, ""), nil
+	})
+	httpmock.RegisterResponder("GET", "https://foo/volume/1", httpmock.NewStringResponder(http.StatusOK, `{"name": "vol"}`))
+	time.Sleep(30 * time.Millisecond)
+	assert.ErrorIs(t, getVolume(c), ErrCircuitOpen)
+	waitCircuitEvents(t, hook, 7)
+	assert.Equal(t, CircuitClosed, c.CircuitState())
+	assert.NoError(t, getVolume(c))
+
+	assert.Contains(t, logger.messages, "circuit breaker of https://foo changed state from half-open to closed")
+	assert.Equal(t, []string{
+		"circuit closed -> open", "circuit open -> half-open", "circuit half-open -> open",
+		"circuit open -> half-open", "circuit half-open -> open",
+		"circuit open -> half-open", "circuit half-open -> closed",
+	}, circuitEvents(hook))
+
+	c.SetCircuitBreaker(nil)
+	assert.Equal(t, CircuitClosed, c.CircuitState())
+}
+
+func TestClientIMPL_CircuitBreaker_ProbeAPIURL(t *testing.T) {
+	c, _, hook := circuitBreakerClient(t, CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Millisecond})
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://bar/cluster", httpmock.NewStringResponder(http.StatusOK, `[{"id": "0"}]`))
+	cb := c.breaker.Load()
+	c.recordRequestResult(context.Background(), cb, nil, errDial)
+	assert.Equal(t, CircuitOpen, c.CircuitState())
+
+	// the probe is sent to the management address of the request, e.g. during failover
+	time.Sleep(5 * time.Millisecond)
+	err := c.admitRequest(withAPIURL(context.Background(), "https://bar"), cb)
+	var openErr *CircuitOpenError
+	require.True(t, errors.As(err, &openErr))
+	assert.Equal(t, "https://bar", openErr.APIURL)
+	waitCircuitEvents(t, hook, 3)
+	assert.Equal(t, CircuitClosed, c.CircuitState())
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["GET https://bar/cluster"])
+}
+
+func TestClientIMPL_CircuitBreaker_ServerErrors(t *testing.T) {
+	c, _, _ := circuitBreakerClient(t, CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour})
+	defer httpmock.DeactivateAndReset()
+
+	for _, status := range []int{http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		c.SetCircuitBreaker(&CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour})
+		httpmock.RegisterResponder("GET", "https://foo/volume/1", httpmock.NewStringResponder(status, ""))
+		assert.Error(t, getVolume(c))
+		assert.Error(t, getVolume(c))
+		assert.Equal(t, CircuitOpen, c.CircuitState(), status)
+	}
+
+	// other responses than FailureStatuses are not counted
+	c.SetCircuitBreaker(&CircuitBreakerConfig{
+		FailureThreshold: 2, OpenTimeout: time.Hour, FailureStatuses: []int{http.StatusServiceUnavailable},
+	})
+	httpmock.RegisterResponder("GET", "https://foo/volume/1", httpmock.NewStringResponder(http.StatusInternalServerError, ""))
+	for i := 0; i < 3; i++ {
+		assert.Error(t, getVolume(c))
+	}
+	assert.Equal(t, CircuitClosed, c.CircuitState())
+}
+
+func TestClientIMPL_CircuitBreaker_Timeouts(t *testing.T) {
+	c, _, _ := circuitBreakerClient(t, CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour})
+	defer httpmock.DeactivateAndReset()
+	c.defaultTimeout = 20 * time.Millisecond
+	httpmock.RegisterResponder("GET", "https://foo/volume/1", func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+
+	// deadline of the caller tells nothing about the array
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		_, err := c.Query(ctx, RequestConfig{Method: "GET", Endpoint: "volume", ID: "1"}, &testResp{})
+		cancel()
+		assert.Error(t, err)
+	}
+	assert.Equal(t, CircuitClosed, c.CircuitState())
+
+	// default timeout of the client is a failure
+	assert.Error(t, getVolume(c))
+	assert.Error(t, getVolume(c))
+	assert.Equal(t, CircuitOpen, c.CircuitState())
+
+	// so is timeout of the transport
+	c.SetCircuitBreaker(&CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour})
+	c.defaultTimeout = time.Second
+	httpmock.RegisterResponder("GET", "https://foo/volume/1",
+		httpmock.NewErrorResponder(fmt.Errorf("awaiting headers: %w", context.DeadlineExceeded)))
+	assert.Error(t, getVolume(c))
+	assert.Error(t, getVolume(c))
+	assert.Equal(t, CircuitOpen, c.CircuitState())
+}
+
+// waitCircuitEvents waits for state changes made by the probe in background
+func waitCircuitEvents(t *testing.T, hook *recordingMetrics, n int) {
+	assert.Eventually(t, func() bool { return len(circuitEvents(hook)) >= n }, time.Second, time.Millisecond)
+}
+
+func circuitEvents(hook *recordingMetrics) []string {
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	var res []string
+	for _, e := range hook.events {
+		if strings.HasPrefix(e, "circuit ") {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+func TestCircuitState_String(t *testing.T) {
+	assert.Equal(t, "half-open", CircuitHalfOpen.String())
+	assert.Equal(t, "CircuitState(7)", CircuitState(7).String())
+	cb := newCircuitBreaker(CircuitBreakerConfig{})
+	assert.Equal(t, CircuitBreakerConfig{
+		FailureThreshold: circuitBreakerDefaultFailureThreshold,
+		OpenTimeout:      circuitBreakerDefaultOpenTimeout,
+		ProbeTimeout:     circuitBreakerDefaultProbeTimeout,
+	}, cb.config)
+}
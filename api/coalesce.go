@@ -0,0 +1,121 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// inFlightCall is a GET request shared by callers which sent identical requests at the same time
+type inFlightCall struct {
+	key     string
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+	body    json.RawMessage
+	meta    RespMeta
+	err     error
+}
+
+// SetRequestCoalescing enables merging of identical concurrent GET requests into a single API call,
+// requests are identical if they have the same endpoint, id, action, query string and headers
+func (c *ClientIMPL) SetRequestCoalescing(enabled bool) {
+	c.coalescing.Store(enabled)
+}
+
+// coalescingKey identifies the request, per-request headers are part of it as they may change the response
+func coalescingKey(config RequestConfig) string {
+	var query string
+	if config.QueryParams != nil {
+		query = config.QueryParams.Encode()
+	}
+	headers := make([]string, 0, len(config.Headers))
+	for name, values := range config.Headers {
+		headers = append(headers, http.CanonicalHeaderKey(name)+": "+strings.Join(values, ","))
+	}
+	sort.Strings(headers)
+	return strings.Join(append([]string{config.Endpoint, config.ID, config.Action, query}, headers...), "\x00")
+}
+
+// coalescedQuery waits for an identical request which is in flight or sends a new one, every caller
+// decodes its own copy of the response. The shared request is canceled once all callers gave up waiting.
+func (c *ClientIMPL) coalescedQuery(ctx context.Context, config RequestConfig, resp interface{}) (RespMeta, error) {
+	key := coalescingKey(config)
+	c.inFlightMutex.Lock()
+	call, ok := c.inFlight[key]
+	if ok {
+		call.waiters++
+		c.inFlightMutex.Unlock()
+		c.logger.Debug(ctx, "%sjoined API call [%s %s] which is in flight", c.prepareTraceMsg(ctx), config.Method, config.Endpoint)
+	} else {
+		// the shared call keeps values of the caller which started it, e.g. trace id,
+		// but it is canceled only when all callers are gone
+		sharedCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &inFlightCall{key: key, done: make(chan struct{}), cancel: cancel, waiters: 1}
+		if c.inFlight == nil {
+			c.inFlight = map[string]*inFlightCall{}
+		}
+		c.inFlight[key] = call
+		c.inFlightMutex.Unlock()
+		go c.runInFlightCall(sharedCtx, config, call)
+	}
+
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		c.leaveInFlightCall(call)
+		return RespMeta{}, ctx.Err()
+	}
+	if call.err != nil || resp == nil || len(call.body) == 0 {
+		return call.meta, call.err
+	}
+	return call.meta, json.Unmarshal(call.body, resp)
+}
+
+func (c *ClientIMPL) runInFlightCall(ctx context.Context, config RequestConfig, call *inFlightCall) {
+	defer call.cancel()
+	call.meta, call.err = c.query(ctx, config, &call.body)
+	c.inFlightMutex.Lock()
+	c.forgetInFlightCall(call)
+	c.inFlightMutex.Unlock()
+	close(call.done)
+}
+
+// leaveInFlightCall cancels the shared request if no caller waits for it anymore
+func (c *ClientIMPL) leaveInFlightCall(call *inFlightCall) {
+	c.inFlightMutex.Lock()
+	defer c.inFlightMutex.Unlock()
+	call.waiters--
+	if call.waiters == 0 {
+		call.cancel()
+		// later callers must not join the canceled call
+		c.forgetInFlightCall(call)
+	}
+}
+
+// forgetInFlightCall removes the call from calls new callers can join, c.inFlightMutex must be held
+func (c *ClientIMPL) forgetInFlightCall(call *inFlightCall) {
+	if c.inFlight[call.key] == call {
+		delete(c.inFlight, call.key)
+	}
+}
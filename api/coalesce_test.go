@@ -0,0 +1,255 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type coalescedResp struct {
+	Name  string
+	Items []string
+}
+
+// blockingResponder counts calls and holds responses until release is closed
+func blockingResponder(calls *atomic.Int32, release <-chan struct{}) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		select {
+		case <-release:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		return httpmock.NewStringResponse(http.StatusOK, `{"name": "vol", "items": ["a", "b"]}`), nil
+	}
+}
+
+func TestClientIMPL_RequestCoalescing(t *testing.T) {
+	c := testClient(t, "https://foo")
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+	c.SetRequestCoalescing(true)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	httpmock.RegisterResponder("GET", "https://foo/volume/1", blockingResponder(&calls, release))
+
+	const callers = 10
+	results := make([]coalescedResp, callers)
+	errs := make([]error, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = c.Query(context.Background(),
+				RequestConfig{Method: "GET", Endpoint: "volume", ID: "1"}, &results[i])
+		}()
+	}
+	assert.Eventually(t, func() bool {
+		c.inFlightMutex.Lock()
+		defer c.inFlightMutex.Unlock()
+		return len(c.inFlight) == 1 && c.inFlight[coalescingKey(RequestConfig{Endpoint: "volume", ID: "1"})].waiters == callers
+	}, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for i := 0; i < callers; i++ {
+		require.NoError(t, errs[i])
+		assert.Equal(t, coalescedResp{Name: "vol", Items: []string{"a", "b"}}, results[i])
+	}
+	// every caller has its own copy
+	results[0].Items[0] = "changed"
+	assert.Equal(t, "a", results[1].Items[0])
+
+	// finished calls are not reused
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume", ID: "1"}, &results[0])
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClientIMPL_RequestCoalescing_DifferentRequests(t *testing.T) {
+	c := testClient(t, "https://foo")
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+	c.SetRequestCoalescing(true)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	httpmock.RegisterResponder("GET", "=~^https://foo/volume", blockingResponder(&calls, release))
+	httpmock.RegisterResponder("POST", "https://foo/volume", blockingResponder(&calls, release))
+
+	configs := []RequestConfig{
+		{Method: "GET", Endpoint: "volume"},
+		{Method: "GET", Endpoint: "volume", QueryParams: c.QueryParams().Select("id")},
+		{Method: "GET", Endpoint: "volume", QueryParams: c.QueryParams().Select("name")},
+		{Method: "GET", Endpoint: "volume", ID: "1"},
+		{Method: "GET", Endpoint: "volume", ID: "1", Action: "snapshot"},
+		{Method: "POST", Endpoint: "volume"},
+		{Method: "POST", Endpoint: "volume"},
+	}
+	var wg sync.WaitGroup
+	for _, cfg := range configs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Query(context.Background(), cfg, &coalescedResp{})
+			assert.NoError(t, err)
+		}()
+	}
+	assert.Eventually(t, func() bool { return calls.Load() == int32(len(configs)) }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+}
+
+func TestClientIMPL_RequestCoalescing_Headers(t *testing.T) {
+	c := testClient(t, "https://foo")
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+	c.SetRequestCoalescing(true)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	languages := make(chan string, 2)
+	httpmock.RegisterResponder("GET", "https://foo/volume/1", func(req *http.Request) (*http.Response, error) {
+		languages <- req.Header.Get("Accept-Language")
+		return blockingResponder(&calls, release)(req)
+	})
+
+	var wg sync.WaitGroup
+	for _, language := range []string{"de-DE", "fr-FR"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Query(context.Background(), RequestConfig{
+				Method: "GET", Endpoint: "volume", ID: "1", Headers: http.Header{"Accept-Language": {language}},
+			}, &coalescedResp{})
+			assert.NoError(t, err)
+		}()
+	}
+	assert.Eventually(t, func() bool { return calls.Load() == 2 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(languages)
+	var sent []string
+	for language := range languages {
+		sent = append(sent, language)
+	}
+	assert.ElementsMatch(t, []string{"de-DE", "fr-FR"}, sent)
+
+	// the same headers in any order and case are one request
+	a := RequestConfig{Endpoint: "volume", Headers: http.Header{"Accept-Language": {"de-DE"}, "X-A": {"1"}}}
+	b := RequestConfig{Endpoint: "volume", Headers: http.Header{"x-a": {"1"}, "accept-language": {"de-DE"}}}
+	assert.Equal(t, coalescingKey(a), coalescingKey(b))
+	assert.NotEqual(t, coalescingKey(a), coalescingKey(RequestConfig{Endpoint: "volume"}))
+}
+
+func TestClientIMPL_RequestCoalescing_Cancel(t *testing.T) {
+	c := testClient(t, "https://foo")
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+	c.SetRequestCoalescing(true)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	httpmock.RegisterResponder("GET", "https://foo/volume", blockingResponder(&calls, release))
+	cfg := RequestConfig{Method: "GET", Endpoint: "volume"}
+
+	// the caller which started the call gives up, the other one still gets the response
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := c.Query(firstCtx, cfg, &coalescedResp{})
+		firstErr <- err
+	}()
+	assert.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	var resp coalescedResp
+	secondErr := make(chan error)
+	go func() {
+		_, err := c.Query(context.Background(), cfg, &resp)
+		secondErr <- err
+	}()
+	assert.Eventually(t, func() bool {
+		c.inFlightMutex.Lock()
+		defer c.inFlightMutex.Unlock()
+		return c.inFlight[coalescingKey(cfg)].waiters == 2
+	}, time.Second, time.Millisecond)
+	cancelFirst()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+	close(release)
+	require.NoError(t, <-secondErr)
+	assert.Equal(t, "vol", resp.Name)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// the shared request is canceled once nobody waits for it
+	httpmock.RegisterResponder("GET", "https://foo/volume", blockingResponder(&calls, make(chan struct{})))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	errCh := make(chan error)
+	go func() {
+		_, err := c.Query(ctx, cfg, &coalescedResp{})
+		errCh <- err
+	}()
+	var call *inFlightCall
+	assert.Eventually(t, func() bool {
+		c.inFlightMutex.Lock()
+		defer c.inFlightMutex.Unlock()
+		call = c.inFlight[coalescingKey(cfg)]
+		return call != nil
+	}, time.Second, time.Millisecond)
+	assert.True(t, errors.Is(<-errCh, context.DeadlineExceeded))
+	<-call.done
+	assert.ErrorIs(t, call.err, context.Canceled)
+	c.inFlightMutex.Lock()
+	assert.Empty(t, c.inFlight)
+	c.inFlightMutex.Unlock()
+}
+
+func TestClientIMPL_RequestCoalescing_Disabled(t *testing.T) {
+	c := testClient(t, "https://foo")
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	httpmock.RegisterResponder("GET", "https://foo/volume", blockingResponder(&calls, release))
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume"}, &coalescedResp{})
+			assert.NoError(t, err)
+		}()
+	}
+	assert.Eventually(t, func() bool { return calls.Load() == 3 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+}
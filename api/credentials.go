@@ -0,0 +1,175 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials are username and password used to log in to PowerStore
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// String hides the password, so credentials can't leak to logs
+func (c Credentials) String() string {
+	return fmt.Sprintf("{Username:%s Password:******}", c.Username)
+}
+
+// GoString hides the password when credentials are printed with %#v
+func (c Credentials) GoString() string {
+	return fmt.Sprintf("api.Credentials{Username:%q, Password:\"******\"}", c.Username)
+}
+
+func (c Credentials) valid() bool {
+	return c.Username != "" && c.Password != ""
+}
+
+// CredentialProvider returns credentials the client logs in with, it is called for every login,
+// including the one after the array rejected previous credentials, so rotated passwords are picked up
+type CredentialProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// CredentialProviderFunc adapts a function to CredentialProvider
+type CredentialProviderFunc func(ctx context.Context) (Credentials, error)
+
+// Credentials calls f
+func (f CredentialProviderFunc) Credentials(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// StaticCredentials always returns the same credentials
+type StaticCredentials Credentials
+
+// Credentials returns the credentials
+func (s StaticCredentials) Credentials(_ context.Context) (Credentials, error) {
+	return Credentials(s), nil
+}
+
+// EnvCredentials reads credentials from env variables on every login
+type EnvCredentials struct {
+	UsernameEnv string
+	PasswordEnv string
+}
+
+// Credentials reads the env variables
+func (e EnvCredentials) Credentials(_ context.Context) (Credentials, error) {
+	creds := Credentials{Username: os.Getenv(e.UsernameEnv), Password: os.Getenv(e.PasswordEnv)}
+	if !creds.valid() {
+		return creds, fmt.Errorf("env variables %s and %s must be set", e.UsernameEnv, e.PasswordEnv)
+	}
+	return creds, nil
+}
+
+// FileCredentials reads credentials from files, e.g. Kubernetes secret mounts, files are read again
+// once their modification time or size changes. Trailing line breaks are removed.
+type FileCredentials struct {
+	// file with username, Username is used when empty
+	UsernameFile string
+	// file with password, Password is used when empty
+	PasswordFile string
+	Username     string
+	Password     string
+
+	mu    sync.Mutex
+	files map[string]watchedFile
+}
+
+type watchedFile struct {
+	modTime time.Time
+	size    int64
+	content string
+}
+
+// Credentials returns content of the files, they are read again if they have changed since the previous call
+func (f *FileCredentials) Credentials(_ context.Context) (Credentials, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	creds := Credentials{Username: f.Username, Password: f.Password}
+	var err error
+	if f.UsernameFile != "" {
+		if creds.Username, err = f.read(f.UsernameFile); err != nil {
+			return Credentials{}, fmt.Errorf("failed to read username: %w", err)
+		}
+	}
+	if f.PasswordFile != "" {
+		if creds.Password, err = f.read(f.PasswordFile); err != nil {
+			return Credentials{}, fmt.Errorf("failed to read password: %w", err)
+		}
+	}
+	if !creds.valid() {
+		return Credentials{}, errors.New("username and password must not be empty")
+	}
+	return creds, nil
+}
+
+func (f *FileCredentials) read(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if cached, ok := f.files[path]; ok && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.content, nil
+	}
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return "", err
+	}
+	if f.files == nil {
+		f.files = map[string]watchedFile{}
+	}
+	content := strings.TrimRight(string(data), "\r\n")
+	f.files[path] = watchedFile{modTime: info.ModTime(), size: info.Size(), content: content}
+	return content, nil
+}
+
+// getCredentials returns credentials of the current session
+func (c *ClientIMPL) getCredentials() Credentials {
+	c.sessionMutex.RLock()
+	defer c.sessionMutex.RUnlock()
+	return c.credentials
+}
+
+// loadCredentials asks the provider for credentials before a login
+func (c *ClientIMPL) loadCredentials(ctx context.Context) error {
+	if c.credentialProvider == nil {
+		return nil
+	}
+	creds, err := c.credentialProvider.Credentials(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get credentials: %w", err)
+	}
+	c.sessionMutex.Lock()
+	defer c.sessionMutex.Unlock()
+	if c.credentials != creds {
+		if c.credentials.valid() {
+			c.logger.Info(ctx, "credentials have changed, logging in as %s", creds.Username)
+		}
+		c.credentials = creds
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentials_Format(t *testing.T) {
+	creds := Credentials{Username: "admin", Password: "secret"}
+	for _, s := range []string{fmt.Sprint(creds), fmt.Sprintf("%v", creds), fmt.Sprintf("%+v", creds), fmt.Sprintf("%#v", creds)} {
+		assert.Contains(t, s, "admin")
+		assert.NotContains(t, s, "secret")
+	}
+}
+
+func TestEnvCredentials(t *testing.T) {
+	provider := EnvCredentials{UsernameEnv: "TEST_PS_USERNAME", PasswordEnv: "TEST_PS_PASSWORD"}
+	_, err := provider.Credentials(context.Background())
+	assert.EqualError(t, err, "env variables TEST_PS_USERNAME and TEST_PS_PASSWORD must be set")
+
+	t.Setenv("TEST_PS_USERNAME", "admin")
+	t.Setenv("TEST_PS_PASSWORD", "secret")
+	creds, err := provider.Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "admin", Password: "secret"}, creds)
+}
+
+func TestFileCredentials(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "password")
+	provider := &FileCredentials{Username: "admin", PasswordFile: passwordFile}
+
+	_, err := provider.Credentials(ctx)
+	assert.ErrorContains(t, err, "failed to read password")
+
+	require.NoError(t, os.WriteFile(passwordFile, []byte("secret\n"), 0o600))
+	creds, err := provider.Credentials(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "admin", Password: "secret"}, creds)
+
+	require.NoError(t, os.WriteFile(passwordFile, []byte("rotated\n"), 0o600))
+	require.NoError(t, os.Chtimes(passwordFile, time.Now(), time.Now().Add(time.Minute)))
+	creds, err = provider.Credentials(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", creds.Password)
+
+	require.NoError(t, os.WriteFile(passwordFile, []byte("\n"), 0o600))
+	_, err = provider.Credentials(ctx)
+	assert.EqualError(t, err, "username and password must not be empty")
+}
+
+// rotatingCredentials returns the current password and counts calls
+type rotatingCredentials struct {
+	mu       sync.Mutex
+	password string
+	calls    int
+}
+
+func (r *rotatingCredentials) Credentials(_ context.Context) (Credentials, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls++
+	return Credentials{Username: "admin", Password: r.password}, nil
+}
+
+func TestClientIMPL_CredentialRotation(t *testing.T) {
+	apiURL := "https://foo"
+	password := "old"
+	checkAuth := func(req *http.Request) bool {
+		username, pass, ok := req.BasicAuth()
+		return ok && username == "admin" && pass == password
+	}
+	transport := httpmock.NewMockTransport()
+	transport.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, loginSessionURL),
+		func(req *http.Request) (*http.Response, error) {
+			if !checkAuth(req) {
+				return httpmock.NewStringResponse(http.StatusUnauthorized, ""), nil
+			}
+			resp := httpmock.NewStringResponse(http.StatusOK, `[{"id": "1"}]`)
+			resp.Header.Set(dellEmcToken, "token-"+password)
+			return resp, nil
+		})
+	transport.RegisterResponder("GET", apiURL+"/volume",
+		func(req *http.Request) (*http.Response, error) {
+			if !checkAuth(req) {
+				return httpmock.NewStringResponse(http.StatusUnauthorized, ""), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, `{}`), nil
+		})
+
+	provider := &rotatingCredentials{password: "old"}
+	cfg := testConfig(apiURL, transport)
+	cfg.Username, cfg.Password = "", ""
+	cfg.Credentials = provider
+	c, err := NewWithContext(context.Background(), cfg)
+	require.NoError(t, err)
+	logger := &failoverLogger{}
+	c.SetLogger(logger)
+
+	// the password is rotated on the array and in the provider
+	password = "new"
+	provider.mu.Lock()
+	provider.password = "new"
+	provider.mu.Unlock()
+
+	_, err = c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume"}, &struct{}{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, provider.calls)
+	assert.Equal(t, "token-new", c.getToken())
+	assert.Equal(t, []string{"credentials have changed, logging in as admin"}, logger.messages)
+
+	// the array rejects credentials returned by the provider, the request fails after a single login
+	password = "newer"
+	_, err = c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume"}, &struct{}{})
+	var apiErr *ErrorMsg
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.Equal(t, 3, provider.calls)
+	for _, msg := range logger.messages {
+		assert.False(t, strings.Contains(msg, "new"), msg)
+	}
+}
+
+func TestNewWithContext_CredentialProviderError(t *testing.T) {
+	cfg := testConfig("https://foo", httpmock.NewMockTransport())
+	cfg.Credentials = CredentialProviderFunc(func(_ context.Context) (Credentials, error) {
+		return Credentials{}, errors.New("vault is sealed")
+	})
+	_, err := NewWithContext(context.Background(), cfg)
+	assert.EqualError(t, err, "failed to get credentials: vault is sealed")
+
+	cfg.Credentials = nil
+	cfg.Password = ""
+	_, err = NewWithContext(context.Background(), cfg)
+	assert.ErrorContains(t, err, "Missing endpoint, username, or password param")
+}
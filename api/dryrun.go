@@ -0,0 +1,207 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// dryRunIDPrefix starts placeholder ids of objects which would be created by the planned requests
+const dryRunIDPrefix = "dry-run-"
+
+// PlannedRequest is a mutating request which was recorded instead of being sent in dry-run mode
+type PlannedRequest struct {
+	Method   string          `json:"method"`
+	Endpoint string          `json:"endpoint"`
+	ID       string          `json:"id,omitempty"`
+	Action   string          `json:"action,omitempty"`
+	Query    string          `json:"query,omitempty"`
+	Body     json.RawMessage `json:"body,omitempty"`
+	// placeholder id returned to the caller of a POST request
+	PlaceholderID string `json:"placeholder_id,omitempty"`
+}
+
+// path returns endpoint, id and action of the request joined like in the request URL
+func (r PlannedRequest) path() string {
+	parts := []string{r.Endpoint}
+	if r.ID != "" {
+		parts = append(parts, r.ID)
+	}
+	if r.Action != "" {
+		parts = append(parts, r.Action)
+	}
+	p := strings.Join(parts, "/")
+	if r.Query != "" {
+		p += "?" + r.Query
+	}
+	return p
+}
+
+// DryRunPlan collects mutating requests of a client in dry-run mode, it is safe for concurrent use
+type DryRunPlan struct {
+	mu       sync.Mutex
+	requests []PlannedRequest
+	lastID   int
+}
+
+// NewDryRunPlan returns an empty plan
+func NewDryRunPlan() *DryRunPlan {
+	return &DryRunPlan{}
+}
+
+// Requests returns a copy of the recorded requests in the order they were made
+func (p *DryRunPlan) Requests() []PlannedRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]PlannedRequest(nil), p.requests...)
+}
+
+// Len returns number of the recorded requests
+func (p *DryRunPlan) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.requests)
+}
+
+// Reset removes the recorded requests, placeholder ids keep growing to stay unique
+func (p *DryRunPlan) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = nil
+}
+
+// MarshalJSON exports the plan as a JSON array of the recorded requests
+func (p *DryRunPlan) MarshalJSON() ([]byte, error) {
+	requests := p.Requests()
+	if requests == nil {
+		requests = []PlannedRequest{}
+	}
+	return json.Marshal(requests)
+}
+
+// Diff returns human-readable plan, one request per line prefixed with
+// "+" for creation, "~" for modification, "-" for deletion and "*" for other actions
+func (p *DryRunPlan) Diff() string {
+	var b strings.Builder
+	for _, r := range p.Requests() {
+		fmt.Fprintf(&b, "%s %s %s", diffMarker(r), r.Method, r.path())
+		if r.PlaceholderID != "" {
+			fmt.Fprintf(&b, " => %s", r.PlaceholderID)
+		}
+		b.WriteString("\n")
+		if len(r.Body) > 0 {
+			fmt.Fprintf(&b, "    %s\n", r.Body)
+		}
+	}
+	return b.String()
+}
+
+func diffMarker(r PlannedRequest) string {
+	switch {
+	case r.Method == http.MethodDelete:
+		return "-"
+	case r.Method == http.MethodPatch || r.Method == http.MethodPut:
+		return "~"
+	case r.Method == http.MethodPost && r.ID == "" && r.Action == "":
+		return "+"
+	default:
+		return "*"
+	}
+}
+
+// record adds the request to the plan and assigns a placeholder id to POST requests
+func (p *DryRunPlan) record(config RequestConfig) (PlannedRequest, error) {
+	r := PlannedRequest{
+		Method:   config.Method,
+		Endpoint: config.Endpoint,
+		ID:       config.ID,
+		Action:   config.Action,
+	}
+	if config.QueryParams != nil {
+		r.Query = config.QueryParams.Encode()
+	}
+	if config.Body != nil {
+		body, err := json.Marshal(config.Body)
+		if err != nil {
+			return r, fmt.Errorf("dry run: can't encode request body: %w", err)
+		}
+		// typed nil pointers are encoded as null
+		if string(body) != "null" {
+			r.Body = body
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if r.Method == http.MethodPost {
+		p.lastID++
+		r.PlaceholderID = fmt.Sprintf("%s%d", dryRunIDPrefix, p.lastID)
+	}
+	p.requests = append(p.requests, r)
+	return r, nil
+}
+
+// IsDryRunID returns true if id is a placeholder returned by a client in dry-run mode
+func IsDryRunID(id string) bool {
+	return strings.HasPrefix(id, dryRunIDPrefix)
+}
+
+// SetDryRun enables dry-run mode, GET requests are still sent but mutating requests are recorded
+// into the plan and succeed without reaching the array. Passing nil disables dry-run mode
+func (c *ClientIMPL) SetDryRun(plan *DryRunPlan) {
+	c.dryRun.Store(plan)
+}
+
+// DryRun returns the plan of the client in dry-run mode, nil if dry-run mode is disabled
+func (c *ClientIMPL) DryRun() *DryRunPlan {
+	return c.dryRun.Load()
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// dryRunQuery records the request into the plan and returns synthetic success,
+// responses of POST requests get the placeholder id if they have an "id" field
+func (c *ClientIMPL) dryRunQuery(ctx context.Context, plan *DryRunPlan, config RequestConfig, resp interface{}) (RespMeta, error) {
+	r, err := plan.record(config)
+	if err != nil {
+		return RespMeta{}, err
+	}
+	c.logger.Info(ctx, "%sdry run: API call [%s %s] was not sent", c.prepareTraceMsg(ctx), config.Method, r.path())
+	if r.PlaceholderID == "" {
+		return RespMeta{Status: http.StatusNoContent}, nil
+	}
+	if resp != nil {
+		// responses without "id" field, e.g. EmptyResponse, are left as they are
+		_ = json.Unmarshal([]byte(fmt.Sprintf(`{"id": %q}`, r.PlaceholderID)), resp)
+	}
+	if r.ID == "" && r.Action == "" {
+		return RespMeta{Status: http.StatusCreated}, nil
+	}
+	return RespMeta{Status: http.StatusOK}, nil
+}
@@ -0,0 +1,124 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dryRunCreateResp struct {
+	ID string `json:"id"`
+}
+
+func TestClientIMPL_DryRun(t *testing.T) {
+	c := testClient(t, "https://foo")
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://foo/volume/1",
+		httpmock.NewStringResponder(http.StatusOK, `{"name": "vol"}`))
+
+	plan := NewDryRunPlan()
+	c.SetDryRun(plan)
+	assert.Same(t, plan, c.DryRun())
+
+	var vol testResp
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume", ID: "1"}, &vol)
+	require.NoError(t, err)
+	assert.Equal(t, "vol", vol.Name)
+
+	var created dryRunCreateResp
+	meta, err := c.Query(context.Background(), RequestConfig{
+		Method: "POST", Endpoint: "volume", Body: map[string]any{"name": "new", "size": 1048576},
+	}, &created)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, meta.Status)
+	assert.Equal(t, "dry-run-1", created.ID)
+	assert.True(t, IsDryRunID(created.ID))
+
+	var empty string
+	meta, err = c.Query(context.Background(), RequestConfig{
+		Method: "PATCH", Endpoint: "volume", ID: "1", Body: map[string]any{"size": 2097152},
+	}, &empty)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, meta.Status)
+
+	qp := c.QueryParams()
+	qp.RawArg("force", "true")
+	_, err = c.Query(context.Background(), RequestConfig{
+		Method: "DELETE", Endpoint: "volume", ID: "1", QueryParams: qp,
+	}, nil)
+	require.NoError(t, err)
+
+	var cloned dryRunCreateResp
+	meta, err = c.Query(context.Background(), RequestConfig{Method: "POST", Endpoint: "volume", ID: "1", Action: "clone"}, &cloned)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, meta.Status)
+	assert.Equal(t, "dry-run-2", cloned.ID)
+
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+	requests := plan.Requests()
+	require.Len(t, requests, 4)
+	assert.Equal(t, PlannedRequest{
+		Method: "POST", Endpoint: "volume", Body: json.RawMessage(`{"name":"new","size":1048576}`),
+		PlaceholderID: "dry-run-1",
+	}, requests[0])
+	assert.Equal(t, "force=true", requests[2].Query)
+
+	assert.Equal(t, `+ POST volume => dry-run-1
+    {"name":"new","size":1048576}
+~ PATCH volume/1
+    {"size":2097152}
+- DELETE volume/1?force=true
+* POST volume/1/clone => dry-run-2
+`, plan.Diff())
+
+	data, err := json.Marshal(plan)
+	require.NoError(t, err)
+	var exported []PlannedRequest
+	require.NoError(t, json.Unmarshal(data, &exported))
+	assert.Equal(t, requests, exported)
+
+	plan.Reset()
+	assert.Equal(t, 0, plan.Len())
+	data, err = json.Marshal(plan)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(data))
+
+	c.SetDryRun(nil)
+	httpmock.RegisterResponder("DELETE", "https://foo/volume/1", httpmock.NewStringResponder(http.StatusNoContent, ""))
+	_, err = c.Query(context.Background(), RequestConfig{Method: "DELETE", Endpoint: "volume", ID: "1"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, plan.Len())
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+}
+
+func TestClientIMPL_DryRun_BadBody(t *testing.T) {
+	c := testClient(t, "https://foo")
+	c.SetDryRun(NewDryRunPlan())
+	_, err := c.Query(context.Background(), RequestConfig{Method: "POST", Endpoint: "volume", Body: make(chan int)}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 0, c.DryRun().Len())
+}
@@ -0,0 +1,202 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ErrorMessage is a single entry of PowerStore error response
+type ErrorMessage struct {
+	// PowerStore error code, e.g. 0xE0A08001000E
+	Code      string   `json:"code"`
+	Severity  string   `json:"severity"`
+	Message   string   `json:"message_l10n"`
+	Arguments []string `json:"arguments"`
+}
+
+// ErrorCondition is a class of PowerStore errors which can be matched with errors.Is
+type ErrorCondition struct {
+	msg string
+}
+
+func (e *ErrorCondition) Error() string {
+	return e.msg
+}
+
+// Sentinel errors which ErrorMsg matches with errors.Is
+var (
+	ErrNotFound                        = &ErrorCondition{"resource not found"}
+	ErrBadRange                        = &ErrorCondition{"requested range is not satisfiable"}
+	ErrNameAlreadyInUse                = &ErrorCondition{"name is already in use"}
+	ErrHostNotAttachedToVolume         = &ErrorCondition{"host is not attached to volume"}
+	ErrVolumeAttachedToHost            = &ErrorCondition{"volume is attached to host"}
+	ErrVolumeDetachedFromHost          = &ErrorCondition{"volume is detached from host"}
+	ErrHostAlreadyRemovedFromNFSExport = &ErrorCondition{"host is already removed from NFS export"}
+	ErrHostAlreadyPresentInNFSExport   = &ErrorCondition{"host is already present in NFS export"}
+	ErrFailoverFromDestination         = &ErrorCondition{"unable to failover from destination"}
+	ErrReplicationSessionExists        = &ErrorCondition{"replication session is already created"}
+	ErrVolumeNotInVolumeGroup          = &ErrorCondition{"volume is not part of the volume group"}
+	ErrFSCreationLimitReached          = &ErrorCondition{"file system creation limit is reached"}
+)
+
+var (
+	errorCodesMu sync.RWMutex
+	// errorCodes maps normalized PowerStore error codes to conditions, extend it with RegisterErrorCode
+	errorCodes = map[string]*ErrorCondition{
+		// "Could not find the <resource> with ID: <id>"
+		normalizeErrorCode("0xE0A08001000E"): ErrNotFound,
+		// "Invalid instance.", reported with 400 for ids of deleted or never existing resources
+		normalizeErrorCode("0xE04040020002"): ErrNotFound,
+	}
+	// errorPatterns classify messages of errors which codes are not registered. message_l10n is localized and
+	// may change between releases, so the patterns are only a fallback, codes of responses captured from
+	// an array belong to errorCodes. Zero status matches any status code
+	errorPatterns = []struct {
+		status    int
+		re        *regexp.Regexp
+		condition *ErrorCondition
+	}{
+		{http.StatusUnprocessableEntity, regexp.MustCompile(`limit of \d+ file systems for the NAS server`), ErrFSCreationLimitReached},
+		{http.StatusUnprocessableEntity, regexp.MustCompile(`not part`), ErrVolumeNotInVolumeGroup},
+		{0, regexp.MustCompile(`(?i)\bname\b.*\balready (in use|exists)\b`), ErrNameAlreadyInUse},
+		{0, regexp.MustCompile(`(?i)\b(is|are) not (attached|mapped) to\b`), ErrHostNotAttachedToVolume},
+		{0, regexp.MustCompile(`(?i)\bvolumes?\b.*\b(is|are) (already )?(attached|mapped) to\b`), ErrVolumeAttachedToHost},
+		{0, regexp.MustCompile(`(?i)\b(is|are) already (detached|unmapped) from\b`), ErrVolumeDetachedFromHost},
+		{0, regexp.MustCompile(`(?i)\balready removed from\b.*\bNFS export\b`), ErrHostAlreadyRemovedFromNFSExport},
+		{0, regexp.MustCompile(`(?i)\balready present in\b.*\bNFS export\b`), ErrHostAlreadyPresentInNFSExport},
+		{0, regexp.MustCompile(`(?i)\bfail ?over\b.*\bfrom (the )?destination\b`), ErrFailoverFromDestination},
+		{0, regexp.MustCompile(`(?i)\breplication session\b.*\balready\b`), ErrReplicationSessionExists},
+	}
+	// errorStatuses are conditions which are defined by http status alone
+	errorStatuses = map[int]*ErrorCondition{
+		http.StatusNotFound:                     ErrNotFound,
+		http.StatusRequestedRangeNotSatisfiable: ErrBadRange,
+	}
+)
+
+// RegisterErrorCode maps PowerStore error code to condition, it replaces existing mapping of the code
+func RegisterErrorCode(code string, condition *ErrorCondition) {
+	errorCodesMu.Lock()
+	defer errorCodesMu.Unlock()
+	errorCodes[normalizeErrorCode(code)] = condition
+}
+
+// normalizeErrorCode makes "0xe0a08001000e" and "0xE0A08001000E" the same code
+func normalizeErrorCode(code string) string {
+	return strings.ToUpper(strings.TrimPrefix(strings.ToLower(code), "0x"))
+}
+
+func lookupErrorCode(code string) (*ErrorCondition, bool) {
+	if code == "" {
+		return nil, false
+	}
+	errorCodesMu.RLock()
+	defer errorCodesMu.RUnlock()
+	condition, ok := errorCodes[normalizeErrorCode(code)]
+	return condition, ok
+}
+
+// messages returns all entries of the error, including the one stored in top level fields
+func (err *ErrorMsg) messages() []ErrorMessage {
+	if len(err.Messages) != 0 {
+		return err.Messages
+	}
+	return []ErrorMessage{{Code: err.Code, Severity: err.Severity, Message: err.Message, Arguments: err.Arguments}}
+}
+
+func (err *ErrorMsg) matchesPattern(status int, re *regexp.Regexp, message string) bool {
+	return (status == 0 || status == err.StatusCode) && re.MatchString(message)
+}
+
+// Conditions returns all conditions which the error matches
+func (err *ErrorMsg) Conditions() []*ErrorCondition {
+	var res []*ErrorCondition
+	add := func(condition *ErrorCondition) {
+		for _, c := range res {
+			if c == condition {
+				return
+			}
+		}
+		res = append(res, condition)
+	}
+	for _, m := range err.messages() {
+		if condition, ok := lookupErrorCode(m.Code); ok {
+			add(condition)
+			continue
+		}
+		for _, p := range errorPatterns {
+			if err.matchesPattern(p.status, p.re, m.Message) {
+				add(p.condition)
+			}
+		}
+	}
+	if condition, ok := errorStatuses[err.StatusCode]; ok {
+		add(condition)
+	}
+	return res
+}
+
+// Classified returns true if the error code or message is known, so conditions are exact
+// and the status code alone should not be used to guess the cause
+func (err *ErrorMsg) Classified() bool {
+	for _, m := range err.messages() {
+		if _, ok := lookupErrorCode(m.Code); ok {
+			return true
+		}
+		for _, p := range errorPatterns {
+			if err.matchesPattern(p.status, p.re, m.Message) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasCondition returns true if the error matches condition
+func (err *ErrorMsg) HasCondition(condition *ErrorCondition) bool {
+	for _, c := range err.Conditions() {
+		if c == condition {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCode returns true if any message of the error has the given PowerStore error code
+func (err *ErrorMsg) HasCode(code string) bool {
+	for _, m := range err.messages() {
+		if m.Code != "" && normalizeErrorCode(m.Code) == normalizeErrorCode(code) {
+			return true
+		}
+	}
+	return false
+}
+
+// Is allows to match the error with sentinel conditions using errors.Is
+func (err *ErrorMsg) Is(target error) bool {
+	condition, ok := target.(*ErrorCondition)
+	if !ok {
+		return false
+	}
+	return err.HasCondition(condition)
+}
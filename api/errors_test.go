@@ -0,0 +1,163 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const multiErrResponseFile = "test_data/err_response_multi.json"
+
+func Test_buildErrorMultipleMessages(t *testing.T) {
+	httpResp := buildResp(t, multiErrResponseFile, http.StatusBadRequest)
+	apiErr := buildError(httpResp)
+	assert.NoError(t, httpResp.Body.Close())
+
+	assert.Len(t, apiErr.Messages, 2)
+	assert.Equal(t, "0xE04040020002", apiErr.Code)
+	assert.Equal(t, "Invalid instance.", apiErr.Message)
+	assert.Contains(t, apiErr.Error(), "Invalid instance.")
+	assert.Contains(t, apiErr.Error(), "Could not find the volume")
+	assert.True(t, apiErr.HasCode("0xe0a08001000e"))
+	assert.False(t, apiErr.HasCode("0xE0000000"))
+
+	// condition of the second message is matched as well
+	assert.True(t, errors.Is(apiErr, ErrNotFound))
+	assert.True(t, errors.Is(fmt.Errorf("wrapped: %w", apiErr), ErrNotFound))
+	assert.False(t, errors.Is(apiErr, ErrNameAlreadyInUse))
+	assert.True(t, apiErr.Classified())
+}
+
+func TestErrorMsg_Conditions(t *testing.T) {
+	assert.Equal(t, []*ErrorCondition{ErrNotFound}, (&ErrorMsg{StatusCode: http.StatusNotFound}).Conditions())
+	assert.Equal(t, []*ErrorCondition{ErrBadRange},
+		(&ErrorMsg{StatusCode: http.StatusRequestedRangeNotSatisfiable}).Conditions())
+	assert.Empty(t, (&ErrorMsg{StatusCode: http.StatusBadRequest}).Conditions())
+
+	notPart := &ErrorMsg{StatusCode: http.StatusUnprocessableEntity, Message: "volume is not part of the group"}
+	assert.True(t, errors.Is(notPart, ErrVolumeNotInVolumeGroup))
+	assert.True(t, notPart.Classified())
+
+	limit := &ErrorMsg{
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "The limit of 125 file systems for the NAS server has been reached",
+	}
+	assert.True(t, errors.Is(limit, ErrFSCreationLimitReached))
+	assert.False(t, errors.Is(limit, ErrVolumeNotInVolumeGroup))
+}
+
+func TestRegisterErrorCode(t *testing.T) {
+	code := "0xE0000000FFFF"
+	apiErr := &ErrorMsg{StatusCode: http.StatusBadRequest, Code: code}
+	assert.False(t, apiErr.Classified())
+	assert.False(t, errors.Is(apiErr, ErrReplicationSessionExists))
+
+	RegisterErrorCode(code, ErrReplicationSessionExists)
+	defer func() {
+		errorCodesMu.Lock()
+		delete(errorCodes, normalizeErrorCode(code))
+		errorCodesMu.Unlock()
+	}()
+	assert.True(t, apiErr.Classified())
+	assert.True(t, errors.Is(apiErr, ErrReplicationSessionExists))
+	assert.False(t, errors.Is(apiErr, errors.New(ErrReplicationSessionExists.Error())))
+}
+
+func errorBody(code, message string) string {
+	return fmt.Sprintf(`{"messages":[{"code":%q,"severity":"Error","message_l10n":%q,"arguments":[]}]}`,
+		code, message)
+}
+
+func TestErrorMsg_CapturedResponses(t *testing.T) {
+	// responses captured from an array are classified by code alone
+	patterns := errorPatterns
+	defer func() { errorPatterns = patterns }()
+	errorPatterns = nil
+
+	tests := []struct {
+		file   string
+		status int
+	}{
+		{errResponseFile, http.StatusNotFound},
+		{multiErrResponseFile, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			apiErr := buildError(buildResp(t, tt.file, tt.status))
+			assert.True(t, apiErr.Classified())
+			assert.Equal(t, []*ErrorCondition{ErrNotFound}, apiErr.Conditions())
+		})
+	}
+}
+
+// TestErrorMsg_Sentinels checks the fallback patterns, messages without a code are written for the test
+// and aren't captured from an array
+func TestErrorMsg_Sentinels(t *testing.T) {
+	sentinels := []*ErrorCondition{
+		ErrNotFound, ErrBadRange, ErrNameAlreadyInUse, ErrHostNotAttachedToVolume, ErrVolumeAttachedToHost,
+		ErrVolumeDetachedFromHost, ErrHostAlreadyRemovedFromNFSExport, ErrHostAlreadyPresentInNFSExport,
+		ErrFailoverFromDestination, ErrReplicationSessionExists, ErrVolumeNotInVolumeGroup, ErrFSCreationLimitReached,
+	}
+	tests := []struct {
+		condition *ErrorCondition
+		status    int
+		body      string
+	}{
+		{ErrNotFound, http.StatusNotFound, errorBody("0xE0A08001000E",
+			"Could not find the volume with ID: f98de58e-9223-4fdc-86bd-d4ff268e20e1")},
+		{ErrNotFound, http.StatusBadRequest, errorBody("0xE04040020002", "Invalid instance.")},
+		{ErrBadRange, http.StatusRequestedRangeNotSatisfiable, errorBody("",
+			"The requested range is not satisfiable.")},
+		{ErrNameAlreadyInUse, http.StatusUnprocessableEntity, errorBody("",
+			"The name csi-vol-1 is already in use by another volume.")},
+		{ErrHostNotAttachedToVolume, http.StatusBadRequest, errorBody("",
+			"The volume 2ce7ab3a-1f5e-4b27-b3f9-2b3b9f4d5c6a is not attached to host 9f4c6e1b-3a2d-4c5e-8f7a-1b2c3d4e5f60.")},
+		{ErrVolumeAttachedToHost, http.StatusUnprocessableEntity, errorBody("",
+			"The volume 2ce7ab3a-1f5e-4b27-b3f9-2b3b9f4d5c6a is attached to one or more hosts, detach it before deletion.")},
+		{ErrVolumeDetachedFromHost, http.StatusUnprocessableEntity, errorBody("",
+			"The volume 2ce7ab3a-1f5e-4b27-b3f9-2b3b9f4d5c6a is already detached from host 9f4c6e1b-3a2d-4c5e-8f7a-1b2c3d4e5f60.")},
+		{ErrHostAlreadyRemovedFromNFSExport, http.StatusBadRequest, errorBody("",
+			"The host 10.0.0.1 is already removed from add_rw_root_hosts of NFS export 6502ab34-54d4-4c6b-9f2e-3f1b2c3d4e5f.")},
+		{ErrHostAlreadyPresentInNFSExport, http.StatusBadRequest, errorBody("",
+			"The host 10.0.0.1 is already present in add_rw_root_hosts of NFS export 6502ab34-54d4-4c6b-9f2e-3f1b2c3d4e5f.")},
+		{ErrFailoverFromDestination, http.StatusBadRequest, errorBody("",
+			"Unable to failover replication session 3c4d5e6f-7a8b-4c9d-8e0f-1a2b3c4d5e6f from destination.")},
+		{ErrReplicationSessionExists, http.StatusBadRequest, errorBody("",
+			"The replication session for the volume group 3c4d5e6f-7a8b-4c9d-8e0f-1a2b3c4d5e6f already exists.")},
+		{ErrVolumeNotInVolumeGroup, http.StatusUnprocessableEntity, errorBody("",
+			"One or more volumes to be removed are not part of the volume group")},
+		{ErrFSCreationLimitReached, http.StatusUnprocessableEntity, errorBody("",
+			"New file system can not be created. The limit of 125 file systems for the NAS server nas-1 has been reached.")},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d", tt.condition, tt.status), func(t *testing.T) {
+			apiErr := buildError(&http.Response{Body: io.NopCloser(strings.NewReader(tt.body)), StatusCode: tt.status})
+			for _, condition := range sentinels {
+				assert.Equal(t, condition == tt.condition, errors.Is(apiErr, condition), condition.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const clusterURL = "cluster"
+
+// ErrClusterMismatch is returned when a failover address serves another cluster
+var ErrClusterMismatch = errors.New("management address serves another cluster")
+
+// ErrClusterUnknown is returned when the client fails over before identity of the cluster was read,
+// so it can't verify that a failover address serves the same cluster
+var ErrClusterUnknown = errors.New("identity of the cluster is not known")
+
+// clusterIdentity identifies the cluster behind management addresses
+type clusterIdentity struct {
+	ID       string `json:"id"`
+	GlobalID string `json:"global_id"`
+}
+
+func (ci clusterIdentity) known() bool {
+	return ci.ID != "" || ci.GlobalID != ""
+}
+
+// sameAs compares global ids if both are known and cluster ids otherwise
+func (ci clusterIdentity) sameAs(other clusterIdentity) bool {
+	if ci.GlobalID != "" && other.GlobalID != "" {
+		return ci.GlobalID == other.GlobalID
+	}
+	return ci.ID == other.ID
+}
+
+type apiURLKey struct{}
+
+// withAPIURL makes requests of ctx go to the management address instead of the active one
+func withAPIURL(ctx context.Context, apiURL string) context.Context {
+	return context.WithValue(ctx, apiURLKey{}, apiURL)
+}
+
+// requestAPIURL returns management address requests of ctx are sent to
+func (c *ClientIMPL) requestAPIURL(ctx context.Context) string {
+	if apiURL, ok := ctx.Value(apiURLKey{}).(string); ok {
+		return apiURL
+	}
+	return c.APIURL()
+}
+
+// APIURL returns management address the client currently sends requests to
+func (c *ClientIMPL) APIURL() string {
+	c.endpointMutex.RLock()
+	defer c.endpointMutex.RUnlock()
+	return c.apiURL
+}
+
+// APIURLs returns all management addresses of the cluster, the active one is not necessarily the first
+func (c *ClientIMPL) APIURLs() []string {
+	return append([]string(nil), c.apiURLs...)
+}
+
+// isConnectionError returns true if the request couldn't reach the array, so it is safe to resend it.
+// Only dial errors qualify, after a connection reset or a failed TLS handshake the array may have
+// received the request
+func isConnectionError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// rememberCluster saves identity of the cluster once a session is created, it is used to verify failover addresses
+func (c *ClientIMPL) rememberCluster(ctx context.Context) {
+	if len(c.apiURLs) < 2 {
+		return
+	}
+	c.endpointMutex.RLock()
+	known := c.cluster.known()
+	c.endpointMutex.RUnlock()
+	if known {
+		return
+	}
+	cluster, _, err := c.queryCluster(ctx)
+	if err != nil {
+		c.logger.Error(ctx, "failed to read identity of the cluster at %s: %s", c.requestAPIURL(ctx), err.Error())
+		return
+	}
+	c.endpointMutex.Lock()
+	c.cluster = cluster
+	c.endpointMutex.Unlock()
+}
+
+func (c *ClientIMPL) queryCluster(ctx context.Context) (clusterIdentity, RespMeta, error) {
+	var clusters []clusterIdentity
+	meta, _, err := c.queryOnce(ctx,
+		RequestConfig{
+			Method:      "GET",
+			Endpoint:    clusterURL,
+			QueryParams: c.QueryParams().Select("id", "global_id"),
+			Priority:    PriorityHigh,
+		}, &clusters)
+	if err != nil {
+		return clusterIdentity{}, meta, err
+	}
+	if len(clusters) == 0 {
+		return clusterIdentity{}, meta, errors.New("cluster is not found")
+	}
+	return clusters[0], meta, nil
+}
+
+// queryWithFailover sends request to the active management address and resends it to another one
+// if the array can't be reached
+func (c *ClientIMPL) queryWithFailover(
+	ctx context.Context,
+	config RequestConfig,
+	traceMsg string,
+	resp interface{},
+) (RespMeta, time.Duration, error) {
+	for failovers := 0; ; failovers++ {
+		apiURL := c.requestAPIURL(ctx)
+		meta, retryAfter, err := c.queryWithSession(withAPIURL(ctx, apiURL), config, traceMsg, resp)
+		if failovers >= len(c.apiURLs)-1 || ctx.Err() != nil || !isConnectionError(err) {
+			return meta, retryAfter, err
+		}
+		c.logger.Error(ctx, "%sAPI [%s %s] can't reach %s: %s", traceMsg, config.Method, config.Endpoint,
+			apiURL, err.Error())
+		if !c.failover(ctx, apiURL) {
+			return meta, retryAfter, err
+		}
+	}
+}
+
+// failover switches to the next reachable management address of the same cluster after failedURL became
+// unreachable, it returns false if no other address can be used
+func (c *ClientIMPL) failover(ctx context.Context, failedURL string) bool {
+	c.failoverMutex.Lock()
+	defer c.failoverMutex.Unlock()
+	if c.APIURL() != failedURL {
+		// another request has already switched the address
+		return true
+	}
+
+	start := 0
+	for i, u := range c.apiURLs {
+		if u == failedURL {
+			start = i
+		}
+	}
+	for i := 1; i < len(c.apiURLs); i++ {
+		candidate := c.apiURLs[(start+i)%len(c.apiURLs)]
+		if err := c.tryAPIURL(ctx, failedURL, candidate); err != nil {
+			c.logger.Error(ctx, "management address %s can't replace %s: %s", candidate, failedURL, err.Error())
+			continue
+		}
+		c.endpointMutex.Lock()
+		c.apiURL = candidate
+		c.endpointMutex.Unlock()
+		c.logger.Info(ctx, "management address %s is unreachable, failed over to %s", failedURL, candidate)
+		return true
+	}
+	return false
+}
+
+// tryAPIURL checks that the candidate address serves the same cluster, the session of the failed
+// address is reused if the array accepts it, otherwise a new session is created
+func (c *ClientIMPL) tryAPIURL(ctx context.Context, failedURL, candidate string) error {
+	c.endpointMutex.RLock()
+	expected := c.cluster
+	c.endpointMutex.RUnlock()
+	if !expected.known() {
+		return fmt.Errorf("%w: %s can't be verified", ErrClusterUnknown, candidate)
+	}
+
+	c.carrySession(failedURL, candidate)
+	ctx = withAPIURL(ctx, candidate)
+	cluster, meta, err := c.queryCluster(ctx)
+	if err != nil && (meta.Status == http.StatusUnauthorized || meta.Status == http.StatusForbidden) {
+		c.logger.Debug(ctx, "session is not accepted by %s, logging in", candidate)
+		if _, err = c.refreshSession(ctx, time.Now()); err != nil {
+			return err
+		}
+		cluster, _, err = c.queryCluster(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	if !expected.sameAs(cluster) {
+		return fmt.Errorf("%w: expected %s (%s), got %s (%s)", ErrClusterMismatch,
+			expected.ID, expected.GlobalID, cluster.ID, cluster.GlobalID)
+	}
+	return nil
+}
+
+// carrySession copies session cookies of one management address to another
+func (c *ClientIMPL) carrySession(from, to string) {
+	if c.httpClient.Jar == nil {
+		return
+	}
+	fromURL, err := url.Parse(from)
+	if err != nil {
+		return
+	}
+	toURL, err := url.Parse(to)
+	if err != nil {
+		return
+	}
+	if cookies := c.httpClient.Jar.Cookies(fromURL); len(cookies) > 0 {
+		c.httpClient.Jar.SetCookies(toURL, cookies)
+	}
+}
@@ -0,0 +1,197 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failoverLogger keeps info and error messages
+type failoverLogger struct {
+	defaultLogger
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *failoverLogger) Info(_ context.Context, format string, args ...interface{}) {
+	l.add(format, args...)
+}
+
+func (l *failoverLogger) Error(_ context.Context, format string, args ...interface{}) {
+	l.add(format, args...)
+}
+
+func (l *failoverLogger) add(format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, fmt.Sprintf(format, args...))
+}
+
+var errDial = &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+// registerArray registers login and cluster endpoints of a management address
+func registerArray(transport *httpmock.MockTransport, apiURL, globalID, cookie string) {
+	transport.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, loginSessionURL),
+		func(_ *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(http.StatusOK, `[{"id": "1", "idle_timeout": 3600}]`)
+			resp.Header.Set(dellEmcToken, "token-"+cookie)
+			resp.Header.Add("Set-Cookie", "auth_cookie="+cookie)
+			return resp, nil
+		})
+	transport.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, clusterURL),
+		func(req *http.Request) (*http.Response, error) {
+			if _, err := req.Cookie("auth_cookie"); err != nil {
+				return httpmock.NewStringResponse(http.StatusUnauthorized, ""), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK,
+				fmt.Sprintf(`[{"id": "0", "global_id": "%s"}]`, globalID)), nil
+		})
+}
+
+func newFailoverClient(t *testing.T, transport *httpmock.MockTransport, urls ...string) (*ClientIMPL, *failoverLogger) {
+	cfg := testConfig(urls[0], transport)
+	cfg.FailoverURLs = urls[1:]
+	c, err := NewWithContext(context.Background(), cfg)
+	require.NoError(t, err)
+	logger := &failoverLogger{}
+	c.SetLogger(logger)
+	return c, logger
+}
+
+func TestClientIMPL_Failover(t *testing.T) {
+	transport := httpmock.NewMockTransport()
+	registerArray(transport, "https://a", "PS1", "a")
+	registerArray(transport, "https://b", "PS1", "b")
+	c, logger := newFailoverClient(t, transport, "https://a", "https://b")
+	assert.Equal(t, clusterIdentity{ID: "0", GlobalID: "PS1"}, c.cluster)
+
+	transport.RegisterResponder("GET", "https://a/volume", httpmock.NewErrorResponder(errDial))
+	var cookie string
+	transport.RegisterResponder("GET", "https://b/volume",
+		func(req *http.Request) (*http.Response, error) {
+			ck, err := req.Cookie("auth_cookie")
+			require.NoError(t, err)
+			cookie = ck.Value
+			return httpmock.NewStringResponse(http.StatusOK, `{"name": "vol"}`), nil
+		})
+
+	var resp struct{ Name string }
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume"}, &resp)
+	require.NoError(t, err)
+	assert.Equal(t, "vol", resp.Name)
+	assert.Equal(t, "https://b", c.APIURL())
+	assert.Equal(t, []string{"https://a", "https://b"}, c.APIURLs())
+	// session of the first address is reused
+	assert.Equal(t, "a", cookie)
+	assert.Equal(t, "token-a", c.getToken())
+	assert.Equal(t, 1, transport.GetCallCountInfo()["GET https://a/login_session"])
+	assert.Zero(t, transport.GetCallCountInfo()["GET https://b/login_session"])
+	assert.Contains(t, logger.messages, "management address https://a is unreachable, failed over to https://b")
+}
+
+func TestClientIMPL_Failover_Relogin(t *testing.T) {
+	transport := httpmock.NewMockTransport()
+	registerArray(transport, "https://a", "PS1", "a")
+	registerArray(transport, "https://b", "PS1", "b")
+	c, _ := newFailoverClient(t, transport, "https://a", "https://b")
+
+	// the other address doesn't accept the session
+	transport.RegisterResponder("GET", "https://b/cluster",
+		func(req *http.Request) (*http.Response, error) {
+			if ck, err := req.Cookie("auth_cookie"); err != nil || ck.Value != "b" {
+				return httpmock.NewStringResponse(http.StatusUnauthorized, ""), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, `[{"id": "0", "global_id": "PS1"}]`), nil
+		})
+	transport.RegisterResponder("POST", "https://a/volume", httpmock.NewErrorResponder(errDial))
+	transport.RegisterResponder("POST", "https://b/volume",
+		httpmock.NewStringResponder(http.StatusCreated, `{"id": "1"}`))
+
+	var resp struct{ ID string }
+	_, err := c.Query(context.Background(), RequestConfig{Method: "POST", Endpoint: "volume"}, &resp)
+	require.NoError(t, err)
+	assert.Equal(t, "1", resp.ID)
+	assert.Equal(t, "https://b", c.APIURL())
+	assert.Equal(t, "token-b", c.getToken())
+	assert.Equal(t, 1, transport.GetCallCountInfo()["GET https://b/login_session"])
+}
+
+func TestClientIMPL_Failover_ClusterMismatch(t *testing.T) {
+	transport := httpmock.NewMockTransport()
+	registerArray(transport, "https://a", "PS1", "a")
+	registerArray(transport, "https://b", "PS2", "b")
+	registerArray(transport, "https://c", "PS1", "c")
+	c, logger := newFailoverClient(t, transport, "https://a", "https://b", "https://c")
+
+	transport.RegisterResponder("GET", "https://a/volume", httpmock.NewErrorResponder(errDial))
+	transport.RegisterResponder("GET", "https://c/volume", httpmock.NewStringResponder(http.StatusOK, `{}`))
+
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume"}, &struct{}{})
+	require.NoError(t, err)
+	assert.Equal(t, "https://c", c.APIURL())
+	assert.Contains(t, logger.messages, "management address https://b can't replace https://a: "+
+		"management address serves another cluster: expected 0 (PS1), got 0 (PS2)")
+}
+
+func TestClientIMPL_Failover_Unreachable(t *testing.T) {
+	transport := httpmock.NewMockTransport()
+	registerArray(transport, "https://a", "PS1", "a")
+	c, _ := newFailoverClient(t, transport, "https://a", "https://b")
+
+	transport.RegisterResponder("GET", "https://a/volume", httpmock.NewErrorResponder(errDial))
+	transport.RegisterResponder("GET", "https://b/cluster", httpmock.NewErrorResponder(errDial))
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume"}, &struct{}{})
+	assert.ErrorIs(t, err, errDial.Err)
+	assert.Equal(t, "https://a", c.APIURL())
+
+	// requests which may have reached the array are not resent
+	transport.RegisterResponder("GET", "https://a/volume", httpmock.NewErrorResponder(errors.New("EOF")))
+	_, err = c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume"}, &struct{}{})
+	assert.Error(t, err)
+	assert.Equal(t, 1, transport.GetCallCountInfo()["GET https://b/cluster"])
+}
+
+func TestNewWithContext_Failover(t *testing.T) {
+	transport := httpmock.NewMockTransport()
+	transport.RegisterResponder("GET", "https://a/login_session", httpmock.NewErrorResponder(errDial))
+	registerArray(transport, "https://b", "PS1", "b")
+	cfg := testConfig("https://a", transport)
+	cfg.FailoverURLs = []string{"https://b"}
+
+	// identity of the cluster isn't known before the first login, so the other address can't be verified
+	_, err := NewWithContext(context.Background(), cfg)
+	assert.ErrorIs(t, err, errDial.Err)
+	c, err := NewWithConfig(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "https://a", c.APIURL())
+	assert.False(t, c.cluster.known())
+	assert.Zero(t, transport.GetCallCountInfo()["GET https://b/cluster"])
+	assert.Zero(t, transport.GetCallCountInfo()["GET https://b/login_session"])
+	assert.ErrorIs(t, c.tryAPIURL(context.Background(), "https://a", "https://b"), ErrClusterUnknown)
+}
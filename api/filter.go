@@ -0,0 +1,256 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// filter operators supported by PowerStore REST API
+const (
+	opEq       = "eq"
+	opNeq      = "neq"
+	opGt       = "gt"
+	opGe       = "gte"
+	opLt       = "lt"
+	opLe       = "lte"
+	opLike     = "like"
+	opILike    = "ilike"
+	opIn       = "in"
+	opContains = "cs"
+	opIs       = "is"
+	opNot      = "not"
+	groupAnd   = "and"
+	groupOr    = "or"
+)
+
+// reserved characters of filter syntax, values containing them are quoted inside lists and groups
+const filterReservedChars = `,.:()"\ {}`
+
+// Filter is a condition which instances of a collection endpoint must match.
+// Filters are created with Eq, Like, In, Or and other functions of this package,
+// e.g. And(Ge("size", 1024), Le("size", 4096), Not(Like("name", "tmp*")))
+type Filter struct {
+	property string
+	operator string
+	// rendered value, already quoted if it is a list
+	value  string
+	negate bool
+	// and/or group of children filters
+	children []Filter
+	// pre-rendered value of Raw filter
+	raw bool
+}
+
+func condition(property, operator, value string) Filter {
+	return Filter{property: property, operator: operator, value: value}
+}
+
+// Raw returns filter with value in PowerStore syntax, e.g. Raw("name", "eq.foo")
+func Raw(property, value string) Filter {
+	return Filter{property: property, value: value, raw: true}
+}
+
+// RawFilters converts map of property to PowerStore syntax value to filters
+func RawFilters(args map[string]string) []Filter {
+	filters := make([]Filter, 0, len(args))
+	for k, v := range args {
+		filters = append(filters, Raw(k, v))
+	}
+	return filters
+}
+
+// Eq matches instances which property is equal to value
+func Eq(property string, value any) Filter {
+	return condition(property, opEq, formatFilterValue(value))
+}
+
+// Neq matches instances which property is not equal to value
+func Neq(property string, value any) Filter {
+	return condition(property, opNeq, formatFilterValue(value))
+}
+
+// Gt matches instances which property is greater than value
+func Gt(property string, value any) Filter {
+	return condition(property, opGt, formatFilterValue(value))
+}
+
+// Ge matches instances which property is greater than or equal to value
+func Ge(property string, value any) Filter {
+	return condition(property, opGe, formatFilterValue(value))
+}
+
+// Lt matches instances which property is less than value
+func Lt(property string, value any) Filter {
+	return condition(property, opLt, formatFilterValue(value))
+}
+
+// Le matches instances which property is less than or equal to value
+func Le(property string, value any) Filter {
+	return condition(property, opLe, formatFilterValue(value))
+}
+
+// Like matches instances which property matches pattern, * matches any sequence of characters
+func Like(property string, pattern string) Filter {
+	return condition(property, opLike, pattern)
+}
+
+// ILike is case-insensitive version of Like
+func ILike(property string, pattern string) Filter {
+	return condition(property, opILike, pattern)
+}
+
+// In matches instances which property is equal to one of values
+func In(property string, values ...any) Filter {
+	return condition(property, opIn, "("+formatFilterList(values)+")")
+}
+
+// Contains matches instances which array property contains all values
+func Contains(property string, values ...any) Filter {
+	return condition(property, opContains, "{"+formatFilterList(values)+"}")
+}
+
+// IsNull matches instances which property is not set
+func IsNull(property string) Filter {
+	return condition(property, opIs, "null")
+}
+
+// Not negates the filter
+func Not(f Filter) Filter {
+	f.negate = !f.negate
+	return f
+}
+
+// And matches instances which match all filters
+func And(filters ...Filter) Filter {
+	return Filter{operator: groupAnd, children: filters}
+}
+
+// Or matches instances which match any of filters
+func Or(filters ...Filter) Filter {
+	return Filter{operator: groupOr, children: filters}
+}
+
+func (f Filter) isGroup() bool {
+	return f.operator == groupAnd || f.operator == groupOr
+}
+
+// String returns filter in PowerStore syntax as it appears in query string before encoding
+func (f Filter) String() string {
+	args := make(url.Values)
+	f.addTo(args)
+	keys := make([]string, 0, len(args))
+	for k := range args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var res []string
+	for _, k := range keys {
+		for _, v := range args[k] {
+			res = append(res, k+"="+v)
+		}
+	}
+	return strings.Join(res, "&")
+}
+
+// addTo adds query arguments representing the filter
+func (f Filter) addTo(args url.Values) {
+	switch {
+	case f.raw:
+		if f.value != "" {
+			args.Add(f.property, f.prefix()+f.value)
+		}
+	case f.isGroup():
+		if len(f.children) == 0 {
+			return
+		}
+		if f.operator == groupAnd && !f.negate {
+			// top level conditions are combined with and
+			for _, child := range f.children {
+				child.addTo(args)
+			}
+			return
+		}
+		args.Add(f.prefix()+f.operator, f.renderChildren())
+	default:
+		args.Add(f.property, f.prefix()+f.operator+"."+f.value)
+	}
+}
+
+func (f Filter) prefix() string {
+	if f.negate {
+		return opNot + "."
+	}
+	return ""
+}
+
+func (f Filter) renderChildren() string {
+	parts := make([]string, 0, len(f.children))
+	for _, child := range f.children {
+		parts = append(parts, child.renderNested())
+	}
+	return "(" + strings.Join(parts, ",") + ")"
+}
+
+// renderNested returns filter as an element of and/or group
+func (f Filter) renderNested() string {
+	switch {
+	case f.raw:
+		return f.property + "." + f.prefix() + f.value
+	case f.isGroup():
+		return f.prefix() + f.operator + f.renderChildren()
+	case f.operator == opIn || f.operator == opContains || f.operator == opIs:
+		return f.property + "." + f.prefix() + f.operator + "." + f.value
+	default:
+		return f.property + "." + f.prefix() + f.operator + "." + quoteFilterValue(f.value)
+	}
+}
+
+func formatFilterValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatFilterList(values []any) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, quoteFilterValue(formatFilterValue(v)))
+	}
+	return strings.Join(parts, ",")
+}
+
+// quoteFilterValue double quotes value containing reserved characters so it is not split by the array
+func quoteFilterValue(value string) string {
+	if !strings.ContainsAny(value, filterReservedChars) {
+		return value
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
@@ -0,0 +1,77 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Filter
+		want   string
+	}{
+		{"eq", Eq("name", "vol.1"), "name=eq.vol.1"},
+		{"neq", Neq("size", 10), "size=neq.10"},
+		{"gt", Gt("size", 10), "size=gt.10"},
+		{"ge", Ge("size", int64(10)), "size=gte.10"},
+		{"lt", Lt("size", 10.5), "size=lt.10.5"},
+		{"le", Le("is_replication_destination", true), "is_replication_destination=lte.true"},
+		{"like", Like("name", "csi-*"), "name=like.csi-*"},
+		{"ilike", ILike("name", "*Vol*"), "name=ilike.*Vol*"},
+		{"in", In("id", "a", "b,c", `d"e`), `id=in.(a,"b,c","d\"e")`},
+		{"contains", Contains("purposes", "Storage_Iscsi_Target"), "purposes=cs.{Storage_Iscsi_Target}"},
+		{"is null", IsNull("parent_id"), "parent_id=is.null"},
+		{"not", Not(Eq("type", "Snapshot")), "type=not.eq.Snapshot"},
+		{"not not", Not(Not(Eq("type", "Snapshot"))), "type=eq.Snapshot"},
+		{"raw", Raw("name", "eq.foo"), "name=eq.foo"},
+		{"not raw", Not(Raw("name", "eq.foo")), "name=not.eq.foo"},
+		{"time", Gt("creation_timestamp", time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+			"creation_timestamp=gt.2025-01-02T03:04:05Z"},
+		{"and", And(Ge("size", 1024), Le("size", 4096)), "size=gte.1024&size=lte.4096"},
+		{"or", Or(Eq("name", "a"), Eq("name", "b.c")), `or=(name.eq.a,name.eq."b.c")`},
+		{"not or", Not(Or(IsNull("parent_id"), Not(In("id", 1, 2)))),
+			"not.or=(parent_id.is.null,id.not.in.(1,2))"},
+		{"nested", Or(Eq("name", "a"), And(Gt("size", 1), Lt("size", 5))),
+			"or=(name.eq.a,and(size.gt.1,size.lt.5))"},
+		{"not and", Not(And(Eq("a", 1), Eq("b", 2))), "not.and=(a.eq.1,b.eq.2)"},
+		{"empty group", Or(), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.String())
+		})
+	}
+}
+
+func TestQueryParams_Filter(t *testing.T) {
+	qp := QueryParams{}
+	qp.RawArg("type", "eq.Primary")
+	qp.Filter(Ge("size", 1024), Le("size", 4096)).Filter(Eq("name", "a&b"))
+	values, err := url.ParseQuery(qp.Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gte.1024", "lte.4096"}, values["size"])
+	assert.Equal(t, "eq.a&b", values.Get("name"))
+	assert.Equal(t, "eq.Primary", values.Get("type"))
+}
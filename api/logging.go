@@ -37,7 +37,7 @@ func (dl *defaultLogger) Info(_ context.Context, format string, args ...interfac
 }
 
 func (dl *defaultLogger) Debug(_ context.Context, format string, args ...interface{}) {
-	if debug {
+	if debug.Load() {
 		log.Printf(format, args...)
 	}
 }
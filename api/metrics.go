@@ -0,0 +1,140 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// MetricsHook receives operational metrics of the client, labeled by http method and endpoint.
+// Implementations must be safe for concurrent use.
+type MetricsHook interface {
+	// RequestStarted is called when request is sent to the array
+	RequestStarted(method, endpoint string)
+	// RequestFinished is called when the response body is closed or the request failed,
+	// status is 0 if no response was received
+	RequestFinished(method, endpoint string, status int, latency time.Duration, responseSize int64)
+	// ThrottleWaited reports time spent in the rate limiter and the concurrency semaphore
+	ThrottleWaited(method, endpoint string, wait time.Duration)
+	// TimedOut is called when API call fails because of a timeout
+	TimedOut(method, endpoint string)
+	// Relogin is called when the client creates a new login session to replace the expired one
+	Relogin()
+	// CircuitStateChanged is called when the circuit breaker changes its state
+	CircuitStateChanged(from, to CircuitState)
+}
+
+// NopMetrics is MetricsHook which ignores all metrics, embed it to implement only some of the methods
+type NopMetrics struct{}
+
+func (NopMetrics) RequestStarted(_, _ string) {}
+
+func (NopMetrics) RequestFinished(_, _ string, _ int, _ time.Duration, _ int64) {}
+
+func (NopMetrics) ThrottleWaited(_, _ string, _ time.Duration) {}
+
+func (NopMetrics) TimedOut(_, _ string) {}
+
+func (NopMetrics) Relogin() {}
+
+func (NopMetrics) CircuitStateChanged(_, _ CircuitState) {}
+
+// SetMetrics sets receiver of client metrics, nil disables metrics
+func (c *ClientIMPL) SetMetrics(hook MetricsHook) {
+	c.metricsHook = hook
+}
+
+func (c *ClientIMPL) metrics() MetricsHook {
+	if c.metricsHook == nil {
+		return NopMetrics{}
+	}
+	return c.metricsHook
+}
+
+// metricsMiddleware reports latency, status and size of every request sent to the array
+func (c *ClientIMPL) metricsMiddleware(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		hook := c.metrics()
+		endpoint := requestEndpoint(req)
+		hook.RequestStarted(req.Method, endpoint)
+		start := time.Now()
+		r, err := next(req)
+		if err != nil || r == nil || r.Body == nil {
+			status := 0
+			if r != nil {
+				status = r.StatusCode
+			}
+			hook.RequestFinished(req.Method, endpoint, status, time.Since(start), 0)
+			return r, err
+		}
+		r.Body = &countingBody{ReadCloser: r.Body, done: func(size int64) {
+			hook.RequestFinished(req.Method, endpoint, r.StatusCode, time.Since(start), size)
+		}}
+		return r, nil
+	}
+}
+
+// requestEndpoint returns endpoint of the request config, which unlike the url path doesn't contain ids
+func requestEndpoint(req *http.Request) string {
+	if cfg, ok := RequestConfigFromContext(req.Context()); ok {
+		return cfg.Endpoint
+	}
+	return req.URL.Path
+}
+
+// countingBody counts bytes read from the response and reports them once the body is closed
+type countingBody struct {
+	io.ReadCloser
+	size int64
+	once sync.Once
+	done func(size int64)
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += int64(n)
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.done(b.size) })
+	return err
+}
+
+// observeTimeout reports failed API call if it has timed out
+func (c *ClientIMPL) observeTimeout(config RequestConfig, err error) {
+	if err != nil && isTimeout(err) {
+		c.metrics().TimedOut(config.Method, config.Endpoint)
+	}
+}
+
+// isTimeout returns true if the error is caused by a timeout of the request or the throttle
+func isTimeout(err error) bool {
+	var semErr *TimeoutSemaphoreError
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || errors.As(err, &semErr) ||
+		(errors.As(err, &netErr) && netErr.Timeout())
+}
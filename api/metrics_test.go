@@ -0,0 +1,160 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingMetrics keeps reported metrics as strings
+type recordingMetrics struct {
+	mu       sync.Mutex
+	events   []string
+	inFlight int
+	waits    int
+}
+
+func (m *recordingMetrics) record(format string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, fmt.Sprintf(format, args...))
+}
+
+func (m *recordingMetrics) RequestStarted(method, endpoint string) {
+	m.mu.Lock()
+	m.inFlight++
+	m.mu.Unlock()
+	m.record("started %s %s", method, endpoint)
+}
+
+func (m *recordingMetrics) RequestFinished(method, endpoint string, status int, latency time.Duration, size int64) {
+	m.mu.Lock()
+	m.inFlight--
+	m.mu.Unlock()
+	if latency <= 0 {
+		m.record("invalid latency")
+	}
+	m.record("finished %s %s %d %d", method, endpoint, status, size)
+}
+
+func (m *recordingMetrics) ThrottleWaited(_, _ string, _ time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.waits++
+}
+
+func (m *recordingMetrics) TimedOut(method, endpoint string) {
+	m.record("timeout %s %s", method, endpoint)
+}
+
+func (m *recordingMetrics) Relogin() {
+	m.record("relogin")
+}
+
+func (m *recordingMetrics) CircuitStateChanged(from, to CircuitState) {
+	m.record("circuit %s -> %s", from, to)
+}
+
+func metricsClient(t *testing.T) (*ClientIMPL, *recordingMetrics) {
+	c := testClient(t, "https://foo")
+	hook := &recordingMetrics{}
+	c.SetMetrics(hook)
+	httpmock.ActivateNonDefault(c.httpClient)
+	return c, hook
+}
+
+func TestClientIMPL_Metrics(t *testing.T) {
+	c, hook := metricsClient(t)
+	defer httpmock.DeactivateAndReset()
+	body := `{"name": "vol"}`
+	httpmock.RegisterResponder("GET", "https://foo/volume/vol-1", httpmock.NewStringResponder(http.StatusOK, body))
+	httpmock.RegisterResponder("POST", "https://foo/volume", httpmock.NewErrorResponder(errors.New("refused")))
+
+	var resp testResp
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume", ID: "vol-1"}, &resp)
+	assert.NoError(t, err)
+	_, err = c.Query(context.Background(), RequestConfig{Method: "POST", Endpoint: "volume"}, &resp)
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{
+		"started GET volume",
+		fmt.Sprintf("finished GET volume 200 %d", len(body)),
+		"started POST volume",
+		"finished POST volume 0 0",
+	}, hook.events)
+	assert.Equal(t, 0, hook.inFlight)
+	assert.Equal(t, 2, hook.waits)
+}
+
+func TestClientIMPL_Metrics_Timeout(t *testing.T) {
+	c, hook := metricsClient(t)
+	defer httpmock.DeactivateAndReset()
+	c.apiThrottle = newThrottle(10*time.Millisecond, 1, &defaultLogger{})
+	assert.NoError(t, c.apiThrottle.Acquire(context.Background()))
+	defer c.apiThrottle.Release(context.Background())
+
+	var resp testResp
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume"}, &resp)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"timeout GET volume"}, hook.events)
+	assert.Equal(t, 1, hook.waits)
+}
+
+func TestClientIMPL_Metrics_Relogin(t *testing.T) {
+	c, hook := metricsClient(t)
+	defer httpmock.DeactivateAndReset()
+	loggedIn := false
+	httpmock.RegisterResponder("GET", "https://foo/"+loginSessionURL,
+		func(_ *http.Request) (*http.Response, error) {
+			loggedIn = true
+			return httpmock.NewStringResponse(http.StatusOK, `[]`), nil
+		})
+	httpmock.RegisterResponder("GET", "https://foo/volume",
+		func(_ *http.Request) (*http.Response, error) {
+			if !loggedIn {
+				return httpmock.NewStringResponse(http.StatusForbidden, ""), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, `{}`), nil
+		})
+
+	var resp testResp
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume"}, &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"started GET volume", "finished GET volume 403 0",
+		"relogin", "started GET login_session", "finished GET login_session 200 2",
+		"started GET volume", "finished GET volume 200 2",
+	}, hook.events)
+}
+
+func Test_isTimeout(t *testing.T) {
+	assert.True(t, isTimeout(context.DeadlineExceeded))
+	assert.True(t, isTimeout(fmt.Errorf("wrapped: %w", context.DeadlineExceeded)))
+	assert.True(t, isTimeout(&TimeoutSemaphoreError{"timeout"}))
+	assert.False(t, isTimeout(errors.New("refused")))
+}
@@ -0,0 +1,162 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+	"time"
+)
+
+// Handler sends request to PowerStore and returns its response
+type Handler func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps Handler to inspect or change outgoing requests and their responses.
+// Middleware can short-circuit the chain by returning a response without calling next,
+// the response is then processed by Query as if it was returned by the array.
+type Middleware func(next Handler) Handler
+
+// Chain composes middlewares, the first one receives the request first and the response last
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next Handler) Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+type requestConfigKey struct{}
+
+// RequestConfigFromContext returns config of the request which is sent through the middleware chain
+func RequestConfigFromContext(ctx context.Context) (RequestConfig, bool) {
+	cfg, ok := ctx.Value(requestConfigKey{}).(RequestConfig)
+	return cfg, ok
+}
+
+func withRequestConfig(ctx context.Context, cfg RequestConfig) context.Context {
+	return context.WithValue(ctx, requestConfigKey{}, cfg)
+}
+
+// Use appends middlewares to the chain. Middlewares added with Use run in the order they were added,
+// before the built-in circuit breaker, throttling, metrics, tracing, authentication and debug dump middlewares.
+func (c *ClientIMPL) Use(middlewares ...Middleware) {
+	c.middlewareMutex.Lock()
+	defer c.middlewareMutex.Unlock()
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// handler returns the middleware chain which ends with the http client
+func (c *ClientIMPL) handler() Handler {
+	c.middlewareMutex.RLock()
+	chain := make([]Middleware, 0, len(c.middlewares)+6)
+	chain = append(chain, c.middlewares...)
+	c.middlewareMutex.RUnlock()
+	chain = append(chain, c.circuitBreakerMiddleware, c.throttleMiddleware, c.metricsMiddleware, c.traceMiddleware, c.authMiddleware, c.debugMiddleware)
+	return Chain(chain...)(c.httpClient.Do)
+}
+
+// throttleMiddleware waits for the rate limiter and holds the throttle until the response body is closed
+func (c *ClientIMPL) throttleMiddleware(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		if err := c.waitThrottle(ctx, req); err != nil {
+			return nil, err
+		}
+		r, err := next(req)
+		if err != nil || r == nil || r.Body == nil {
+			c.apiThrottle.Release(ctx)
+			return r, err
+		}
+		r.Body = &releaseOnClose{ReadCloser: r.Body, release: func() { c.apiThrottle.Release(ctx) }}
+		return r, nil
+	}
+}
+
+// waitThrottle waits for the rate limiter and acquires the throttle, the wait is traced as a child span
+func (c *ClientIMPL) waitThrottle(ctx context.Context, req *http.Request) (err error) {
+	spanCtx, span := c.startSpan(ctx, "PowerStore throttle wait")
+	start := time.Now()
+	defer func() {
+		endSpan(span, err)
+		c.metrics().ThrottleWaited(req.Method, requestEndpoint(req), time.Since(start))
+	}()
+	if c.rateLimiter != nil {
+		cfg, _ := RequestConfigFromContext(ctx)
+		if err := c.rateLimiter.Wait(spanCtx, cfg); err != nil {
+			return err
+		}
+	}
+
+	c.logger.Debug(ctx, "Requesting a lock for API : [%s %s]\n", req.Method, req.URL)
+	return c.apiThrottle.Acquire(ctx)
+}
+
+// releaseOnClose calls release once the body is closed
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// authMiddleware adds credentials and session token to the request and saves the token of a successful response
+func (c *ClientIMPL) authMiddleware(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		creds := c.getCredentials()
+		req.SetBasicAuth(creds.Username, creds.Password)
+		if token := c.getToken(); len(token) != 0 {
+			req.Header.Set(dellEmcToken, token)
+		}
+		r, err := next(req)
+		if err == nil && r.StatusCode >= 200 && r.StatusCode < 300 {
+			// Save DELL-EMC-TOKEN if it was a successful response.
+			c.touchSession(r.Header.Get(dellEmcToken))
+		}
+		return r, err
+	}
+}
+
+// debugMiddleware dumps requests and responses with redacted credentials if GOPOWERSTORE_DEBUG is set
+func (c *ClientIMPL) debugMiddleware(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		if !debug.Load() {
+			return next(req)
+		}
+		ctx := req.Context()
+		traceMsg := c.prepareTraceMsg(ctx)
+		if requestData, err := httputil.DumpRequest(req, true); err == nil {
+			c.logger.Debug(ctx, "%sREQUEST: %s", traceMsg, prepareHTTPDump(requestData))
+		}
+		r, err := next(req)
+		if err == nil {
+			dump, _ := httputil.DumpResponse(r, true)
+			c.logger.Debug(ctx, "%sRESPONSE: %v\n", traceMsg, prepareHTTPDump(dump))
+		}
+		return r, err
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// captureLogger keeps debug messages
+type captureLogger struct {
+	defaultLogger
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *captureLogger) Debug(_ context.Context, format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, fmt.Sprintf(format, args...))
+}
+
+func TestClientIMPL_Use(t *testing.T) {
+	c := testClient(t, "https://foo")
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	var tenant, auth string
+	httpmock.RegisterResponder("GET", "https://foo/volume",
+		func(req *http.Request) (*http.Response, error) {
+			tenant = req.Header.Get("X-Tenant")
+			auth = req.Header.Get("Authorization")
+			resp := httpmock.NewStringResponse(200, `{"name": "vol"}`)
+			resp.Header.Set("X-Served-By", "array")
+			return resp, nil
+		})
+
+	var order []string
+	var endpoint string
+	var servedBy string
+	c.Use(
+		func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, "first")
+				cfg, ok := RequestConfigFromContext(req.Context())
+				assert.True(t, ok)
+				endpoint = cfg.Endpoint
+				r, err := next(req)
+				servedBy = r.Header.Get("X-Served-By")
+				order = append(order, "first done")
+				return r, err
+			}
+		},
+		func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, "second")
+				// authentication is added later in the chain
+				assert.Empty(t, req.Header.Get("Authorization"))
+				req.Header.Set("X-Tenant", "tenant-1")
+				r, err := next(req)
+				order = append(order, "second done")
+				return r, err
+			}
+		},
+	)
+
+	var resp testResp
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume"}, &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "vol", resp.Name)
+	assert.Equal(t, []string{"first", "second", "second done", "first done"}, order)
+	assert.Equal(t, "volume", endpoint)
+	assert.Equal(t, "array", servedBy)
+	assert.Equal(t, "tenant-1", tenant)
+	assert.True(t, strings.HasPrefix(auth, "Basic "))
+}
+
+func TestClientIMPL_Use_ShortCircuit(t *testing.T) {
+	c := testClient(t, "https://foo")
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	c.Use(func(_ Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/volume" {
+				return httpmock.NewStringResponse(200, `{"name": "synthetic"}`), nil
+			}
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}, nil
+		}
+	})
+
+	var resp testResp
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume"}, &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "synthetic", resp.Name)
+
+	meta, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "host"}, &resp)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, meta.Status)
+	assert.Equal(t, 0, httpmock.GetTotalCallCount())
+}
+
+func TestClientIMPL_throttleMiddleware(t *testing.T) {
+	c := testClient(t, "https://foo")
+	c.apiThrottle = newThrottle(c.defaultTimeout, 1, &defaultLogger{})
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://foo/volume", httpmock.NewStringResponder(200, `{"name": "vol"}`))
+
+	// the only slot of the throttle is released after each response is read
+	for i := 0; i < 3; i++ {
+		var resp testResp
+		_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume"}, &resp)
+		assert.NoError(t, err)
+	}
+}
+
+func TestClientIMPL_debugMiddleware(t *testing.T) {
+	c := testClient(t, "https://foo")
+	logger := &captureLogger{}
+	c.SetLogger(logger)
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://foo/volume", httpmock.NewStringResponder(200, `{"name": "vol"}`))
+
+	debug.Store(true)
+	defer debug.Store(false)
+	var resp testResp
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume"}, &resp)
+	assert.NoError(t, err)
+
+	var request, response string
+	for _, m := range logger.messages {
+		switch {
+		case strings.HasPrefix(m, "REQUEST: "):
+			request = m
+		case strings.HasPrefix(m, "RESPONSE: "):
+			response = m
+		}
+	}
+	assert.Contains(t, request, "GET /volume")
+	assert.Contains(t, request, "Authorization: ******")
+	assert.NotContains(t, request, "YWRtaW46cGFzc3dvcmQ=")
+	assert.Contains(t, response, `{"name": "vol"}`)
+}
+
+func TestChain(t *testing.T) {
+	var calls []string
+	mw := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				return next(req)
+			}
+		}
+	}
+	h := Chain(mw("a"), mw("b"), mw("c"))(func(_ *http.Request) (*http.Response, error) {
+		calls = append(calls, "handler")
+		return &http.Response{StatusCode: http.StatusNoContent}, nil
+	})
+	r, err := h(&http.Request{})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, r.StatusCode)
+	assert.Equal(t, []string{"a", "b", "c", "handler"}, calls)
+}
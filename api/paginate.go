@@ -0,0 +1,105 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is number of instances requested per page when page size isn't set
+const DefaultPageSize = 1000
+
+// Paginate returns iterator over all instances of a collection endpoint.
+// Pages of pageSize instances are requested lazily while the caller keeps iterating,
+// so breaking out of the loop stops sending requests. Request error is yielded once and ends iteration.
+// QueryParams of cfg are not modified, offset and limit of every page replace theirs.
+func Paginate[T any](ctx context.Context, client Client, cfg RequestConfig, pageSize int) iter.Seq2[T, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return func(yield func(T, error) bool) {
+		qp := cfg.QueryParams
+		if qp == nil {
+			qp = client.QueryParams()
+		}
+		offset := 0
+		for {
+			var page []T
+			cfg := cfg
+			cfg.QueryParams = &pageQueryParams{QueryParamsEncoder: qp, offset: offset, limit: pageSize}
+			meta, err := client.Query(ctx, cfg, &page)
+			if err != nil {
+				var apiErr *ErrorMsg
+				if offset > 0 && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+					// could happen if some instances were deleted during pagination
+					return
+				}
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !meta.Pagination.IsPaginate || len(page) == 0 {
+				return
+			}
+			offset = meta.Pagination.Last + 1
+			if offset >= meta.Pagination.Total {
+				return
+			}
+		}
+	}
+}
+
+// pageQueryParams adds offset and limit of a page to query params of the caller without modifying them
+type pageQueryParams struct {
+	QueryParamsEncoder
+	offset int
+	limit  int
+}
+
+func (qp *pageQueryParams) Encode() string {
+	q, err := url.ParseQuery(qp.QueryParamsEncoder.Encode())
+	if err != nil {
+		q = make(url.Values)
+	}
+	q.Set("offset", strconv.Itoa(qp.offset))
+	q.Set("limit", strconv.Itoa(qp.limit))
+	return q.Encode()
+}
+
+// Collect reads all instances from iterator, on error it returns instances read so far and the error
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var result []T
+	for item, err := range seq {
+		if err != nil {
+			return result, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
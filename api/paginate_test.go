@@ -0,0 +1,164 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// pagedResponder serves total items named by their index in pages requested with offset and limit
+func pagedResponder(t *testing.T, total int) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+		limit, err := strconv.Atoi(req.URL.Query().Get("limit"))
+		assert.NoError(t, err)
+		if offset >= total {
+			return httpmock.NewStringResponse(http.StatusRequestedRangeNotSatisfiable, ""), nil
+		}
+		last := min(offset+limit, total) - 1
+		var items []testResp
+		for i := offset; i <= last; i++ {
+			items = append(items, testResp{Name: strconv.Itoa(i)})
+		}
+		resp, err := httpmock.NewJsonResponse(http.StatusPartialContent, items)
+		resp.Header.Set(paginationHeader, fmt.Sprintf("%d-%d/%d", offset, last, total))
+		return resp, err
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	apiURL := "https://foo"
+	testURL := "mock"
+	c := testClient(t, apiURL)
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, testURL), pagedResponder(t, 7))
+
+	items, err := Collect(Paginate[testResp](context.Background(), c,
+		RequestConfig{Method: "GET", Endpoint: testURL}, 3))
+	assert.NoError(t, err)
+	assert.Len(t, items, 7)
+	assert.Equal(t, "6", items[6].Name)
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+
+	// stopping early doesn't request next pages
+	httpmock.ZeroCallCounters()
+	for item, err := range Paginate[testResp](context.Background(), c,
+		RequestConfig{Method: "GET", Endpoint: testURL, QueryParams: c.QueryParams()}, 3) {
+		assert.NoError(t, err)
+		if item.Name == "1" {
+			break
+		}
+	}
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestPaginate_QueryParamsReused(t *testing.T) {
+	apiURL := "https://foo"
+	testURL := "mock"
+	c := testClient(t, apiURL)
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+	responder := pagedResponder(t, 7)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, testURL),
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "name", req.URL.Query().Get("select"))
+			return responder(req)
+		})
+
+	qp := c.QueryParams().Select("name")
+	seq := Paginate[testResp](context.Background(), c, RequestConfig{Method: "GET", Endpoint: testURL, QueryParams: qp}, 3)
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			items, err := Collect(seq)
+			assert.NoError(t, err)
+			assert.Len(t, items, 7)
+		}()
+	}
+	wg.Wait()
+	items, err := Collect(seq)
+	assert.NoError(t, err)
+	assert.Len(t, items, 7)
+	assert.Equal(t, "select=name", qp.Encode())
+}
+
+func TestPaginate_NotPaginated(t *testing.T) {
+	apiURL := "https://foo"
+	testURL := "mock"
+	c := testClient(t, apiURL)
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, testURL),
+		httpmock.NewStringResponder(http.StatusOK, `[{"name": "Foo"}, {"name": "Bar"}]`))
+
+	items, err := Collect(Paginate[testResp](context.Background(), c,
+		RequestConfig{Method: "GET", Endpoint: testURL}, 0))
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestPaginate_Error(t *testing.T) {
+	apiURL := "https://foo"
+	testURL := "mock"
+	c := testClient(t, apiURL)
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, testURL),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return pagedResponder(t, 10)(req)
+			}
+			return httpmock.NewStringResponse(http.StatusBadRequest, ""), nil
+		})
+
+	items, err := Collect(Paginate[testResp](context.Background(), c,
+		RequestConfig{Method: "GET", Endpoint: testURL}, 5))
+	assert.Error(t, err)
+	assert.Len(t, items, 5)
+
+	// instances were deleted while paginating
+	calls = 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, testURL),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return pagedResponder(t, 10)(req)
+			}
+			return httpmock.NewStringResponse(http.StatusRequestedRangeNotSatisfiable, ""), nil
+		})
+	items, err = Collect(Paginate[testResp](context.Background(), c,
+		RequestConfig{Method: "GET", Endpoint: testURL}, 5))
+	assert.NoError(t, err)
+	assert.Len(t, items, 5)
+}
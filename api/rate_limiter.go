@@ -0,0 +1,318 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// RequestPriority defines order in which requests waiting for the rate limiter are served
+type RequestPriority int
+
+// Request priorities, requests of lower priority wait while there are higher priority requests waiting
+const (
+	PriorityLow    RequestPriority = -1
+	PriorityNormal RequestPriority = 0
+	PriorityHigh   RequestPriority = 1
+)
+
+const (
+	priorityContextKey ContextKey = "gopowerstore.priority"
+	// how often a waiter re-checks the bucket while higher priority requests are queued
+	rateLimiterPollInterval = 5 * time.Millisecond
+)
+
+func (p RequestPriority) String() string {
+	switch {
+	case p < PriorityNormal:
+		return "low"
+	case p > PriorityNormal:
+		return "high"
+	default:
+		return "normal"
+	}
+}
+
+// index returns position of priority in per-priority arrays
+func (p RequestPriority) index() int {
+	return max(min(int(p), int(PriorityHigh)), int(PriorityLow)) - int(PriorityLow)
+}
+
+// WithPriority returns context which makes requests use the given priority in the rate limiter
+func WithPriority(ctx context.Context, priority RequestPriority) context.Context {
+	return context.WithValue(ctx, priorityContextKey, priority)
+}
+
+// requestPriority returns priority from request config or from context if config doesn't set it
+func requestPriority(ctx context.Context, cfg RequestConfig) RequestPriority {
+	if cfg.Priority != PriorityNormal {
+		return cfg.Priority
+	}
+	if p, ok := ctx.Value(priorityContextKey).(RequestPriority); ok {
+		return p
+	}
+	return PriorityNormal
+}
+
+// RateLimiterInterface gives ability to limit requests per second sent to PowerStore API
+type RateLimiterInterface interface {
+	Wait(ctx context.Context, cfg RequestConfig) error
+	SetLogger(logger Logger) RateLimiterInterface
+}
+
+// RateLimiterError is returned if a request can't be sent before its context expires
+type RateLimiterError struct {
+	msg string
+}
+
+func (e *RateLimiterError) Error() string {
+	return e.msg
+}
+
+// RateLimitBudget describes a token bucket
+type RateLimitBudget struct {
+	// average number of requests per second
+	RequestsPerSecond float64
+	// number of requests which may be sent at once, defaults to RequestsPerSecond rounded up
+	Burst int
+}
+
+// RateLimiterConfig describes budgets used by TokenBucketRateLimiter
+type RateLimiterConfig struct {
+	// budget shared by all requests, zero RequestsPerSecond means no global limit
+	Global RateLimitBudget
+	// budgets for requests to specific endpoints, e.g. "metrics"
+	Endpoints map[string]RateLimitBudget
+	// budgets for requests with specific http method, e.g. "GET"
+	Methods map[string]RateLimitBudget
+	// how often wait statistics are written to the logger, zero disables the report
+	StatsInterval time.Duration
+}
+
+// RateLimiterStats holds wait statistics of requests of the same priority
+type RateLimiterStats struct {
+	// number of requests passed through the limiter
+	Requests int64
+	// number of requests which had to wait for a token
+	Delayed   int64
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+type tokenBucket struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	tokens  float64
+	last    time.Time
+	waiting [3]int
+}
+
+func newTokenBucket(budget RateLimitBudget) *tokenBucket {
+	burst := float64(budget.Burst)
+	if burst < 1 {
+		burst = math.Max(1, math.Ceil(budget.RequestsPerSecond))
+	}
+	return &tokenBucket{
+		rate:   budget.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// take consumes a token or returns the time to wait before trying again
+func (b *tokenBucket) take(idx int) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(time.Now())
+	for i := idx + 1; i < len(b.waiting); i++ {
+		if b.waiting[i] > 0 {
+			return rateLimiterPollInterval, false
+		}
+	}
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second)), false
+}
+
+// giveBack returns a token taken for a request which isn't sent
+func (b *tokenBucket) giveBack() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(time.Now())
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+func (b *tokenBucket) setWaiting(idx int, delta int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.waiting[idx] += delta
+}
+
+func (b *tokenBucket) wait(ctx context.Context, idx int) error {
+	delay, ok := b.take(idx)
+	if ok {
+		return nil
+	}
+	b.setWaiting(idx, 1)
+	defer b.setWaiting(idx, -1)
+	for {
+		if deadline, isSet := ctx.Deadline(); isSet && time.Until(deadline) < delay {
+			return &RateLimiterError{"request rate limit can't be satisfied before the context deadline"}
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &RateLimiterError{"context is done while waiting for request rate limit"}
+		case <-timer.C:
+		}
+		if delay, ok = b.take(idx); ok {
+			return nil
+		}
+	}
+}
+
+// TokenBucketRateLimiter limits number of requests per second, globally and per endpoint or method
+type TokenBucketRateLimiter struct {
+	global    *tokenBucket
+	endpoints map[string]*tokenBucket
+	methods   map[string]*tokenBucket
+
+	statsMu       sync.Mutex
+	stats         [3]RateLimiterStats
+	statsInterval time.Duration
+	lastReport    time.Time
+	logger        Logger
+}
+
+// NewTokenBucketRateLimiter returns rate limiter configured with provided budgets
+func NewTokenBucketRateLimiter(cfg RateLimiterConfig, logger Logger) *TokenBucketRateLimiter {
+	if logger == nil {
+		logger = &defaultLogger{}
+	}
+	rl := &TokenBucketRateLimiter{
+		endpoints:     make(map[string]*tokenBucket),
+		methods:       make(map[string]*tokenBucket),
+		statsInterval: cfg.StatsInterval,
+		lastReport:    time.Now(),
+		logger:        logger,
+	}
+	if cfg.Global.RequestsPerSecond > 0 {
+		rl.global = newTokenBucket(cfg.Global)
+	}
+	for endpoint, budget := range cfg.Endpoints {
+		if budget.RequestsPerSecond > 0 {
+			rl.endpoints[endpoint] = newTokenBucket(budget)
+		}
+	}
+	for method, budget := range cfg.Methods {
+		if budget.RequestsPerSecond > 0 {
+			rl.methods[method] = newTokenBucket(budget)
+		}
+	}
+	return rl
+}
+
+// Wait blocks until the request may be sent according to all budgets which apply to it
+func (rl *TokenBucketRateLimiter) Wait(ctx context.Context, cfg RequestConfig) error {
+	priority := requestPriority(ctx, cfg)
+	idx := priority.index()
+	start := time.Now()
+
+	var taken []*tokenBucket
+	for _, b := range []*tokenBucket{rl.endpoints[cfg.Endpoint], rl.methods[cfg.Method], rl.global} {
+		if b == nil {
+			continue
+		}
+		if err := b.wait(ctx, idx); err != nil {
+			// the request isn't sent, so it doesn't use budgets it has already waited for
+			for _, t := range taken {
+				t.giveBack()
+			}
+			rl.logger.Error(ctx, "%s: [%s %s]", err.Error(), cfg.Method, cfg.Endpoint)
+			return err
+		}
+		taken = append(taken, b)
+	}
+
+	waited := time.Since(start)
+	if waited > rateLimiterPollInterval {
+		rl.logger.Debug(ctx, "request [%s %s] with %s priority waited %s for rate limiter",
+			cfg.Method, cfg.Endpoint, priority, waited)
+	}
+	rl.record(ctx, idx, waited)
+	return nil
+}
+
+func (rl *TokenBucketRateLimiter) record(ctx context.Context, idx int, waited time.Duration) {
+	rl.statsMu.Lock()
+	defer rl.statsMu.Unlock()
+	s := &rl.stats[idx]
+	s.Requests++
+	if waited > rateLimiterPollInterval {
+		s.Delayed++
+		s.TotalWait += waited
+		s.MaxWait = max(s.MaxWait, waited)
+	}
+
+	if rl.statsInterval <= 0 || time.Since(rl.lastReport) < rl.statsInterval {
+		return
+	}
+	rl.lastReport = time.Now()
+	for i, s := range rl.stats {
+		if s.Requests == 0 {
+			continue
+		}
+		var avgWait time.Duration
+		if s.Delayed > 0 {
+			avgWait = s.TotalWait / time.Duration(s.Delayed)
+		}
+		rl.logger.Info(ctx, "rate limiter stats: priority=%s requests=%d delayed=%d avg_wait=%s max_wait=%s",
+			RequestPriority(i+int(PriorityLow)), s.Requests, s.Delayed, avgWait, s.MaxWait)
+	}
+}
+
+// Stats returns wait statistics grouped by request priority
+func (rl *TokenBucketRateLimiter) Stats() map[RequestPriority]RateLimiterStats {
+	rl.statsMu.Lock()
+	defer rl.statsMu.Unlock()
+	res := make(map[RequestPriority]RateLimiterStats, len(rl.stats))
+	for i, s := range rl.stats {
+		res[RequestPriority(i+int(PriorityLow))] = s
+	}
+	return res
+}
+
+// SetLogger sets logger used for wait statistics
+func (rl *TokenBucketRateLimiter) SetLogger(logger Logger) RateLimiterInterface {
+	rl.logger = logger
+	return rl
+}
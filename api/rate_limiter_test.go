@@ -0,0 +1,174 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucketRateLimiter_Wait(t *testing.T) {
+	rl := NewTokenBucketRateLimiter(RateLimiterConfig{
+		Global: RateLimitBudget{RequestsPerSecond: 20, Burst: 2},
+	}, &defaultLogger{})
+	ctx := context.Background()
+	cfg := RequestConfig{Method: "GET", Endpoint: "volume"}
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		assert.Nil(t, rl.Wait(ctx, cfg))
+	}
+	// 2 requests fit into the burst, 4 more need 50ms each
+	assert.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+
+	stats := rl.Stats()[PriorityNormal]
+	assert.Equal(t, int64(6), stats.Requests)
+	assert.Positive(t, stats.Delayed)
+	assert.Positive(t, stats.MaxWait)
+}
+
+func TestTokenBucketRateLimiter_Deadline(t *testing.T) {
+	rl := NewTokenBucketRateLimiter(RateLimiterConfig{
+		Global: RateLimitBudget{RequestsPerSecond: 0.1},
+	}, nil)
+	cfg := RequestConfig{Method: "GET", Endpoint: "volume"}
+	assert.Nil(t, rl.Wait(context.Background(), cfg))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := rl.Wait(ctx, cfg)
+	assert.IsType(t, &RateLimiterError{}, err)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestTokenBucketRateLimiter_GiveBack(t *testing.T) {
+	rl := NewTokenBucketRateLimiter(RateLimiterConfig{
+		Global:    RateLimitBudget{RequestsPerSecond: 0.1},
+		Endpoints: map[string]RateLimitBudget{"volume": {RequestsPerSecond: 0.1}},
+		Methods:   map[string]RateLimitBudget{"GET": {RequestsPerSecond: 0.1}},
+	}, nil)
+	assert.Nil(t, rl.Wait(context.Background(), RequestConfig{Method: "POST", Endpoint: "host"}))
+
+	// the global budget is used up, tokens of the endpoint and the method are returned
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cfg := RequestConfig{Method: "GET", Endpoint: "volume"}
+	assert.IsType(t, &RateLimiterError{}, rl.Wait(ctx, cfg))
+	for _, b := range []*tokenBucket{rl.endpoints["volume"], rl.methods["GET"]} {
+		delay, ok := b.take(PriorityNormal.index())
+		assert.True(t, ok, delay)
+	}
+}
+
+func TestTokenBucketRateLimiter_Budgets(t *testing.T) {
+	rl := NewTokenBucketRateLimiter(RateLimiterConfig{
+		Endpoints: map[string]RateLimitBudget{"metrics": {RequestsPerSecond: 0.1}},
+		Methods:   map[string]RateLimitBudget{"DELETE": {RequestsPerSecond: 0.1}},
+	}, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	assert.Nil(t, rl.Wait(ctx, RequestConfig{Method: "POST", Endpoint: "metrics"}))
+	assert.NotNil(t, rl.Wait(ctx, RequestConfig{Method: "POST", Endpoint: "metrics"}))
+	assert.Nil(t, rl.Wait(ctx, RequestConfig{Method: "DELETE", Endpoint: "volume"}))
+	assert.NotNil(t, rl.Wait(ctx, RequestConfig{Method: "DELETE", Endpoint: "host"}))
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, rl.Wait(ctx, RequestConfig{Method: "GET", Endpoint: "volume"}))
+	}
+}
+
+func TestTokenBucketRateLimiter_Priority(t *testing.T) {
+	rl := NewTokenBucketRateLimiter(RateLimiterConfig{
+		Global: RateLimitBudget{RequestsPerSecond: 10, Burst: 1},
+	}, nil)
+	ctx := context.Background()
+	assert.Nil(t, rl.Wait(ctx, RequestConfig{}))
+
+	var mu sync.Mutex
+	var order []RequestPriority
+	var wg sync.WaitGroup
+	run := func(ctx context.Context, cfg RequestConfig) {
+		defer wg.Done()
+		assert.Nil(t, rl.Wait(ctx, cfg))
+		mu.Lock()
+		order = append(order, requestPriority(ctx, cfg))
+		mu.Unlock()
+	}
+	wg.Add(3)
+	go run(ctx, RequestConfig{Priority: PriorityLow})
+	time.Sleep(10 * time.Millisecond)
+	go run(ctx, RequestConfig{})
+	time.Sleep(10 * time.Millisecond)
+	go run(WithPriority(ctx, PriorityHigh), RequestConfig{})
+	wg.Wait()
+
+	assert.Equal(t, []RequestPriority{PriorityHigh, PriorityNormal, PriorityLow}, order)
+}
+
+func TestRequestPriority(t *testing.T) {
+	ctx := WithPriority(context.Background(), PriorityLow)
+	assert.Equal(t, PriorityLow, requestPriority(ctx, RequestConfig{}))
+	assert.Equal(t, PriorityHigh, requestPriority(ctx, RequestConfig{Priority: PriorityHigh}))
+	assert.Equal(t, PriorityNormal, requestPriority(context.Background(), RequestConfig{}))
+	assert.Equal(t, "low", PriorityLow.String())
+	assert.Equal(t, "normal", PriorityNormal.String())
+	assert.Equal(t, "high", PriorityHigh.String())
+}
+
+func TestClient_Query_RateLimiter(t *testing.T) {
+	apiURL := "https://foo"
+	testURL := "mock"
+	c := MockClient(10*time.Second, 0, key)
+	c.apiURL = apiURL
+	c.SetRateLimiter(NewTokenBucketRateLimiter(RateLimiterConfig{
+		Global:        RateLimitBudget{RequestsPerSecond: 0.1},
+		StatsInterval: time.Nanosecond,
+	}, nil))
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, testURL),
+		httpmock.NewStringResponder(http.StatusOK, `{"name": "Foo"}`))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := c.Query(ctx, RequestConfig{Method: "GET", Endpoint: testURL}, &testResp{})
+	assert.Nil(t, err)
+	_, err = c.Query(ctx, RequestConfig{Method: "GET", Endpoint: testURL}, &testResp{})
+	assert.IsType(t, &RateLimiterError{}, err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func Test_newThrottle(t *testing.T) {
+	ctx := context.Background()
+	throttle := newThrottle(time.Second, 0, nil)
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, throttle.Acquire(ctx))
+	}
+	throttle.Release(ctx)
+	assert.Equal(t, throttle, throttle.SetLogger(&defaultLogger{}))
+	assert.IsType(t, &TimeoutSemaphore{}, newThrottle(time.Second, 1, nil))
+}
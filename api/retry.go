@@ -0,0 +1,188 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy defaults
+const (
+	retryPolicyDefaultMaxAttempts    = 3
+	retryPolicyDefaultInitialBackoff = 500 * time.Millisecond
+	retryPolicyDefaultMaxBackoff     = 10 * time.Second
+	retryPolicyDefaultMultiplier     = 2.0
+	retryPolicyDefaultJitter         = 0.2
+)
+
+// RetryPolicy describes how failed requests are retried by ClientIMPL.Query
+type RetryPolicy struct {
+	// total number of attempts, including the first one; values lower than 2 disable retries
+	MaxAttempts int
+	// delay before the first retry
+	InitialBackoff time.Duration
+	// upper bound for the delay between attempts
+	MaxBackoff time.Duration
+	// factor the delay grows by after every attempt
+	Multiplier float64
+	// fraction (0..1) of the delay which is randomized to spread retries of concurrent callers
+	Jitter float64
+	// http statuses which are retried
+	RetryableStatusCodes []int
+	// retry requests which failed before a response was received (connection refused, reset, timeout)
+	RetryOnNetworkErrors bool
+	// optional filter which decides if a specific network error is retried
+	NetworkErrorFilter func(err error) bool
+	// retry non-idempotent requests (POST, PATCH) for every call, not only for requests
+	// which set RequestConfig.Retryable
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a policy which retries throttled and unavailable responses and network errors.
+// 500 is not retried by default because PowerStore uses it for some validation failures.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    retryPolicyDefaultMaxAttempts,
+		InitialBackoff: retryPolicyDefaultInitialBackoff,
+		MaxBackoff:     retryPolicyDefaultMaxBackoff,
+		Multiplier:     retryPolicyDefaultMultiplier,
+		Jitter:         retryPolicyDefaultJitter,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryOnNetworkErrors: true,
+	}
+}
+
+// Backoff returns the delay before the next attempt; attempt is the number of the attempt which just failed
+func (rp *RetryPolicy) Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	multiplier := rp.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := float64(rp.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if rp.MaxBackoff > 0 && delay > float64(rp.MaxBackoff) {
+		delay = float64(rp.MaxBackoff)
+	}
+	jitter := math.Min(math.Max(rp.Jitter, 0), 1)
+	if jitter > 0 {
+		// #nosec G404 -- jitter does not need a cryptographically secure source
+		delay -= delay * jitter * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+func (rp *RetryPolicy) allowsMethod(method string, cfg RequestConfig) bool {
+	switch method {
+	case http.MethodPost, http.MethodPatch:
+		return rp.RetryNonIdempotent || cfg.Retryable
+	default:
+		return true
+	}
+}
+
+func (rp *RetryPolicy) isRetryableStatus(status int) bool {
+	for _, code := range rp.RetryableStatusCodes {
+		if code == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (rp *RetryPolicy) isRetryableError(err error) bool {
+	var apiErr *ErrorMsg
+	if errors.As(err, &apiErr) {
+		return rp.isRetryableStatus(apiErr.StatusCode)
+	}
+	if !rp.RetryOnNetworkErrors || !isNetworkError(err) {
+		return false
+	}
+	if rp.NetworkErrorFilter != nil {
+		return rp.NetworkErrorFilter(err)
+	}
+	return true
+}
+
+// shouldRetry checks if the request which failed on the given attempt may be sent again
+func (rp *RetryPolicy) shouldRetry(ctx context.Context, cfg RequestConfig, attempt int, err error) bool {
+	if rp == nil || err == nil || attempt >= rp.MaxAttempts {
+		return false
+	}
+	if ctx.Err() != nil {
+		return false
+	}
+	return rp.allowsMethod(cfg.Method, cfg) && rp.isRetryableError(err)
+}
+
+func isNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// parseRetryAfter reads delay from Retry-After header which may contain seconds or http date
+func parseRetryAfter(h http.Header) time.Duration {
+	value := h.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+// waitForRetry sleeps before the next attempt, returns false if there is not enough time left in the context
+func waitForRetry(ctx context.Context, delay time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+		return false
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
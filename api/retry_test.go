@@ -0,0 +1,191 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	rp := DefaultRetryPolicy()
+	rp.InitialBackoff = time.Millisecond
+	rp.MaxBackoff = 5 * time.Millisecond
+	return rp
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	rp := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+	assert.Equal(t, 100*time.Millisecond, rp.Backoff(1))
+	assert.Equal(t, 200*time.Millisecond, rp.Backoff(2))
+	assert.Equal(t, 400*time.Millisecond, rp.Backoff(3))
+	assert.Equal(t, time.Second, rp.Backoff(10))
+
+	rp.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := rp.Backoff(1)
+		assert.GreaterOrEqual(t, delay, 50*time.Millisecond)
+		assert.LessOrEqual(t, delay, 100*time.Millisecond)
+	}
+}
+
+func TestRetryPolicy_shouldRetry(t *testing.T) {
+	ctx := context.Background()
+	rp := DefaultRetryPolicy()
+	unavailable := &ErrorMsg{StatusCode: http.StatusServiceUnavailable}
+	badRequest := &ErrorMsg{StatusCode: http.StatusBadRequest}
+	get := RequestConfig{Method: http.MethodGet}
+	post := RequestConfig{Method: http.MethodPost}
+
+	var nilPolicy *RetryPolicy
+	assert.False(t, nilPolicy.shouldRetry(ctx, get, 1, unavailable))
+	assert.True(t, rp.shouldRetry(ctx, get, 1, unavailable))
+	assert.False(t, rp.shouldRetry(ctx, get, rp.MaxAttempts, unavailable))
+	assert.False(t, rp.shouldRetry(ctx, get, 1, badRequest))
+	assert.False(t, rp.shouldRetry(ctx, get, 1, errors.New("decode error")))
+	assert.False(t, rp.shouldRetry(ctx, get, 1, &TimeoutSemaphoreError{}))
+
+	assert.False(t, rp.shouldRetry(ctx, post, 1, unavailable))
+	post.Retryable = true
+	assert.True(t, rp.shouldRetry(ctx, post, 1, unavailable))
+	rp.RetryNonIdempotent = true
+	assert.True(t, rp.shouldRetry(ctx, RequestConfig{Method: http.MethodPatch}, 1, unavailable))
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.False(t, rp.shouldRetry(canceled, get, 1, unavailable))
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	h := http.Header{}
+	assert.Equal(t, time.Duration(0), parseRetryAfter(h))
+	h.Set("Retry-After", "3")
+	assert.Equal(t, 3*time.Second, parseRetryAfter(h))
+	h.Set("Retry-After", "foo")
+	assert.Equal(t, time.Duration(0), parseRetryAfter(h))
+}
+
+func TestClient_Query_Retry(t *testing.T) {
+	apiURL := "https://foo"
+	testURL := "mock"
+	c := testClient(t, apiURL)
+	c.SetRetryPolicy(testRetryPolicy())
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	callCount := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, testURL),
+		func(_ *http.Request) (*http.Response, error) {
+			callCount++
+			if callCount < 3 {
+				return httpmock.NewStringResponse(http.StatusServiceUnavailable, ""), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, `{"name": "Foo"}`), nil
+		})
+
+	resp := &testResp{}
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: testURL}, resp)
+	assert.Nil(t, err)
+	assert.Equal(t, "Foo", resp.Name)
+	assert.Equal(t, 3, callCount)
+}
+
+func TestClient_Query_RetryExhausted(t *testing.T) {
+	apiURL := "https://foo"
+	testURL := "mock"
+	c := testClient(t, apiURL)
+	c.SetRetryPolicy(testRetryPolicy())
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, testURL),
+		httpmock.NewStringResponder(http.StatusTooManyRequests, ""))
+
+	meta, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: testURL}, &testResp{})
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, meta.Status)
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+}
+
+func TestClient_Query_RetryNetworkError(t *testing.T) {
+	apiURL := "https://foo"
+	testURL := "mock"
+	c := testClient(t, apiURL)
+	c.SetRetryPolicy(testRetryPolicy())
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/%s", apiURL, testURL),
+		httpmock.NewErrorResponder(errors.New("connection refused")))
+
+	_, err := c.Query(context.Background(), RequestConfig{Method: "DELETE", Endpoint: testURL}, &testResp{})
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+}
+
+func TestClient_Query_NoRetryForPost(t *testing.T) {
+	apiURL := "https://foo"
+	testURL := "mock"
+	c := testClient(t, apiURL)
+	c.SetRetryPolicy(testRetryPolicy())
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/%s", apiURL, testURL),
+		httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+
+	_, err := c.Query(context.Background(), RequestConfig{Method: "POST", Endpoint: testURL}, &testResp{})
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+
+	httpmock.ZeroCallCounters()
+	_, err = c.Query(context.Background(), RequestConfig{Method: "POST", Endpoint: testURL, Retryable: true}, &testResp{})
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+}
+
+func TestClient_Query_RetryHonorsDeadline(t *testing.T) {
+	apiURL := "https://foo"
+	testURL := "mock"
+	c := testClient(t, apiURL)
+	rp := testRetryPolicy()
+	rp.InitialBackoff = time.Minute
+	rp.MaxBackoff = time.Minute
+	c.SetRetryPolicy(rp)
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, testURL),
+		httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err := c.Query(ctx, RequestConfig{Method: "GET", Endpoint: testURL}, &testResp{})
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,323 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// selectTag is a struct tag which controls how a field appears in select expression.
+// Options are separated by comma:
+//
+//   - "-": field is not requested
+//   - "embed": field is an embedded resource, its fields are selected from the nested struct: name(a,b)
+//   - "star": with embed, embedded resource selects all its fields and its own embedded resources: name(*,c(d))
+//   - "all": embedded resource selects all its fields: name(*)
+//   - "fields=a|b": embedded resource selects only the listed fields of the nested struct: name(a,b)
+//   - "explicit": field is not returned for "*" and is listed after it
+//   - "min=3.0": field is supported since the given array version
+const selectTag = "select"
+
+type selectField struct {
+	name       string
+	minVersion ArrayVersion
+	embed      bool
+	star       bool
+	all        bool
+	explicit   bool
+	only       []string
+	elem       reflect.Type
+}
+
+// supportedBy returns true if the array version supports the field, unknown (zero) version
+// supports only fields without minimal version
+func (f selectField) supportedBy(version ArrayVersion) bool {
+	return f.minVersion.IsZero() || (!version.IsZero() && version.AtLeast(f.minVersion))
+}
+
+var selectFieldsCache sync.Map // reflect.Type -> []selectField
+
+// SelectFields returns PowerStore select expression built from json tags of struct v,
+// embedded resources are rendered with their nested fields, e.g. policies(id,name).
+// Fields which require a minimal array version are included, see SelectFieldsForVersion.
+func SelectFields(v any) []string {
+	return selectFieldsOf(v, false, anyVersion)
+}
+
+// SelectAllFields is SelectFields for structs which request all fields with "*",
+// only embedded resources are listed after it
+func SelectAllFields(v any) []string {
+	return selectFieldsOf(v, true, anyVersion)
+}
+
+// SelectFieldsForVersion is SelectFieldsForArrayVersion for major.minor version such as 3.6
+//
+// Deprecated: use SelectFieldsForArrayVersion, float versions can't represent minor versions above 9
+func SelectFieldsForVersion(v any, version float32) []string {
+	return SelectFieldsForArrayVersion(v, ArrayVersionFromFloat(version))
+}
+
+// SelectFieldsForArrayVersion is SelectFields without fields the array version doesn't support,
+// zero version means unknown version and excludes all fields which require a minimal version
+func SelectFieldsForArrayVersion(v any, version ArrayVersion) []string {
+	return selectFieldsOf(v, false, version)
+}
+
+func selectFieldsOf(v any, star bool, version ArrayVersion) []string {
+	t := structType(reflect.TypeOf(v))
+	if t == nil {
+		return nil
+	}
+	return renderSelectFields(t, star, version, map[reflect.Type]bool{})
+}
+
+// PruneFields is PruneFieldsForArrayVersion for major.minor version such as 3.6
+//
+// Deprecated: use PruneFieldsForArrayVersion, float versions can't represent minor versions above 9
+func PruneFields(v any, fields []string, version float32) []string {
+	return PruneFieldsForArrayVersion(v, fields, ArrayVersionFromFloat(version))
+}
+
+// PruneFieldsForArrayVersion removes from fields the top level fields of struct v which the array version
+// doesn't support, zero version means unknown version like in SelectFieldsForArrayVersion
+func PruneFieldsForArrayVersion(v any, fields []string, version ArrayVersion) []string {
+	t := structType(reflect.TypeOf(v))
+	if t == nil {
+		return fields
+	}
+	unsupported := make(map[string]bool)
+	for _, f := range parseSelectFields(t) {
+		if !f.supportedBy(version) {
+			unsupported[f.name] = true
+		}
+	}
+	if len(unsupported) == 0 {
+		return fields
+	}
+	res := make([]string, 0, len(fields))
+	for _, field := range fields {
+		name, _, _ := strings.Cut(field, "(")
+		if !unsupported[strings.TrimSpace(name)] {
+			res = append(res, field)
+		}
+	}
+	return res
+}
+
+func renderSelectFields(t reflect.Type, star bool, version ArrayVersion, visiting map[reflect.Type]bool) []string {
+	visiting[t] = true
+	defer delete(visiting, t)
+	var res []string
+	if star {
+		res = append(res, "*")
+	}
+	for _, f := range parseSelectFields(t) {
+		switch {
+		case !f.supportedBy(version):
+		case !f.embed:
+			if !star || f.explicit {
+				res = append(res, f.name)
+			}
+		case f.all || visiting[f.elem]:
+			res = append(res, f.name+"(*)")
+		case len(f.only) != 0:
+			res = append(res, f.name+"("+strings.Join(onlyFields(f.elem, f.only, version), ",")+")")
+		default:
+			nested := renderSelectFields(f.elem, f.star, version, visiting)
+			res = append(res, f.name+"("+strings.Join(nested, ",")+")")
+		}
+	}
+	return res
+}
+
+// onlyFields returns the listed fields without the ones the array version doesn't support,
+// unknown names are kept so ValidateSelectFields reports them
+func onlyFields(t reflect.Type, only []string, version ArrayVersion) []string {
+	unsupported := make(map[string]bool)
+	for _, f := range parseSelectFields(t) {
+		if !f.supportedBy(version) {
+			unsupported[f.name] = true
+		}
+	}
+	var res []string
+	for _, name := range only {
+		if !unsupported[name] {
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+// ValidateSelectFields checks select expression against json tags of struct v. Every selected field,
+// nested ones included, must exist in the struct and, unless "*" is selected, every field of the struct
+// must be selected.
+func ValidateSelectFields(v any, fields []string) error {
+	t := structType(reflect.TypeOf(v))
+	if t == nil {
+		return fmt.Errorf("%T is not a struct", v)
+	}
+	var items []string
+	for _, f := range fields {
+		items = append(items, splitSelect(f)...)
+	}
+	return validateSelect(t, items, true, t.Name())
+}
+
+func validateSelect(t reflect.Type, items []string, complete bool, path string) error {
+	known := make(map[string]selectField)
+	for _, f := range parseSelectFields(t) {
+		known[f.name] = f
+	}
+	var errs []error
+	selected := make(map[string]bool)
+	for _, item := range items {
+		name, nested, hasNested := strings.Cut(item, "(")
+		name = strings.TrimSpace(name)
+		if name == "*" {
+			complete = false
+			continue
+		}
+		f, ok := known[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown field %q", path, name))
+			continue
+		}
+		selected[name] = true
+		if !hasNested {
+			continue
+		}
+		elem := f.elem
+		if elem == nil {
+			errs = append(errs, fmt.Errorf("%s: field %q is not an embedded resource", path, name))
+			continue
+		}
+		nested = strings.TrimSuffix(strings.TrimSpace(nested), ")")
+		if err := validateSelect(elem, splitSelect(nested), false, path+"."+name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if complete {
+		for _, f := range parseSelectFields(t) {
+			if !selected[f.name] {
+				errs = append(errs, fmt.Errorf("%s: field %q is not selected", path, f.name))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// splitSelect splits select expression by commas which are not inside parentheses
+func splitSelect(s string) []string {
+	var res []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				res = append(res, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" {
+		res = append(res, last)
+	}
+	return res
+}
+
+// structType returns struct type behind pointers, slices and arrays or nil
+func structType(t reflect.Type) reflect.Type {
+	for t != nil {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array:
+			t = t.Elem()
+		case reflect.Struct:
+			return t
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func parseSelectFields(t reflect.Type) []selectField {
+	if cached, ok := selectFieldsCache.Load(t); ok {
+		return cached.([]selectField)
+	}
+	var res []selectField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		jsonTag := sf.Tag.Get("json")
+		name, _, _ := strings.Cut(jsonTag, ",")
+		if sf.Anonymous && name == "" {
+			if et := structType(sf.Type); et != nil {
+				// fields of embedded struct are promoted like encoding/json does
+				res = append(res, parseSelectFields(et)...)
+				continue
+			}
+		}
+		if !sf.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		f := selectField{name: name}
+		skip := false
+		for _, opt := range strings.Split(sf.Tag.Get(selectTag), ",") {
+			switch {
+			case opt == "-":
+				skip = true
+			case opt == "embed":
+				f.embed = true
+			case opt == "star":
+				f.star = true
+			case opt == "all":
+				f.embed = true
+				f.all = true
+			case opt == "explicit":
+				f.explicit = true
+			case strings.HasPrefix(opt, "fields="):
+				f.embed = true
+				f.only = strings.Split(strings.TrimPrefix(opt, "fields="), "|")
+			case strings.HasPrefix(opt, "min="):
+				if v, err := ParseArrayVersion(strings.TrimPrefix(opt, "min=")); err == nil {
+					f.minVersion = v
+				}
+			}
+		}
+		if skip {
+			continue
+		}
+		if f.elem = structType(sf.Type); f.elem == nil {
+			f.embed, f.star, f.all, f.only = false, false, false, nil
+		}
+		res = append(res, f)
+	}
+	selectFieldsCache.Store(t, res)
+	return res
+}
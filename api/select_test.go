@@ -0,0 +1,182 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type selectChild struct {
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	State string `json:"state" select:"min=3.0"`
+}
+
+type selectBase struct {
+	Description string `json:"description"`
+}
+
+type selectParent struct {
+	selectBase
+	ID         string        `json:"id"`
+	Secret     string        `json:"-"`
+	Ignored    string        `json:"ignored" select:"-"`
+	NQN        string        `json:"nqn" select:"min=3.0"`
+	DRTest     bool          `json:"is_dr_test" select:"min=3.7"`
+	Children   []selectChild `json:"children" select:"embed"`
+	Owner      *selectChild  `json:"owner" select:"fields=id|name"`
+	Everything selectChild   `json:"everything" select:"all"`
+	Details    selectChild   `json:"details" select:"explicit"`
+	Parent     *selectParent `json:"parent" select:"embed"`
+	unexported string
+}
+
+func TestSelectFields(t *testing.T) {
+	assert.Equal(t, []string{
+		"description", "id", "nqn", "is_dr_test", "children(id,name,state)", "owner(id,name)",
+		"everything(*)", "details", "parent(*)",
+	}, SelectFields(&selectParent{}))
+	assert.Equal(t, []string{"id", "name", "state"}, SelectFields([]selectChild{}))
+	assert.Nil(t, SelectFields("not a struct"))
+}
+
+func TestSelectAllFields(t *testing.T) {
+	assert.Equal(t, []string{
+		"*", "children(id,name,state)", "owner(id,name)", "everything(*)", "details", "parent(*)",
+	}, SelectAllFields(selectParent{}))
+	assert.Equal(t, []string{"*"}, SelectAllFields(&selectChild{}))
+}
+
+func TestSelectFields_Star(t *testing.T) {
+	type group struct {
+		ID       string `json:"id"`
+		Mappings []struct {
+			ID    string      `json:"id"`
+			Child selectChild `json:"child" select:"all"`
+		} `json:"mappings" select:"embed,star"`
+	}
+	assert.Equal(t, []string{"*", "mappings(*,child(*))"}, SelectAllFields(&group{}))
+}
+
+func TestSelectFieldsForVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version float32
+		want    []string
+	}{
+		{"unknown", 0, []string{
+			"description", "id", "children(id,name)", "owner(id,name)", "everything(*)", "details", "parent(*)",
+		}},
+		{"old", 2.1, []string{
+			"description", "id", "children(id,name)", "owner(id,name)", "everything(*)", "details", "parent(*)",
+		}},
+		{"3.0", 3.0, []string{
+			"description", "id", "nqn", "children(id,name,state)", "owner(id,name)", "everything(*)", "details", "parent(*)",
+		}},
+		{"3.6", 3.6, []string{
+			"description", "id", "nqn", "children(id,name,state)", "owner(id,name)", "everything(*)", "details", "parent(*)",
+		}},
+		{"3.7", float32(3) + float32(7)*0.1, []string{
+			"description", "id", "nqn", "is_dr_test", "children(id,name,state)", "owner(id,name)", "everything(*)",
+			"details", "parent(*)",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SelectFieldsForVersion(&selectParent{}, tt.version))
+		})
+	}
+}
+
+func TestPruneFields(t *testing.T) {
+	fields := []string{"id", "nqn", "is_dr_test", "children(id,state)"}
+	assert.Equal(t, []string{"id", "children(id,state)"}, PruneFields(&selectParent{}, fields, 0))
+	assert.Equal(t, []string{"id", "nqn", "children(id,state)"}, PruneFields(&selectParent{}, fields, 3.2))
+	assert.Equal(t, fields, PruneFields(&selectParent{}, fields, 3.7))
+	assert.Equal(t, fields, PruneFields(42, fields, 0))
+}
+
+func TestSelectFieldsForArrayVersion(t *testing.T) {
+	// 3.10 is newer than 3.7 although 3.1 as float isn't
+	assert.Contains(t, SelectFieldsForArrayVersion(&selectParent{}, MustParseArrayVersion("3.10")), "is_dr_test")
+	assert.NotContains(t, SelectFieldsForArrayVersion(&selectParent{}, MustParseArrayVersion("3.6.9.9")), "is_dr_test")
+	fields := []string{"id", "is_dr_test"}
+	assert.Equal(t, fields, PruneFieldsForArrayVersion(&selectParent{}, fields, MustParseArrayVersion("3.10")))
+	assert.Equal(t, []string{"id"}, PruneFieldsForArrayVersion(&selectParent{}, fields, ArrayVersion{}))
+}
+
+func TestValidateSelectFields(t *testing.T) {
+	assert.NoError(t, ValidateSelectFields(&selectParent{}, SelectFields(&selectParent{})))
+	assert.NoError(t, ValidateSelectFields(&selectParent{}, SelectAllFields(&selectParent{})))
+	assert.NoError(t, ValidateSelectFields(&selectChild{}, []string{"*"}))
+	assert.NoError(t, ValidateSelectFields(&selectChild{}, []string{"id,name", "state"}))
+
+	err := ValidateSelectFields(&selectChild{}, []string{"id", "nmae"})
+	assert.ErrorContains(t, err, `selectChild: unknown field "nmae"`)
+	assert.ErrorContains(t, err, `selectChild: field "name" is not selected`)
+	assert.ErrorContains(t, err, `selectChild: field "state" is not selected`)
+
+	err = ValidateSelectFields(&selectParent{}, []string{"*", "children(id, size)", "id(name)"})
+	assert.ErrorContains(t, err, `selectParent.children: unknown field "size"`)
+	assert.ErrorContains(t, err, `selectParent: field "id" is not an embedded resource`)
+
+	assert.Error(t, ValidateSelectFields("string", nil))
+}
+
+func TestClientIMPL_QueryParamsWithFields_ArrayVersion(t *testing.T) {
+	c := testClient(t, "https://foo")
+	fp := &selectParent{}
+	assert.Equal(t, float32(0), c.ArrayVersion())
+	assert.NotContains(t, c.QueryParamsWithFields(fp).Encode(), "nqn")
+
+	c.SetArrayVersion(3.6)
+	assert.Equal(t, float32(3.6), c.ArrayVersion())
+	encoded := c.QueryParamsWithFields(fp).Encode()
+	assert.Contains(t, encoded, "nqn")
+	assert.NotContains(t, encoded, "is_dr_test")
+}
+
+func (p *selectParent) Fields() []string {
+	return SelectFields(p)
+}
+
+type versionedSelectChild struct {
+	selectChild
+}
+
+func (p *versionedSelectChild) Fields() []string {
+	return []string{"id"}
+}
+
+func (p *versionedSelectChild) FieldsForVersion(version ArrayVersion) []string {
+	if version.IsZero() {
+		return p.Fields()
+	}
+	return SelectFieldsForArrayVersion(p, version)
+}
+
+func TestClientIMPL_QueryParamsWithFields_VersionedFieldProvider(t *testing.T) {
+	c := testClient(t, "https://foo")
+	assert.Equal(t, "select=id", c.QueryParamsWithFields(&versionedSelectChild{}).Encode())
+
+	c.SetVersion(MustParseArrayVersion("3.6"))
+	assert.Equal(t, "select=id%2Cname%2Cstate", c.QueryParamsWithFields(&versionedSelectChild{}).Encode())
+}
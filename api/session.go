@@ -0,0 +1,260 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	loginSessionURL = "login_session"
+	logoutURL       = "logout"
+	// share of the idle timeout after which the session is refreshed before the next request
+	sessionRefreshRatio = 0.9
+)
+
+// AuthError is returned when PowerStore rejects provided credentials
+type AuthError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("authentication failed: %s", e.Err.Error())
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+type loginSession struct {
+	ID string `json:"id"`
+	// session idle timeout in seconds
+	IdleTimeout int `json:"idle_timeout"`
+}
+
+// sessionJar is a cookie jar which can be dropped when the session is closed
+type sessionJar struct {
+	mu  sync.RWMutex
+	jar http.CookieJar
+}
+
+func newSessionJar() (*sessionJar, error) {
+	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: nil})
+	if err != nil {
+		return nil, err
+	}
+	return &sessionJar{jar: jar}, nil
+}
+
+func (j *sessionJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	j.jar.SetCookies(u, cookies)
+}
+
+func (j *sessionJar) Cookies(u *url.URL) []*http.Cookie {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.jar.Cookies(u)
+}
+
+func (j *sessionJar) reset() error {
+	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: nil})
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.jar = jar
+	return nil
+}
+
+func (c *ClientIMPL) getToken() string {
+	c.sessionMutex.RLock()
+	defer c.sessionMutex.RUnlock()
+	return c.token
+}
+
+// touchSession saves token of a successful response and marks session as active
+func (c *ClientIMPL) touchSession(token string) {
+	c.sessionMutex.Lock()
+	defer c.sessionMutex.Unlock()
+	if len(token) != 0 {
+		c.token = token
+	}
+	c.lastUsed = time.Now()
+}
+
+// sessionExpiring returns true if the session was idle long enough to be dropped by the array soon
+func (c *ClientIMPL) sessionExpiring() bool {
+	c.sessionMutex.RLock()
+	defer c.sessionMutex.RUnlock()
+	if c.idleTimeout <= 0 || c.lastUsed.IsZero() {
+		return false
+	}
+	return time.Since(c.lastUsed) > time.Duration(float64(c.idleTimeout)*sessionRefreshRatio)
+}
+
+func (c *ClientIMPL) login(ctx context.Context) (RespMeta, error) {
+	apiURL := c.APIURL()
+	meta, err := c.refreshSession(ctx, time.Time{})
+	if len(c.apiURLs) > 1 && isConnectionError(err) && c.failover(ctx, apiURL) {
+		// failover has logged in using another management address
+		return RespMeta{Status: http.StatusOK}, nil
+	}
+	return meta, err
+}
+
+// refreshSession creates a new login session unless one was already created after notBefore
+func (c *ClientIMPL) refreshSession(ctx context.Context, notBefore time.Time) (RespMeta, error) {
+	c.loginMutex.Lock()
+	defer c.loginMutex.Unlock()
+
+	c.sessionMutex.RLock()
+	lastLogin := c.lastLogin
+	c.sessionMutex.RUnlock()
+	if !notBefore.IsZero() && lastLogin.After(notBefore) {
+		// another goroutine has already logged in while this request was in flight
+		return RespMeta{Status: http.StatusOK}, nil
+	}
+
+	var cancelFuncPtr *func()
+	ctx, cancelFuncPtr = c.setupContext(ctx)
+	if cancelFuncPtr != nil {
+		defer (*cancelFuncPtr)()
+	}
+
+	if !notBefore.IsZero() {
+		c.metrics().Relogin()
+	}
+	if err := c.loadCredentials(ctx); err != nil {
+		return RespMeta{}, err
+	}
+	ctx, span := c.startSpan(ctx, "PowerStore login")
+	var sessions []loginSession
+	meta, _, err := c.queryOnce(ctx,
+		RequestConfig{
+			Method:   "GET",
+			Endpoint: loginSessionURL,
+			Priority: PriorityHigh,
+		}, &sessions)
+	endSpan(span, err)
+	if err != nil {
+		return meta, err
+	}
+	c.rememberCluster(ctx)
+
+	c.sessionMutex.Lock()
+	defer c.sessionMutex.Unlock()
+	c.lastLogin = time.Now()
+	c.lastUsed = c.lastLogin
+	if len(sessions) > 0 {
+		c.idleTimeout = time.Duration(sessions[0].IdleTimeout) * time.Second
+	}
+	return meta, nil
+}
+
+// queryWithSession sends request and re-login once if the session has expired
+func (c *ClientIMPL) queryWithSession(
+	ctx context.Context,
+	config RequestConfig,
+	traceMsg string,
+	resp interface{},
+) (RespMeta, time.Duration, error) {
+	if c.sessionExpiring() {
+		c.logger.Debug(ctx, "%ssession is about to expire, refreshing it", traceMsg)
+		if _, err := c.refreshSession(ctx, time.Now()); err != nil {
+			c.logger.Error(ctx, "%sfailed to refresh session: %s", traceMsg, err.Error())
+		}
+	}
+
+	sentAt := time.Now()
+	meta, retryAfter, err := c.queryOnce(ctx, config, resp)
+	if err == nil || (meta.Status != http.StatusForbidden && meta.Status != http.StatusUnauthorized) {
+		return meta, retryAfter, err
+	}
+
+	// the session has expired or credentials were rotated, log in with fresh credentials once,
+	// no need to retry if login api has failed
+	if _, loginErr := c.refreshSession(ctx, sentAt); loginErr != nil {
+		c.logger.Error(ctx, "%sfailed to log in again: %s", traceMsg, loginErr.Error())
+		return meta, retryAfter, err
+	}
+	// login successful - resend the failed request
+	return c.queryOnce(ctx, config, resp)
+}
+
+// Ping checks that the array is reachable and the session is valid
+func (c *ClientIMPL) Ping(ctx context.Context) error {
+	var sessions []loginSession
+	_, err := c.Query(ctx,
+		RequestConfig{
+			Method:   "GET",
+			Endpoint: loginSessionURL,
+		}, &sessions)
+	return err
+}
+
+// Close ends the login session, the client logs in again if it is used afterwards
+func (c *ClientIMPL) Close(ctx context.Context) error {
+	c.loginMutex.Lock()
+	defer c.loginMutex.Unlock()
+
+	var err error
+	if len(c.getToken()) != 0 {
+		var cancelFuncPtr *func()
+		ctx, cancelFuncPtr = c.setupContext(ctx)
+		if cancelFuncPtr != nil {
+			defer (*cancelFuncPtr)()
+		}
+		var resp struct{}
+		_, _, err = c.queryOnce(ctx,
+			RequestConfig{
+				Method:   "POST",
+				Endpoint: logoutURL,
+			}, &resp)
+	}
+
+	c.sessionMutex.Lock()
+	c.token = ""
+	c.lastUsed = time.Time{}
+	c.lastLogin = time.Time{}
+	c.sessionMutex.Unlock()
+	if c.sessionJar != nil {
+		err = errors.Join(err, c.sessionJar.reset())
+	}
+	return err
+}
+
+func newAuthError(err error) error {
+	var apiErr *ErrorMsg
+	if errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden) {
+		return &AuthError{StatusCode: apiErr.StatusCode, Err: err}
+	}
+	return err
+}
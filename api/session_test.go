@@ -0,0 +1,187 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func testConfig(apiURL string, transport http.RoundTripper) Config {
+	return Config{
+		APIURL:         apiURL,
+		Username:       "admin",
+		Password:       "password",
+		DefaultTimeout: 10 * time.Second,
+		RateLimit:      10,
+		RequestIDKey:   key,
+		Transport:      TransportOptions{RoundTripper: transport},
+	}
+}
+
+func TestNewWithContext(t *testing.T) {
+	apiURL := "https://foo"
+	transport := httpmock.NewMockTransport()
+	transport.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, loginSessionURL),
+		func(_ *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(http.StatusOK, `[{"id": "1", "idle_timeout": 3600}]`)
+			resp.Header.Set(dellEmcToken, "token")
+			return resp, nil
+		})
+
+	c, err := NewWithContext(context.Background(), testConfig(apiURL, transport))
+	assert.Nil(t, err)
+	assert.Equal(t, "token", c.getToken())
+	assert.Equal(t, time.Hour, c.idleTimeout)
+}
+
+func TestNewWithContext_AuthError(t *testing.T) {
+	apiURL := "https://foo"
+	transport := httpmock.NewMockTransport()
+	transport.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, loginSessionURL),
+		httpmock.NewStringResponder(http.StatusUnauthorized,
+			`{"messages": [{"code": "0xE09010010013", "severity": "Error", "message_l10n": "Invalid credentials"}]}`))
+
+	c, err := NewWithContext(context.Background(), testConfig(apiURL, transport))
+	assert.Nil(t, c)
+	var authErr *AuthError
+	assert.True(t, errors.As(err, &authErr))
+	assert.Equal(t, http.StatusUnauthorized, authErr.StatusCode)
+	assert.Contains(t, err.Error(), "Invalid credentials")
+
+	transport.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, loginSessionURL),
+		httpmock.NewErrorResponder(errors.New("connection refused")))
+	_, err = NewWithContext(context.Background(), testConfig(apiURL, transport))
+	assert.NotNil(t, err)
+	assert.False(t, errors.As(err, &authErr))
+}
+
+func TestClientIMPL_Ping(t *testing.T) {
+	apiURL := "https://foo"
+	c := testClient(t, apiURL)
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, loginSessionURL),
+		httpmock.NewStringResponder(http.StatusOK, `[{"id": "1"}]`))
+	assert.Nil(t, c.Ping(context.Background()))
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, loginSessionURL),
+		httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+	assert.NotNil(t, c.Ping(context.Background()))
+}
+
+func TestClientIMPL_Close(t *testing.T) {
+	apiURL := "https://foo"
+	c := testClient(t, apiURL)
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/%s", apiURL, logoutURL),
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "token", req.Header.Get(dellEmcToken))
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	c.touchSession("token")
+	assert.Nil(t, c.Close(context.Background()))
+	assert.Empty(t, c.getToken())
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+
+	// nothing to end when there is no session
+	assert.Nil(t, c.Close(context.Background()))
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestClientIMPL_ProactiveSessionRefresh(t *testing.T) {
+	apiURL := "https://foo"
+	testURL := "mock"
+	c := testClient(t, apiURL)
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, loginSessionURL),
+		httpmock.NewStringResponder(http.StatusOK, `[{"id": "1", "idle_timeout": 60}]`))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, testURL),
+		httpmock.NewStringResponder(http.StatusOK, `{"name": "Foo"}`))
+
+	c.idleTimeout = time.Minute
+	c.lastUsed = time.Now().Add(-55 * time.Second)
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: testURL}, &testResp{})
+	assert.Nil(t, err)
+	info := httpmock.GetCallCountInfo()
+	assert.Equal(t, 1, info[fmt.Sprintf("GET %s/%s", apiURL, loginSessionURL)])
+
+	// session is fresh now, no login is expected
+	_, err = c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: testURL}, &testResp{})
+	assert.Nil(t, err)
+	info = httpmock.GetCallCountInfo()
+	assert.Equal(t, 1, info[fmt.Sprintf("GET %s/%s", apiURL, loginSessionURL)])
+}
+
+func TestClientIMPL_ConcurrentRelogin(t *testing.T) {
+	apiURL := "https://foo"
+	testURL := "mock"
+	c := testClient(t, apiURL)
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	var mu sync.Mutex
+	loggedIn := false
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, loginSessionURL),
+		func(_ *http.Request) (*http.Response, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			loggedIn = true
+			resp := httpmock.NewStringResponse(http.StatusOK, `[{"id": "1"}]`)
+			resp.Header.Set(dellEmcToken, "token")
+			return resp, nil
+		})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", apiURL, testURL),
+		func(_ *http.Request) (*http.Response, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if !loggedIn {
+				return httpmock.NewStringResponse(http.StatusForbidden, ""), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, `{"name": "Foo"}`), nil
+		})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := &testResp{}
+			_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: testURL}, resp)
+			assert.Nil(t, err)
+			assert.Equal(t, "Foo", resp.Name)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, "token", c.getToken())
+}
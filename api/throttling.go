@@ -1,6 +1,6 @@
 /*
  *
- * Copyright © 2021-2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+ * Copyright © 2021-2025 Dell Inc. or its subsidiaries. All Rights Reserved.
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -56,6 +56,27 @@ func NewTimeoutSemaphore(timeout time.Duration, rateLimit int, logger Logger) *T
 	}
 }
 
+// newThrottle returns semaphore limiting concurrent requests, rateLimit lower than 1 disables the limit
+func newThrottle(timeout time.Duration, rateLimit int, logger Logger) TimeoutSemaphoreInterface {
+	if rateLimit < 1 {
+		return &unlimitedSemaphore{}
+	}
+	return NewTimeoutSemaphore(timeout, rateLimit, logger)
+}
+
+// unlimitedSemaphore is used when concurrency is limited only by requests per second
+type unlimitedSemaphore struct{}
+
+func (us *unlimitedSemaphore) Acquire(_ context.Context) error {
+	return nil
+}
+
+func (us *unlimitedSemaphore) Release(_ context.Context) {}
+
+func (us *unlimitedSemaphore) SetLogger(_ Logger) TimeoutSemaphoreInterface {
+	return us
+}
+
 func (ts *TimeoutSemaphore) Acquire(ctx context.Context) error {
 	// find the min timeout between default timeout and context timeout
 	timeout := ts.Timeout
@@ -18,6 +18,7 @@ package api
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -35,9 +36,20 @@ func TestSemaphore(t *testing.T) {
 		return nil
 	}
 
+	// background calls must finish before the test ends, they log through the default logger
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	background := func(sec int, ctx context.Context, ts TimeoutSemaphoreInterface) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = f(sec, ctx, ts)
+		}()
+	}
+
 	// long running function
 	ts := NewTimeoutSemaphore(1*time.Second, 1, &defaultLogger{})
-	go f(3, context.Background(), ts)
+	background(3, context.Background(), ts)
 	// wait for run long function
 	time.Sleep(1 * time.Second)
 	err := f(1, context.Background(), ts)
@@ -45,7 +57,7 @@ func TestSemaphore(t *testing.T) {
 
 	// fast running function
 	ts = NewTimeoutSemaphore(2*time.Second, 1, &defaultLogger{})
-	go f(1, context.Background(), ts)
+	background(1, context.Background(), ts)
 	err = f(2, context.Background(), ts)
 	assert.Nil(t, err)
 
@@ -53,7 +65,7 @@ func TestSemaphore(t *testing.T) {
 	ts = NewTimeoutSemaphore(3*time.Second, 1, &defaultLogger{})
 	testCtx, cancelFunc := context.WithDeadline(context.Background(), time.Now().Add(1*time.Second))
 	defer cancelFunc()
-	go f(1, testCtx, ts)
+	background(1, testCtx, ts)
 	err = f(2, testCtx, ts)
 	assert.Nil(t, err)
 }
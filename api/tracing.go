@@ -0,0 +1,139 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation scope of spans created by the client
+const tracerName = "github.com/dell/gopowerstore/api"
+
+// Span attributes
+const (
+	attrMethod          = attribute.Key("http.request.method")
+	attrStatusCode      = attribute.Key("http.response.status_code")
+	attrEndpoint        = attribute.Key("powerstore.endpoint")
+	attrResourceID      = attribute.Key("powerstore.resource.id")
+	attrAction          = attribute.Key("powerstore.action")
+	attrPaginationFirst = attribute.Key("powerstore.pagination.first")
+	attrPaginationLast  = attribute.Key("powerstore.pagination.last")
+	attrPaginationTotal = attribute.Key("powerstore.pagination.total")
+	attrErrorCodes      = attribute.Key("powerstore.error.codes")
+	attrAttempt         = attribute.Key("powerstore.attempt")
+)
+
+// traceContext propagates spans in W3C traceparent and tracestate headers
+var traceContext = propagation.TraceContext{}
+
+// SetTracerProvider sets OpenTelemetry provider of spans, nil disables tracing, the global provider
+// is not used unless it is passed explicitly
+func (c *ClientIMPL) SetTracerProvider(tp trace.TracerProvider) {
+	c.tracerProvider = tp
+}
+
+func (c *ClientIMPL) tracer() trace.Tracer {
+	if c.tracerProvider != nil {
+		return c.tracerProvider.Tracer(tracerName)
+	}
+	return noop.NewTracerProvider().Tracer(tracerName)
+}
+
+// startQuerySpan opens a client span which covers all attempts of the request
+func (c *ClientIMPL) startQuerySpan(ctx context.Context, config RequestConfig) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		attrMethod.String(config.Method),
+		attrEndpoint.String(config.Endpoint),
+	}
+	if config.ID != "" {
+		attrs = append(attrs, attrResourceID.String(config.ID))
+	}
+	if config.Action != "" {
+		attrs = append(attrs, attrAction.String(config.Action))
+	}
+	return c.tracer().Start(ctx, "PowerStore "+config.Method+" "+config.Endpoint,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// endQuerySpan records the result of the request and ends the span
+func endQuerySpan(span trace.Span, meta RespMeta, err error) {
+	defer span.End()
+	if !span.IsRecording() {
+		return
+	}
+	if meta.Status != 0 {
+		span.SetAttributes(attrStatusCode.Int(meta.Status))
+	}
+	if meta.Pagination.IsPaginate {
+		span.SetAttributes(
+			attrPaginationFirst.Int(meta.Pagination.First),
+			attrPaginationLast.Int(meta.Pagination.Last),
+			attrPaginationTotal.Int(meta.Pagination.Total),
+		)
+	}
+	if err == nil {
+		return
+	}
+	var apiErr *ErrorMsg
+	if errors.As(err, &apiErr) {
+		var errorCodes []string
+		for _, m := range apiErr.messages() {
+			if m.Code != "" {
+				errorCodes = append(errorCodes, m.Code)
+			}
+		}
+		if len(errorCodes) != 0 {
+			span.SetAttributes(attrErrorCodes.StringSlice(errorCodes))
+		}
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// startSpan opens an internal child span, e.g. for throttle wait or login
+func (c *ClientIMPL) startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return c.tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindInternal))
+}
+
+// endSpan ends the span and marks it failed if err is set
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// traceMiddleware sends W3C trace context of the current span in request headers if tracing is enabled
+func (c *ClientIMPL) traceMiddleware(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		if c.tracerProvider != nil {
+			traceContext.Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+		}
+		return next(req)
+	}
+}
@@ -0,0 +1,247 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/binary"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// recordingTracerProvider keeps ended spans, so the tests don't depend on OpenTelemetry SDK
+type recordingTracerProvider struct {
+	noop.TracerProvider
+	mu     sync.Mutex
+	spans  []*recordedSpan
+	lastID atomic.Uint64
+}
+
+func (tp *recordingTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return &recordingTracer{provider: tp}
+}
+
+// GetSpans returns ended spans
+func (tp *recordingTracerProvider) GetSpans() []*recordedSpan {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	return append([]*recordedSpan(nil), tp.spans...)
+}
+
+type recordingTracer struct {
+	noop.Tracer
+	provider *recordingTracerProvider
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+	parent := trace.SpanContextFromContext(ctx)
+	var traceID trace.TraceID
+	var spanID trace.SpanID
+	binary.BigEndian.PutUint64(spanID[:], t.provider.lastID.Add(1))
+	if parent.IsValid() {
+		traceID = parent.TraceID()
+	} else {
+		binary.BigEndian.PutUint64(traceID[8:], t.provider.lastID.Add(1))
+	}
+	span := &recordedSpan{
+		provider: t.provider,
+		Name:     name,
+		SpanKind: cfg.SpanKind(),
+		Context: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled,
+		}),
+		Parent:     parent,
+		Attributes: make(map[attribute.Key]attribute.Value),
+	}
+	span.SetAttributes(cfg.Attributes()...)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordedSpan struct {
+	noop.Span
+	provider   *recordingTracerProvider
+	Name       string
+	SpanKind   trace.SpanKind
+	Context    trace.SpanContext
+	Parent     trace.SpanContext
+	Attributes map[attribute.Key]attribute.Value
+	StatusCode codes.Code
+}
+
+func (s *recordedSpan) SpanContext() trace.SpanContext { return s.Context }
+
+func (s *recordedSpan) IsRecording() bool { return true }
+
+func (s *recordedSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, attr := range kv {
+		s.Attributes[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordedSpan) SetStatus(code codes.Code, _ string) { s.StatusCode = code }
+
+func (s *recordedSpan) End(...trace.SpanEndOption) {
+	s.provider.mu.Lock()
+	defer s.provider.mu.Unlock()
+	s.provider.spans = append(s.provider.spans, s)
+}
+
+func tracedClient(t *testing.T) (*ClientIMPL, *recordingTracerProvider) {
+	tp := &recordingTracerProvider{}
+	c := testClient(t, "https://foo")
+	c.SetTracerProvider(tp)
+	httpmock.ActivateNonDefault(c.httpClient)
+	return c, tp
+}
+
+func spanByName(spans []*recordedSpan, name string) (*recordedSpan, bool) {
+	for _, s := range spans {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+func TestClientIMPL_Query_Tracing(t *testing.T) {
+	c, exporter := tracedClient(t)
+	defer httpmock.DeactivateAndReset()
+
+	var traceparent string
+	httpmock.RegisterResponder("GET", "https://foo/volume/vol-1",
+		func(req *http.Request) (*http.Response, error) {
+			traceparent = req.Header.Get("traceparent")
+			resp := httpmock.NewStringResponse(http.StatusPartialContent, `{"name": "vol"}`)
+			resp.Header.Set("content-range", "0-99/250")
+			return resp, nil
+		})
+
+	var resp testResp
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume", ID: "vol-1"}, &resp)
+	assert.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	query, ok := spanByName(spans, "PowerStore GET volume")
+	assert.True(t, ok)
+	assert.Equal(t, trace.SpanKindClient, query.SpanKind)
+	attrs := query.Attributes
+	assert.Equal(t, "GET", attrs[attrMethod].AsString())
+	assert.Equal(t, "volume", attrs[attrEndpoint].AsString())
+	assert.Equal(t, "vol-1", attrs[attrResourceID].AsString())
+	assert.Equal(t, int64(http.StatusPartialContent), attrs[attrStatusCode].AsInt64())
+	assert.Equal(t, int64(0), attrs[attrPaginationFirst].AsInt64())
+	assert.Equal(t, int64(99), attrs[attrPaginationLast].AsInt64())
+	assert.Equal(t, int64(250), attrs[attrPaginationTotal].AsInt64())
+	assert.Equal(t, codes.Unset, query.StatusCode)
+
+	wait, ok := spanByName(spans, "PowerStore throttle wait")
+	assert.True(t, ok)
+	assert.Equal(t, query.Context.SpanID(), wait.Parent.SpanID())
+
+	// W3C trace context of the query span is sent to the array
+	assert.Contains(t, traceparent, query.Context.TraceID().String())
+	assert.Contains(t, traceparent, query.Context.SpanID().String())
+}
+
+func TestClientIMPL_Query_TracingError(t *testing.T) {
+	c, exporter := tracedClient(t)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("DELETE", "https://foo/volume/vol-1",
+		httpmock.NewStringResponder(http.StatusNotFound,
+			`{"messages": [{"code": "0xE0A08001000E", "severity": "Error", "message_l10n": "not found"}]}`))
+
+	var resp testResp
+	_, err := c.Query(context.Background(), RequestConfig{Method: "DELETE", Endpoint: "volume", ID: "vol-1"}, &resp)
+	assert.Error(t, err)
+
+	query, ok := spanByName(exporter.GetSpans(), "PowerStore DELETE volume")
+	assert.True(t, ok)
+	attrs := query.Attributes
+	assert.Equal(t, int64(http.StatusNotFound), attrs[attrStatusCode].AsInt64())
+	assert.Equal(t, []string{"0xE0A08001000E"}, attrs[attrErrorCodes].AsStringSlice())
+	assert.Equal(t, codes.Error, query.StatusCode)
+}
+
+func TestClientIMPL_Query_TracingLogin(t *testing.T) {
+	c, exporter := tracedClient(t)
+	defer httpmock.DeactivateAndReset()
+
+	loggedIn := false
+	httpmock.RegisterResponder("GET", "https://foo/"+loginSessionURL,
+		func(_ *http.Request) (*http.Response, error) {
+			loggedIn = true
+			return httpmock.NewStringResponse(http.StatusOK, `[{"id": "1"}]`), nil
+		})
+	httpmock.RegisterResponder("GET", "https://foo/volume",
+		func(_ *http.Request) (*http.Response, error) {
+			if !loggedIn {
+				return httpmock.NewStringResponse(http.StatusForbidden, ""), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, `{"name": "vol"}`), nil
+		})
+
+	var resp testResp
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume"}, &resp)
+	assert.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	query, ok := spanByName(spans, "PowerStore GET volume")
+	assert.True(t, ok)
+	login, ok := spanByName(spans, "PowerStore login")
+	assert.True(t, ok)
+	assert.Equal(t, query.Context.SpanID(), login.Parent.SpanID())
+	assert.Equal(t, int64(http.StatusOK), query.Attributes[attrStatusCode].AsInt64())
+}
+
+func TestClientIMPL_Query_NoTracerProvider(t *testing.T) {
+	// the global provider of the application isn't used
+	exporter := &recordingTracerProvider{}
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+	otel.SetTracerProvider(exporter)
+
+	c := testClient(t, "https://foo")
+	httpmock.ActivateNonDefault(c.httpClient)
+	defer httpmock.DeactivateAndReset()
+
+	var header http.Header
+	httpmock.RegisterResponder("GET", "https://foo/volume",
+		func(req *http.Request) (*http.Response, error) {
+			header = req.Header.Clone()
+			return httpmock.NewStringResponse(http.StatusOK, `{"name": "vol"}`), nil
+		})
+
+	var resp testResp
+	_, err := c.Query(context.Background(), RequestConfig{Method: "GET", Endpoint: "volume"}, &resp)
+	assert.NoError(t, err)
+	assert.Empty(t, header.Get("traceparent"))
+	assert.Empty(t, header.Get("tracestate"))
+	assert.Empty(t, exporter.GetSpans())
+}
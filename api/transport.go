@@ -0,0 +1,121 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+var errInvalidCACertificates = errors.New("no valid PEM certificates found in CA bundle")
+
+// TransportOptions holds settings of the http transport used to reach PowerStore API
+type TransportOptions struct {
+	// skip https cert check
+	Insecure bool
+	// PEM encoded CA certificates trusted in addition to the system pool
+	CACertificates []byte
+	// client certificate presented for mutual TLS
+	ClientCertificate *tls.Certificate
+	// proxy selection function, see http.Transport.Proxy
+	Proxy func(*http.Request) (*url.URL, error)
+	// custom transport, when set all other options are ignored
+	RoundTripper http.RoundTripper
+}
+
+// Config holds settings used to create ClientIMPL
+type Config struct {
+	APIURL string
+	// other management addresses of the same cluster, the client fails over to them when it can't connect
+	// to APIURL, but only after it has read identity of the cluster, see ErrClusterUnknown
+	FailoverURLs []string
+	Username     string
+	Password     string
+	// provider of credentials which is called for every login, Username and Password are ignored when it is set
+	Credentials    CredentialProvider
+	DefaultTimeout time.Duration
+	RateLimit      int
+	RequestIDKey   ContextKey
+	Transport      TransportOptions
+	// OpenTelemetry provider of spans, tracing is disabled when nil
+	TracerProvider trace.TracerProvider
+	// receiver of client metrics, metrics are disabled when nil
+	Metrics MetricsHook
+	// Configure is called with the new client before the first login, e.g. to set retry policy and middlewares
+	Configure func(*ClientIMPL)
+}
+
+// NewHTTPClient returns http client configured according to provided options
+func NewHTTPClient(opts TransportOptions) (*http.Client, error) {
+	if opts.RoundTripper != nil {
+		return &http.Client{Transport: opts.RoundTripper}, nil
+	}
+
+	tlsConfig, err := newTLSConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:           opts.Proxy,
+			TLSClientConfig: tlsConfig,
+		},
+	}, nil
+}
+
+func newTLSConfig(opts TransportOptions) (*tls.Config, error) {
+	if opts.Insecure {
+		tlsConfig := &tls.Config{
+			InsecureSkipVerify: true, // #nosec G402
+		}
+		if opts.ClientCertificate != nil {
+			tlsConfig.Certificates = []tls.Certificate{*opts.ClientCertificate}
+		}
+		return tlsConfig, nil
+	}
+
+	pool, err := systemCertPoolFunc()
+	if err != nil {
+		if len(opts.CACertificates) == 0 {
+			return nil, fmt.Errorf("%w: %s", errSysCerts, err.Error())
+		}
+		pool = x509.NewCertPool()
+	}
+	if len(opts.CACertificates) != 0 && !pool.AppendCertsFromPEM(opts.CACertificates) {
+		return nil, errInvalidCACertificates
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs:            pool,
+		InsecureSkipVerify: false,
+		CipherSuites:       GetSecuredCipherSuites(),
+		MinVersion:         tls.VersionTLS12,
+	}
+	if opts.ClientCertificate != nil {
+		tlsConfig.Certificates = []tls.Certificate{*opts.ClientCertificate}
+	}
+	return tlsConfig, nil
+}
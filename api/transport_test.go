@@ -0,0 +1,94 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTLSTestServer(t *testing.T) (*httptest.Server, []byte) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(ts.Close)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	return ts, caPEM
+}
+
+func TestNewHTTPClient_CACertificates(t *testing.T) {
+	ts, caPEM := newTLSTestServer(t)
+
+	client, err := NewHTTPClient(TransportOptions{})
+	assert.Nil(t, err)
+	_, err = client.Get(ts.URL)
+	assert.NotNil(t, err)
+
+	client, err = NewHTTPClient(TransportOptions{CACertificates: caPEM})
+	assert.Nil(t, err)
+	resp, err := client.Get(ts.URL)
+	assert.Nil(t, err)
+	assert.NoError(t, resp.Body.Close())
+
+	_, err = NewHTTPClient(TransportOptions{CACertificates: []byte("not a certificate")})
+	assert.ErrorIs(t, err, errInvalidCACertificates)
+}
+
+func TestNewHTTPClient_SystemCertPoolError(t *testing.T) {
+	defer func() { systemCertPoolFunc = x509.SystemCertPool }()
+	systemCertPoolFunc = func() (*x509.CertPool, error) {
+		return nil, errors.New("no pool")
+	}
+	ts, caPEM := newTLSTestServer(t)
+
+	_, err := NewHTTPClient(TransportOptions{})
+	assert.ErrorIs(t, err, errSysCerts)
+
+	client, err := NewHTTPClient(TransportOptions{CACertificates: caPEM})
+	assert.Nil(t, err)
+	resp, err := client.Get(ts.URL)
+	assert.Nil(t, err)
+	assert.NoError(t, resp.Body.Close())
+
+	_, err = New("https://foo", "admin", "password", false, 0, 1, key)
+	assert.ErrorIs(t, err, errSysCerts)
+}
+
+func TestNewHTTPClient_Options(t *testing.T) {
+	rt := &http.Transport{}
+	client, err := NewHTTPClient(TransportOptions{RoundTripper: rt, Insecure: true})
+	assert.Nil(t, err)
+	assert.Equal(t, rt, client.Transport)
+
+	proxyURL, _ := url.Parse("http://proxy:3128")
+	client, err = NewHTTPClient(TransportOptions{Insecure: true, Proxy: http.ProxyURL(proxyURL)})
+	assert.Nil(t, err)
+	transport := client.Transport.(*http.Transport)
+	assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+	got, err := transport.Proxy(&http.Request{})
+	assert.Nil(t, err)
+	assert.Equal(t, proxyURL, got)
+}
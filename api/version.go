@@ -0,0 +1,110 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ArrayVersion is version of PowerStore software, e.g. 3.6.0.1
+type ArrayVersion struct {
+	Major int
+	Minor int
+	Patch int
+	Build int
+}
+
+// anyVersion supports every field, it is used when select fields are not pruned
+var anyVersion = ArrayVersion{Major: math.MaxInt32}
+
+// ParseArrayVersion parses version such as "3.6", "3.6.0.1" or "4.1.0.0-2437897", text after "-" or "+" is ignored
+func ParseArrayVersion(s string) (ArrayVersion, error) {
+	trimmed := strings.TrimSpace(s)
+	if i := strings.IndexAny(trimmed, "-+"); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+	parts := strings.Split(trimmed, ".")
+	if trimmed == "" || len(parts) > 4 {
+		return ArrayVersion{}, fmt.Errorf("invalid array version %q", s)
+	}
+	var numbers [4]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return ArrayVersion{}, fmt.Errorf("invalid array version %q", s)
+		}
+		numbers[i] = n
+	}
+	return ArrayVersion{Major: numbers[0], Minor: numbers[1], Patch: numbers[2], Build: numbers[3]}, nil
+}
+
+// MustParseArrayVersion is ParseArrayVersion which panics on invalid versions, it is meant for constants
+func MustParseArrayVersion(s string) ArrayVersion {
+	v, err := ParseArrayVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ArrayVersionFromFloat converts major.minor version such as 3.6 which is returned by
+// GetSoftwareMajorMinorVersion, minor versions above 9 can't be represented this way
+func ArrayVersionFromFloat(version float32) ArrayVersion {
+	if version <= 0 {
+		return ArrayVersion{}
+	}
+	major := math.Floor(float64(version))
+	return ArrayVersion{Major: int(major), Minor: int(math.Round((float64(version) - major) * 10))}
+}
+
+// IsZero returns true for unknown version
+func (v ArrayVersion) IsZero() bool {
+	return v == ArrayVersion{}
+}
+
+// Compare returns -1, 0 or 1 if v is lower, equal or greater than other
+func (v ArrayVersion) Compare(other ArrayVersion) int {
+	for _, d := range [...]int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch, v.Build - other.Build} {
+		switch {
+		case d < 0:
+			return -1
+		case d > 0:
+			return 1
+		}
+	}
+	return 0
+}
+
+// AtLeast returns true if v is equal or greater than other
+func (v ArrayVersion) AtLeast(other ArrayVersion) bool {
+	return v.Compare(other) >= 0
+}
+
+// MajorMinor returns version as major + minor*0.1 like GetSoftwareMajorMinorVersion does
+func (v ArrayVersion) MajorMinor() float32 {
+	return float32(v.Major) + float32(v.Minor)*0.1
+}
+
+// String returns version as major.minor.patch.build
+func (v ArrayVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d.%d", v.Major, v.Minor, v.Patch, v.Build)
+}
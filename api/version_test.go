@@ -0,0 +1,85 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseArrayVersion(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ArrayVersion
+		wantErr bool
+	}{
+		{"3.6", ArrayVersion{Major: 3, Minor: 6}, false},
+		{"3.10.0.1", ArrayVersion{Major: 3, Minor: 10, Build: 1}, false},
+		{" 4.1.0.0-2437897 ", ArrayVersion{Major: 4, Minor: 1}, false},
+		{"4.0.0.0+dev", ArrayVersion{Major: 4}, false},
+		{"", ArrayVersion{}, true},
+		{"3.x", ArrayVersion{}, true},
+		{"3.6.", ArrayVersion{}, true},
+		{"-1.0", ArrayVersion{}, true},
+		{"1.2.3.4.5", ArrayVersion{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseArrayVersion(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	assert.Panics(t, func() { MustParseArrayVersion("bad") })
+}
+
+func TestArrayVersion_Compare(t *testing.T) {
+	v310 := MustParseArrayVersion("3.10")
+	assert.Equal(t, 1, v310.Compare(MustParseArrayVersion("3.9")))
+	assert.Equal(t, -1, v310.Compare(MustParseArrayVersion("4.0")))
+	assert.Equal(t, 0, v310.Compare(MustParseArrayVersion("3.10.0.0")))
+	assert.Equal(t, -1, MustParseArrayVersion("3.0.0.1").Compare(MustParseArrayVersion("3.0.1.0")))
+	assert.True(t, v310.AtLeast(MustParseArrayVersion("3.10")))
+	assert.False(t, v310.AtLeast(MustParseArrayVersion("4.0")))
+	assert.True(t, ArrayVersion{}.IsZero())
+	assert.Equal(t, "3.10.0.0", v310.String())
+}
+
+func TestArrayVersionFromFloat(t *testing.T) {
+	assert.Equal(t, ArrayVersion{Major: 3, Minor: 6}, ArrayVersionFromFloat(3.6))
+	assert.Equal(t, ArrayVersion{Major: 3, Minor: 7}, ArrayVersionFromFloat(float32(3)+float32(7)*0.1))
+	assert.Equal(t, ArrayVersion{}, ArrayVersionFromFloat(0))
+	assert.InDelta(t, 3.6, MustParseArrayVersion("3.6.0.1").MajorMinor(), 0.001)
+}
+
+func TestClientIMPL_SetVersion(t *testing.T) {
+	c := &ClientIMPL{}
+	assert.True(t, c.Version().IsZero())
+	assert.Equal(t, float32(0), c.ArrayVersion())
+	c.SetVersion(MustParseArrayVersion("3.10.1"))
+	assert.Equal(t, ArrayVersion{Major: 3, Minor: 10, Patch: 1}, c.Version())
+	c.SetArrayVersion(3.6)
+	assert.Equal(t, ArrayVersion{Major: 3, Minor: 6}, c.Version())
+	assert.InDelta(t, 3.6, c.ArrayVersion(), 0.001)
+}
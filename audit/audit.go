@@ -0,0 +1,194 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package audit writes tamper-evident trail of mutating PowerStore API calls. Records are
+// written as JSON lines where every line carries hash of the previous one, so Verify
+// detects removed, reordered or edited lines.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/dell/gopowerstore/api"
+)
+
+// Entry is a line of the audit file
+type Entry struct {
+	// sequence number of the record starting with 1
+	Seq uint64 `json:"seq"`
+	// hash of the previous entry, empty for the first one
+	PrevHash string `json:"prev_hash"`
+	// hash of Seq, PrevHash and Record
+	Hash string `json:"hash"`
+	// api.AuditRecord as it was written, hashed byte for byte
+	Record json.RawMessage `json:"record"`
+}
+
+// entryHash chains the record to the previous entry
+func entryHash(seq uint64, prevHash string, record []byte) string {
+	h := sha256.New()
+	h.Write([]byte(strconv.FormatUint(seq, 10) + "\n" + prevHash + "\n"))
+	h.Write(record)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+var _ api.AuditSink = &FileSink{}
+
+// FileSink is api.AuditSink which appends hash-chained records to a JSON lines file,
+// pass it to ClientOptions.SetAuditSink. It is safe for concurrent use
+type FileSink struct {
+	mu       sync.Mutex
+	file     *os.File
+	seq      uint64
+	lastHash string
+}
+
+// NewFileSink opens or creates the audit file and continues its chain. The existing file is verified
+// first, appending to a broken chain would hide the tampering
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	res, err := Verify(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("can't continue audit file %s: %w", path, err)
+	}
+	return &FileSink{file: file, seq: res.LastSeq, lastHash: res.LastHash}, nil
+}
+
+// WriteAuditRecord appends the record to the file and syncs it to the disk
+func (s *FileSink) WriteAuditRecord(_ context.Context, record api.AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return os.ErrClosed
+	}
+	entry := Entry{Seq: s.seq + 1, PrevHash: s.lastHash, Record: data}
+	entry.Hash = entryHash(entry.Seq, entry.PrevHash, entry.Record)
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err = s.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if err = s.file.Sync(); err != nil {
+		return err
+	}
+	s.seq, s.lastHash = entry.Seq, entry.Hash
+	return nil
+}
+
+// Close closes the audit file
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// ErrChainBroken is matched by errors.Is for every ChainError
+var ErrChainBroken = errors.New("audit chain is broken")
+
+// ChainError describes the first line of the audit file which breaks the chain
+type ChainError struct {
+	// line number starting with 1
+	Line   int
+	Reason string
+}
+
+func (err *ChainError) Error() string {
+	return fmt.Sprintf("audit chain is broken at line %d: %s", err.Line, err.Reason)
+}
+
+// Is makes errors.Is(err, ErrChainBroken) true
+func (err *ChainError) Is(target error) bool {
+	return target == ErrChainBroken
+}
+
+// VerifyResult describes a valid audit chain. Removal of the last lines can't be detected
+// from the file alone, keep LastSeq and LastHash elsewhere to compare them later
+type VerifyResult struct {
+	Records  int
+	LastSeq  uint64
+	LastHash string
+}
+
+// Verify reads the audit chain and returns ChainError for the first line which was
+// edited, removed, reordered or which is not a complete entry
+func Verify(r io.Reader) (VerifyResult, error) {
+	var res VerifyResult
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if len(data) == 0 && errors.Is(err, io.EOF) {
+			return res, nil
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return res, err
+		}
+		if !bytes.HasSuffix(data, []byte("\n")) {
+			return res, &ChainError{Line: line, Reason: "incomplete entry"}
+		}
+		var entry Entry
+		if jerr := json.Unmarshal(data, &entry); jerr != nil {
+			return res, &ChainError{Line: line, Reason: "malformed entry: " + jerr.Error()}
+		}
+		switch {
+		case entry.Seq != res.LastSeq+1:
+			return res, &ChainError{Line: line, Reason: fmt.Sprintf("sequence number %d follows %d", entry.Seq, res.LastSeq)}
+		case entry.PrevHash != res.LastHash:
+			return res, &ChainError{Line: line, Reason: "previous hash doesn't match"}
+		case entry.Hash != entryHash(entry.Seq, entry.PrevHash, entry.Record):
+			return res, &ChainError{Line: line, Reason: "hash doesn't match the record"}
+		}
+		res.Records++
+		res.LastSeq, res.LastHash = entry.Seq, entry.Hash
+	}
+}
+
+// VerifyFile verifies the audit chain stored in the file
+func VerifyFile(path string) (VerifyResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return VerifyResult{}, err
+	}
+	defer file.Close()
+	return Verify(file)
+}
@@ -0,0 +1,120 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dell/gopowerstore/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRecord(id string) api.AuditRecord {
+	return api.AuditRecord{
+		Time:     time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC),
+		TraceID:  "trace",
+		ArrayID:  "PS1",
+		Method:   "POST",
+		Endpoint: "volume",
+		Body:     []byte(`{"name":"<vol>"}`),
+		Status:   201,
+		ResultID: id,
+	}
+}
+
+// writeAuditFile writes records to a new audit file and returns its lines
+func writeAuditFile(t *testing.T, path string, ids ...string) []string {
+	sink, err := NewFileSink(path)
+	require.NoError(t, err)
+	for _, id := range ids {
+		require.NoError(t, sink.WriteAuditRecord(context.Background(), testRecord(id)))
+	}
+	require.NoError(t, sink.Close())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.SplitAfter(string(data), "\n")
+	return lines[:len(lines)-1]
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	lines := writeAuditFile(t, path, "1", "2")
+	assert.Len(t, lines, 2)
+
+	// the chain continues after reopening
+	writeAuditFile(t, path, "3")
+	res, err := VerifyFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.Records)
+	assert.Equal(t, uint64(3), res.LastSeq)
+	assert.Len(t, res.LastHash, 64)
+
+	sink, err := NewFileSink(path)
+	require.NoError(t, err)
+	require.NoError(t, sink.Close())
+	assert.ErrorIs(t, sink.WriteAuditRecord(context.Background(), testRecord("4")), os.ErrClosed)
+	assert.NoError(t, sink.Close())
+}
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+	lines := writeAuditFile(t, filepath.Join(dir, "audit.jsonl"), "1", "2", "3")
+
+	tests := []struct {
+		name   string
+		lines  []string
+		line   int
+		reason string
+	}{
+		{"edited", []string{lines[0], strings.Replace(lines[1], `"result_id":"2"`, `"result_id":"9"`, 1), lines[2]}, 2, "hash doesn't match"},
+		{"removed", []string{lines[0], lines[2]}, 2, "sequence number 3 follows 1"},
+		{"first removed", []string{lines[1], lines[2]}, 1, "sequence number 2 follows 0"},
+		{"reordered", []string{lines[0], lines[2], lines[1]}, 2, "sequence number 3 follows 1"},
+		{"incomplete", []string{lines[0], strings.TrimSuffix(lines[1], "\n")}, 2, "incomplete entry"},
+		{"malformed", []string{lines[0], "not json\n"}, 2, "malformed entry"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name+".jsonl")
+			require.NoError(t, os.WriteFile(path, []byte(strings.Join(tt.lines, "")), 0o600))
+			_, err := VerifyFile(path)
+			assert.ErrorIs(t, err, ErrChainBroken)
+			var chainErr *ChainError
+			require.True(t, errors.As(err, &chainErr))
+			assert.Equal(t, tt.line, chainErr.Line)
+			assert.Contains(t, chainErr.Reason, tt.reason)
+
+			_, err = NewFileSink(path)
+			assert.ErrorIs(t, err, ErrChainBroken)
+		})
+	}
+
+	res, err := Verify(strings.NewReader(""))
+	assert.NoError(t, err)
+	assert.Equal(t, VerifyResult{}, res)
+	_, err = VerifyFile(filepath.Join(dir, "missing.jsonl"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
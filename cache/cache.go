@@ -0,0 +1,356 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package cache provides read-through cache of PowerStore API responses.
+//
+// Cache is an api.Middleware, pass Cache.Middleware to ClientOptions.SetMiddlewares. Successful GET responses
+// of endpoints which have a TTL are kept and served without reaching the array. POST, PATCH and DELETE
+// requests invalidate cached responses of the endpoint they are sent to.
+package cache
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dell/gopowerstore/api"
+)
+
+// Config defaults
+const (
+	defaultRevalidateTimeout = 30 * time.Second
+)
+
+// Config describes which responses are cached and for how long
+type Config struct {
+	// TTL of endpoints, e.g. "cluster", responses of endpoints without TTL are not cached
+	TTLs map[string]time.Duration
+	// TTL of endpoints which are not listed in TTLs, zero disables caching of them
+	DefaultTTL time.Duration
+	// time after expiration during which the stale response is served while it is refreshed in background,
+	// zero disables stale responses
+	StaleWhileRevalidate time.Duration
+	// timeout of background refresh, 30 seconds when zero
+	RevalidateTimeout time.Duration
+	// max number of cached responses, the oldest ones are evicted first, zero means no limit
+	MaxEntries int
+	// endpoints whose responses are also invalidated by writes to the key endpoint,
+	// e.g. "host": {"host_volume_mapping"}
+	Related map[string][]string
+}
+
+// DefaultConfig caches endpoints which rarely change: cluster, installed software, limits,
+// FC ports and IP pool addresses
+func DefaultConfig() Config {
+	return Config{
+		TTLs: map[string]time.Duration{
+			"cluster":            10 * time.Minute,
+			"software_installed": 10 * time.Minute,
+			"limit":              time.Hour,
+			"fc_port":            5 * time.Minute,
+			"ip_pool_address":    5 * time.Minute,
+		},
+		StaleWhileRevalidate: time.Minute,
+	}
+}
+
+// Stats are cache counters since the cache was created
+type Stats struct {
+	// responses served from cache while they were fresh
+	Hits int64
+	// stale responses served while they were refreshed
+	StaleHits int64
+	// requests of cached endpoints which were sent to the array
+	Misses int64
+	// completed and failed background refreshes of stale responses
+	Revalidations      int64
+	RevalidationErrors int64
+	// responses dropped because of writes to their endpoints
+	Invalidations int64
+	// responses currently kept
+	Entries int
+}
+
+type entry struct {
+	family     string
+	status     int
+	header     http.Header
+	body       []byte
+	storedAt   time.Time
+	expiresAt  time.Time
+	staleUntil time.Time
+	refreshing bool
+}
+
+// Cache keeps API responses in memory, it may be shared by clients of different arrays
+type Cache struct {
+	cfg Config
+
+	mu          sync.Mutex
+	entries     map[string]*entry
+	generations map[string]uint64
+
+	hits, staleHits, misses, revalidations, revalidationErrors, invalidations atomic.Int64
+}
+
+// New returns empty cache
+func New(cfg Config) *Cache {
+	if cfg.RevalidateTimeout <= 0 {
+		cfg.RevalidateTimeout = defaultRevalidateTimeout
+	}
+	return &Cache{cfg: cfg, entries: map[string]*entry{}, generations: map[string]uint64{}}
+}
+
+// Stats returns cache counters
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	entries := len(c.entries)
+	c.mu.Unlock()
+	return Stats{
+		Hits:               c.hits.Load(),
+		StaleHits:          c.staleHits.Load(),
+		Misses:             c.misses.Load(),
+		Revalidations:      c.revalidations.Load(),
+		RevalidationErrors: c.revalidationErrors.Load(),
+		Invalidations:      c.invalidations.Load(),
+		Entries:            entries,
+	}
+}
+
+// Invalidate drops cached responses of the endpoint, e.g. "volume"
+func (c *Cache) Invalidate(endpoint string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.invalidate(family(endpoint))
+}
+
+// Purge drops all cached responses
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, e := range c.entries {
+		c.generations[e.family]++
+		delete(c.entries, key)
+	}
+}
+
+// invalidate drops responses of the family and related ones, c.mu must be held
+func (c *Cache) invalidate(f string) {
+	for _, name := range append([]string{f}, c.cfg.Related[f]...) {
+		c.generations[name]++
+		for key, e := range c.entries {
+			if e.family == name {
+				delete(c.entries, key)
+				c.invalidations.Add(1)
+			}
+		}
+	}
+}
+
+// family returns the first segment of endpoint, e.g. "volume" for "volume/{id}/snapshot"
+func family(endpoint string) string {
+	f, _, _ := strings.Cut(strings.Trim(endpoint, "/"), "/")
+	return f
+}
+
+func (c *Cache) ttl(f string) time.Duration {
+	if ttl, ok := c.cfg.TTLs[f]; ok {
+		return ttl
+	}
+	return c.cfg.DefaultTTL
+}
+
+// requestFamily returns endpoint family of the request, the path below api/rest is used for requests
+// which are not sent by Query
+func requestFamily(req *http.Request) string {
+	if cfg, ok := api.RequestConfigFromContext(req.Context()); ok {
+		return family(cfg.Endpoint)
+	}
+	if _, endpoint, ok := strings.Cut(req.URL.Path, "/api/rest/"); ok {
+		return family(endpoint)
+	}
+	return family(req.URL.Path)
+}
+
+// cacheKey returns key of the cached response. Per-request headers of RequestConfig may change
+// the response, e.g. Accept-Language, so they are part of the key. Session headers are not.
+func cacheKey(req *http.Request) string {
+	key := req.URL.String()
+	cfg, ok := api.RequestConfigFromContext(req.Context())
+	if !ok || len(cfg.Headers) == 0 {
+		return key
+	}
+	headers := make([]string, 0, len(cfg.Headers))
+	for name, values := range cfg.Headers {
+		headers = append(headers, http.CanonicalHeaderKey(name)+": "+strings.Join(values, ","))
+	}
+	sort.Strings(headers)
+	return key + "\x00" + strings.Join(headers, "\x00")
+}
+
+// Middleware returns api.Middleware which serves cached responses and invalidates them on writes
+func (c *Cache) Middleware() api.Middleware {
+	return func(next api.Handler) api.Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			f := requestFamily(req)
+			switch req.Method {
+			case http.MethodGet:
+			case http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete:
+				r, err := next(req)
+				c.mu.Lock()
+				c.invalidate(f)
+				c.mu.Unlock()
+				return r, err
+			default:
+				return next(req)
+			}
+			ttl := c.ttl(f)
+			if ttl <= 0 {
+				return next(req)
+			}
+
+			key := cacheKey(req)
+			now := time.Now()
+			c.mu.Lock()
+			e, ok := c.entries[key]
+			switch {
+			case ok && now.Before(e.expiresAt):
+				c.mu.Unlock()
+				c.hits.Add(1)
+				return e.response(req), nil
+			case ok && now.Before(e.staleUntil):
+				refresh := !e.refreshing
+				e.refreshing = true
+				generation := c.generations[f]
+				c.mu.Unlock()
+				c.staleHits.Add(1)
+				if refresh {
+					go c.revalidate(next, req, key, f, ttl, generation)
+				}
+				return e.response(req), nil
+			case ok:
+				delete(c.entries, key)
+			}
+			generation := c.generations[f]
+			c.mu.Unlock()
+
+			c.misses.Add(1)
+			r, err := next(req)
+			if err != nil || !cacheable(r) {
+				return r, err
+			}
+			body, err := readBody(r)
+			if err != nil {
+				return nil, err
+			}
+			c.store(key, f, ttl, generation, r, body)
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			return r, nil
+		}
+	}
+}
+
+// revalidate refreshes the stale response in background
+func (c *Cache) revalidate(next api.Handler, req *http.Request, key, f string, ttl time.Duration, generation uint64) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(req.Context()), c.cfg.RevalidateTimeout)
+	defer cancel()
+	r, err := next(req.Clone(ctx))
+	var body []byte
+	if err == nil {
+		body, err = readBody(r)
+	}
+	if err != nil || !cacheable(r) {
+		c.revalidationErrors.Add(1)
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if e, ok := c.entries[key]; ok {
+			e.refreshing = false
+		}
+		return
+	}
+	c.store(key, f, ttl, generation, r, body)
+	c.revalidations.Add(1)
+}
+
+// store caches the response unless the endpoint was invalidated after the request had been sent
+func (c *Cache) store(key, f string, ttl time.Duration, generation uint64, r *http.Response, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generations[f] != generation {
+		return
+	}
+	now := time.Now()
+	c.entries[key] = &entry{
+		family:     f,
+		status:     r.StatusCode,
+		header:     r.Header.Clone(),
+		body:       body,
+		storedAt:   now,
+		expiresAt:  now.Add(ttl),
+		staleUntil: now.Add(ttl + c.cfg.StaleWhileRevalidate),
+	}
+	if c.cfg.MaxEntries > 0 && len(c.entries) > c.cfg.MaxEntries {
+		c.evictOldest()
+	}
+}
+
+// evictOldest drops the response which was stored first, c.mu must be held
+func (c *Cache) evictOldest() {
+	var oldestKey string
+	var oldest *entry
+	for key, e := range c.entries {
+		if oldest == nil || e.storedAt.Before(oldest.storedAt) {
+			oldestKey, oldest = key, e
+		}
+	}
+	delete(c.entries, oldestKey)
+}
+
+func cacheable(r *http.Response) bool {
+	return r.StatusCode == http.StatusOK || r.StatusCode == http.StatusPartialContent
+}
+
+func readBody(r *http.Response) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	defer r.Body.Close()
+	return io.ReadAll(r.Body)
+}
+
+// response returns a copy of the cached response
+func (e *entry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.status, http.StatusText(e.status)),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
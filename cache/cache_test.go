@@ -0,0 +1,226 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cache_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dell/gopowerstore"
+	"github.com/dell/gopowerstore/cache"
+	"github.com/dell/gopowerstore/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, c *cache.Cache) (*simulator.Server, gopowerstore.Client) {
+	sim := simulator.New(simulator.Options{})
+	t.Cleanup(sim.Close)
+	client, err := gopowerstore.NewClientWithArgs(sim.URL(), sim.Username(), sim.Password(),
+		gopowerstore.NewClientOptions().SetDefaultTimeout(10*time.Second).SetMiddlewares(c.Middleware()))
+	require.NoError(t, err)
+	return sim, client
+}
+
+// countRequests returns number of requests the simulator received for the endpoint
+func countRequests(sim *simulator.Server, method, endpoint string) int {
+	n := 0
+	for _, r := range sim.Requests() {
+		if r.Method == method && r.Endpoint == endpoint {
+			n++
+		}
+	}
+	return n
+}
+
+func TestCache_Hits(t *testing.T) {
+	ctx := context.Background()
+	c := cache.New(cache.DefaultConfig())
+	sim, client := newTestClient(t, c)
+
+	for i := 0; i < 3; i++ {
+		cluster, err := client.GetCluster(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, sim.GlobalID(), cluster.GlobalID)
+	}
+	assert.Equal(t, 1, countRequests(sim, "GET", "cluster"))
+	assert.Equal(t, 1, countRequests(sim, "GET", "software_installed"))
+	stats := c.Stats()
+	assert.Equal(t, int64(4), stats.Hits)
+	assert.Equal(t, int64(2), stats.Misses)
+	assert.Equal(t, 2, stats.Entries)
+
+	// endpoints without TTL are not cached
+	_, err := client.GetVolumes(ctx)
+	require.NoError(t, err)
+	_, err = client.GetVolumes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, countRequests(sim, "GET", "volume"))
+	assert.Equal(t, int64(2), c.Stats().Misses)
+
+	c.Purge()
+	_, err = client.GetCluster(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, countRequests(sim, "GET", "cluster"))
+}
+
+func TestCache_Invalidation(t *testing.T) {
+	ctx := context.Background()
+	c := cache.New(cache.Config{TTLs: map[string]time.Duration{"volume": time.Minute}})
+	sim, client := newTestClient(t, c)
+
+	name := "vol-1"
+	size := int64(1024 * 1024 * 1024)
+	resp, err := client.CreateVolume(ctx, &gopowerstore.VolumeCreate{Name: &name, Size: &size})
+	require.NoError(t, err)
+	vol, err := client.GetVolume(ctx, resp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "vol-1", vol.Name)
+	_, err = client.GetVolume(ctx, resp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), c.Stats().Hits)
+
+	_, err = client.ModifyVolume(ctx, &gopowerstore.VolumeModify{Name: "vol-2"}, resp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), c.Stats().Invalidations)
+	vol, err = client.GetVolume(ctx, resp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "vol-2", vol.Name)
+	assert.Equal(t, 2, countRequests(sim, "GET", "volume/"+resp.ID))
+
+	c.Invalidate("volume")
+	_, err = client.GetVolume(ctx, resp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, countRequests(sim, "GET", "volume/"+resp.ID))
+}
+
+func TestCache_StaleWhileRevalidate(t *testing.T) {
+	ctx := context.Background()
+	c := cache.New(cache.Config{
+		TTLs:                 map[string]time.Duration{"cluster": 100 * time.Millisecond, "software_installed": time.Hour},
+		StaleWhileRevalidate: time.Minute,
+	})
+	sim, client := newTestClient(t, c)
+
+	_, err := client.GetCluster(ctx)
+	require.NoError(t, err)
+	time.Sleep(110 * time.Millisecond)
+
+	// the stale response is served at once while the fresh one is requested
+	sim.SetLatency(50 * time.Millisecond)
+	start := time.Now()
+	_, err = client.GetCluster(ctx)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+	assert.Equal(t, int64(1), c.Stats().StaleHits)
+
+	assert.Eventually(t, func() bool {
+		return countRequests(sim, "GET", "cluster") == 2 && c.Stats().Revalidations == 1
+	}, time.Second, 5*time.Millisecond)
+	sim.SetLatency(0)
+
+	// the refreshed response is fresh again
+	_, err = client.GetCluster(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, countRequests(sim, "GET", "cluster"))
+	assert.Equal(t, int64(1), c.Stats().StaleHits)
+	assert.Zero(t, c.Stats().RevalidationErrors)
+}
+
+func TestCache_RequestHeaders(t *testing.T) {
+	ctx := context.Background()
+	c := cache.New(cache.Config{TTLs: map[string]time.Duration{"volume": time.Minute}})
+	sim, client := newTestClient(t, c)
+
+	get := func(headers http.Header) {
+		var resp []gopowerstore.Volume
+		_, err := client.APIClient().Query(ctx, gopowerstore.RequestConfig{
+			Method: "GET", Endpoint: "volume", Headers: headers,
+		}, &resp)
+		require.NoError(t, err)
+	}
+	get(nil)
+	get(http.Header{"Accept-Language": {"de-DE"}})
+	get(http.Header{"accept-language": {"de-DE"}})
+	get(http.Header{"Accept-Language": {"fr-FR"}})
+	get(nil)
+	assert.Equal(t, 3, countRequests(sim, "GET", "volume"))
+}
+
+func TestCache_Middleware(t *testing.T) {
+	c := cache.New(cache.Config{DefaultTTL: time.Minute, MaxEntries: 2})
+	release := make(chan struct{})
+	var calls atomic.Int32
+	handler := c.Middleware()(func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		if req.URL.Query().Get("wait") != "" {
+			<-release
+		}
+		status := http.StatusOK
+		if strings.HasSuffix(req.URL.Path, "/missing") {
+			status = http.StatusNotFound
+		}
+		return &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+	})
+	get := func(url string) *http.Response {
+		req, err := http.NewRequestWithContext(context.Background(), "GET", url, nil)
+		require.NoError(t, err)
+		r, err := handler(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "{}", string(body))
+		return r
+	}
+
+	// errors are not cached
+	get("https://a/api/rest/volume/missing")
+	get("https://a/api/rest/volume/missing")
+	assert.Equal(t, int32(2), calls.Load())
+
+	// responses requested before a write are not cached after it
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		get("https://a/api/rest/volume?wait=1")
+	}()
+	assert.Eventually(t, func() bool { return c.Stats().Misses == 3 }, time.Second, time.Millisecond)
+	req, err := http.NewRequest("DELETE", "https://a/api/rest/volume/1", nil)
+	require.NoError(t, err)
+	_, err = handler(req)
+	require.NoError(t, err)
+	close(release)
+	<-done
+	assert.Zero(t, c.Stats().Entries)
+
+	// the oldest response is evicted
+	get("https://a/api/rest/host/1")
+	get("https://a/api/rest/host/2")
+	get("https://a/api/rest/host/3")
+	assert.Equal(t, 2, c.Stats().Entries)
+	calls.Store(0)
+	get("https://a/api/rest/host/3")
+	get("https://a/api/rest/host/1")
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,121 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package gopowerstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dell/gopowerstore/api"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Capability is a PowerStore feature which requires a minimal array version
+type Capability string
+
+// Capabilities which are checked before calling the API methods which use them
+const (
+	CapabilityNVMeTCP          Capability = "NVMe/TCP"
+	CapabilityMetroVolume      Capability = "metro volume"
+	CapabilityMetroVolumeGroup Capability = "metro volume group"
+	CapabilityFileReplication  Capability = "file replication"
+	CapabilitySMBShareACL      Capability = "SMB share ACL"
+)
+
+// ErrUnsupportedByArrayVersion is matched by errors.Is for every UnsupportedByArrayVersionError
+var ErrUnsupportedByArrayVersion = errors.New("operation is not supported by the array version")
+
+// UnsupportedByArrayVersionError is returned when the array is older than the capability requires
+type UnsupportedByArrayVersionError struct {
+	Capability Capability
+	Required   api.ArrayVersion
+	Actual     api.ArrayVersion
+}
+
+func (err *UnsupportedByArrayVersionError) Error() string {
+	return fmt.Sprintf("%s requires PowerStore %s or newer, the array runs %s",
+		err.Capability, err.Required, err.Actual)
+}
+
+// Is makes errors.Is(err, ErrUnsupportedByArrayVersion) true
+func (err *UnsupportedByArrayVersionError) Is(target error) bool {
+	return target == ErrUnsupportedByArrayVersion
+}
+
+var (
+	capabilitiesMutex sync.RWMutex
+	capabilities      = map[Capability]api.ArrayVersion{
+		CapabilityNVMeTCP:          api.MustParseArrayVersion("2.1"),
+		CapabilityMetroVolume:      api.MustParseArrayVersion("3.0"),
+		CapabilityMetroVolumeGroup: api.MustParseArrayVersion("4.0"),
+		CapabilityFileReplication:  api.MustParseArrayVersion("3.0"),
+		CapabilitySMBShareACL:      api.MustParseArrayVersion("3.0"),
+	}
+)
+
+// RegisterCapability sets the minimal array version of a capability, it may be used to add
+// capabilities or to override the version of the known ones
+func RegisterCapability(capability Capability, minVersion api.ArrayVersion) {
+	capabilitiesMutex.Lock()
+	defer capabilitiesMutex.Unlock()
+	capabilities[capability] = minVersion
+}
+
+// CapabilityMinVersion returns the minimal array version of a capability, false if the capability is unknown
+func CapabilityMinVersion(capability Capability) (api.ArrayVersion, bool) {
+	capabilitiesMutex.RLock()
+	defer capabilitiesMutex.RUnlock()
+	v, ok := capabilities[capability]
+	return v, ok
+}
+
+// SupportsCapability returns true if the array version supports the capability. Unknown capabilities
+// and unknown (zero) versions are considered supported and left for the array to decide
+func SupportsCapability(version api.ArrayVersion, capability Capability) bool {
+	minVersion, ok := CapabilityMinVersion(capability)
+	return !ok || version.IsZero() || version.AtLeast(minVersion)
+}
+
+// RequireCapability returns UnsupportedByArrayVersionError if the array is older than the capability requires.
+// The array version remembered by the api client is used, otherwise it is queried. If the version can't be
+// found the check passes and the array decides
+func (c *ClientIMPL) RequireCapability(ctx context.Context, capability Capability) error {
+	minVersion, ok := CapabilityMinVersion(capability)
+	if !ok {
+		return nil
+	}
+	var version api.ArrayVersion
+	if vg, ok := c.API.(arrayVersionGetter); ok {
+		version = vg.Version()
+	}
+	if version.IsZero() {
+		var err error
+		if version, err = c.GetArrayVersion(ctx); err != nil {
+			log.Debugf("couldn't check whether the array supports %s: %s", capability, err.Error())
+			return nil
+		}
+	}
+	if version.IsZero() || version.AtLeast(minVersion) {
+		return nil
+	}
+	return &UnsupportedByArrayVersionError{Capability: capability, Required: minVersion, Actual: version}
+}
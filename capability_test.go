@@ -0,0 +1,93 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package gopowerstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/dell/gopowerstore/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupportsCapability(t *testing.T) {
+	assert.True(t, SupportsCapability(api.MustParseArrayVersion("4.0"), CapabilityMetroVolumeGroup))
+	assert.False(t, SupportsCapability(api.MustParseArrayVersion("3.10"), CapabilityMetroVolumeGroup))
+	assert.True(t, SupportsCapability(api.ArrayVersion{}, CapabilityMetroVolumeGroup))
+	assert.True(t, SupportsCapability(api.MustParseArrayVersion("1.0"), Capability("unknown")))
+
+	const custom Capability = "custom"
+	RegisterCapability(custom, api.MustParseArrayVersion("3.10"))
+	defer func() {
+		capabilitiesMutex.Lock()
+		delete(capabilities, custom)
+		capabilitiesMutex.Unlock()
+	}()
+	v, ok := CapabilityMinVersion(custom)
+	assert.True(t, ok)
+	assert.Equal(t, api.MustParseArrayVersion("3.10"), v)
+	assert.False(t, SupportsCapability(api.MustParseArrayVersion("3.9"), custom))
+}
+
+func TestClientIMPL_RequireCapability(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	defer C.APIClient().(arrayVersionSetter).SetVersion(api.ArrayVersion{})
+
+	configureURL := fmt.Sprintf("%s/%s/%s", volumeMockURL, volID, VolumeActionConfigureMetro)
+	httpmock.RegisterResponder("POST", configureURL,
+		httpmock.NewStringResponder(http.StatusOK, `{"metro_replication_session_id": "id"}`))
+	httpmock.RegisterResponder("GET", apiSoftwareInstalledMockURL,
+		httpmock.NewStringResponder(200, `[{"id": "1", "is_cluster": true, "build_version": "2.1.0.0"}]`))
+
+	_, err := C.ConfigureMetroVolume(context.Background(), volID, &metroConfig)
+	assert.ErrorIs(t, err, ErrUnsupportedByArrayVersion)
+	var unsupported *UnsupportedByArrayVersionError
+	assert.True(t, errors.As(err, &unsupported))
+	assert.Equal(t, CapabilityMetroVolume, unsupported.Capability)
+	assert.Equal(t, api.MustParseArrayVersion("2.1"), unsupported.Actual)
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()["POST "+configureURL])
+
+	// the version is remembered by the api client
+	assert.NoError(t, C.RequireCapability(context.Background(), CapabilityNVMeTCP))
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["GET "+apiSoftwareInstalledMockURL])
+
+	C.APIClient().(arrayVersionSetter).SetVersion(api.MustParseArrayVersion("3.0.0.0"))
+	resp, err := C.ConfigureMetroVolume(context.Background(), volID, &metroConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "id", resp.ID)
+}
+
+func TestClientIMPL_RequireCapability_UnknownVersion(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	defer C.APIClient().(arrayVersionSetter).SetVersion(api.ArrayVersion{})
+
+	httpmock.RegisterResponder("GET", apiSoftwareInstalledMockURL,
+		httpmock.NewStringResponder(http.StatusInternalServerError, ""))
+	assert.NoError(t, C.RequireCapability(context.Background(), CapabilityMetroVolumeGroup))
+
+	httpmock.RegisterResponder("GET", apiSoftwareInstalledMockURL,
+		httpmock.NewStringResponder(200, `[{"id": "1", "is_cluster": false, "build_version": "2.0.0.0"}]`))
+	assert.NoError(t, C.RequireCapability(context.Background(), CapabilityMetroVolumeGroup))
+}
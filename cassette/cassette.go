@@ -0,0 +1,317 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package cassette records PowerStore API traffic to files and replays it offline.
+//
+// Recorder is an http.RoundTripper, pass it to ClientOptions.SetTransport. In ModeRecord requests are
+// sent to the array and scrubbed request/response pairs are saved after every response. In ModeReplay
+// responses are read from the file and no request leaves the process.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode defines whether Recorder talks to the array or replays recorded traffic
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette file
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the array and saves interactions to the cassette file
+	ModeRecord
+)
+
+// Match defines how replayed requests are matched with recorded ones
+type Match int
+
+const (
+	// MatchStrict requires requests to come in the recorded order with the same method, url and body
+	MatchStrict Match = iota
+	// MatchLenient serves the first unused interaction with the same method, path and query in any order,
+	// request bodies are ignored and the last interaction is reused when all matching ones are used
+	MatchLenient
+)
+
+// ParseMode parses "record" or "replay"
+func ParseMode(s string) (Mode, error) {
+	switch strings.ToLower(s) {
+	case "record":
+		return ModeRecord, nil
+	case "replay", "":
+		return ModeReplay, nil
+	}
+	return ModeReplay, fmt.Errorf("cassette: unknown mode %q", s)
+}
+
+// ParseMatch parses "strict" or "lenient"
+func ParseMatch(s string) (Match, error) {
+	switch strings.ToLower(s) {
+	case "strict":
+		return MatchStrict, nil
+	case "lenient", "":
+		return MatchLenient, nil
+	}
+	return MatchStrict, fmt.Errorf("cassette: unknown match %q", s)
+}
+
+// ErrNoInteraction is returned in replay mode when no recorded interaction matches the request
+var ErrNoInteraction = errors.New("cassette: no recorded interaction matches request")
+
+// Request is a recorded request, URL doesn't contain scheme and host so cassettes don't depend on the array address
+type Request struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Interaction is a recorded request/response pair
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette is the content of a cassette file
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Options configure Recorder
+type Options struct {
+	Mode  Mode
+	Match Match
+	// Transport sends requests in ModeRecord, http.DefaultTransport is used when nil
+	Transport http.RoundTripper
+	// Scrubber removes secrets from interactions before they are saved, DefaultScrubber is used when nil
+	Scrubber Scrubber
+}
+
+// Recorder is http.RoundTripper which records or replays PowerStore API traffic
+type Recorder struct {
+	path     string
+	opts     Options
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+	next     int
+}
+
+// New returns Recorder for cassette file path, the file must exist in ModeReplay
+func New(path string, opts Options) (*Recorder, error) {
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport
+	}
+	if opts.Scrubber == nil {
+		opts.Scrubber = DefaultScrubber
+	}
+	r := &Recorder{path: path, opts: opts}
+	if opts.Mode == ModeRecord {
+		return r, nil
+	}
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("cassette: invalid file %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Interactions returns recorded interactions
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.opts.Mode == ModeRecord {
+		return r.record(req, body)
+	}
+	return r.replay(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.opts.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close() // #nosec G104
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := r.opts.Scrubber(Interaction{
+		Request: Request{
+			Method:  req.Method,
+			URL:     req.URL.RequestURI(),
+			Headers: req.Header.Clone(),
+			Body:    string(body),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header.Clone(),
+			Body:       string(respBody),
+		},
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	return resp, r.save()
+}
+
+// save writes the cassette file, it is called after every recorded response so a failed test run keeps its traffic
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, data, 0o600)
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i, err := r.find(req, body)
+	if err != nil {
+		return nil, err
+	}
+	r.used[i] = true
+	recorded := r.cassette.Interactions[i].Response
+	header := recorded.Headers.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// find returns index of the interaction which matches request
+func (r *Recorder) find(req *http.Request, body []byte) (int, error) {
+	uri := req.URL.RequestURI()
+	if r.opts.Match == MatchStrict {
+		if r.next >= len(r.cassette.Interactions) {
+			return 0, fmt.Errorf("%w: %s %s, all %d interactions are used",
+				ErrNoInteraction, req.Method, uri, len(r.cassette.Interactions))
+		}
+		recorded := r.cassette.Interactions[r.next].Request
+		if recorded.Method != req.Method || recorded.URL != uri || !sameBody(recorded.Body, r.scrubBody(body)) {
+			return 0, fmt.Errorf("%w: %s %s, expected %s %s", ErrNoInteraction, req.Method, uri,
+				recorded.Method, recorded.URL)
+		}
+		r.next++
+		return r.next - 1, nil
+	}
+
+	last := -1
+	for i, interaction := range r.cassette.Interactions {
+		if interaction.Request.Method != req.Method || !sameURL(interaction.Request.URL, uri) {
+			continue
+		}
+		if !r.used[i] {
+			return i, nil
+		}
+		last = i
+	}
+	if last < 0 {
+		return 0, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, uri)
+	}
+	return last, nil
+}
+
+// scrubBody scrubs request body the same way as recorded ones, so secrets don't break strict matching
+func (r *Recorder) scrubBody(body []byte) string {
+	return r.opts.Scrubber(Interaction{Request: Request{Body: string(body)}}).Request.Body
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close() // #nosec G104
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// sameURL compares path and query arguments ignoring the order of arguments
+func sameURL(a, b string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return ua.Path == ub.Path && ua.Query().Encode() == ub.Query().Encode()
+}
+
+// sameBody compares bodies as JSON values if both are JSON
+func sameBody(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var va, vb interface{}
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+	na, _ := json.Marshal(va)
+	nb, _ := json.Marshal(vb)
+	return bytes.Equal(na, nb)
+}
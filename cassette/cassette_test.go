@@ -0,0 +1,212 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cassette
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dell/gopowerstore"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	apiURL = "https://array.example.com/api/rest"
+	volID  = "39bb1b5f-5624-490d-9ece-18f7b28a904e"
+)
+
+func newArrayTransport() *httpmock.MockTransport {
+	transport := httpmock.NewMockTransport()
+	transport.RegisterResponder("GET", apiURL+"/login_session",
+		func(_ *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(http.StatusOK, `[{"id": "1", "idle_timeout": 3600}]`)
+			resp.Header.Set("DELL-EMC-TOKEN", "secret-token")
+			resp.Header.Set("Set-Cookie", "auth_cookie=secret-cookie")
+			return resp, nil
+		})
+	transport.RegisterResponder("GET", apiURL+"/volume/"+volID,
+		httpmock.NewStringResponder(http.StatusOK, `{"id": "`+volID+`", "name": "vol-1"}`))
+	transport.RegisterResponder("POST", apiURL+"/host",
+		httpmock.NewStringResponder(http.StatusCreated, `{"id": "host-1"}`))
+	return transport
+}
+
+func newClient(t *testing.T, recorder *Recorder) gopowerstore.Client {
+	c, err := gopowerstore.NewClientWithArgs(apiURL, "admin", "Password123!",
+		gopowerstore.NewClientOptions().SetTransport(recorder).SetDefaultTimeout(10*time.Second))
+	assert.NoError(t, err)
+	return c
+}
+
+func record(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "cassettes", "volume.json")
+	recorder, err := New(path, Options{Mode: ModeRecord, Transport: newArrayTransport()})
+	assert.NoError(t, err)
+	c := newClient(t, recorder)
+
+	vol, err := c.GetVolume(context.Background(), volID)
+	assert.NoError(t, err)
+	assert.Equal(t, "vol-1", vol.Name)
+	chap := "chap-secret-value"
+	name := "host-1"
+	_, err = c.CreateHost(context.Background(), &gopowerstore.HostCreate{
+		Name: &name,
+		Initiators: &[]gopowerstore.InitiatorCreateModify{{
+			ChapSinglePassword: &chap,
+			ChapSingleUsername: &name,
+		}},
+	})
+	assert.NoError(t, err)
+	return path
+}
+
+func TestRecorder_Record(t *testing.T) {
+	path := record(t)
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	content := string(data)
+
+	for _, secret := range []string{"Password123!", "secret-token", "secret-cookie", "chap-secret-value", "array.example.com"} {
+		assert.NotContains(t, content, secret)
+	}
+	assert.Contains(t, content, `\"chap_single_password\":\"******\"`)
+	assert.Contains(t, content, `"url": "/api/rest/volume/`+volID)
+
+	recorder, err := New(path, Options{})
+	assert.NoError(t, err)
+	interactions := recorder.Interactions()
+	assert.Len(t, interactions, 3)
+	assert.Equal(t, Redacted, interactions[0].Response.Headers.Get("DELL-EMC-TOKEN"))
+	assert.Equal(t, Redacted, interactions[1].Request.Headers.Get("Authorization"))
+}
+
+func TestRecorder_ReplayStrict(t *testing.T) {
+	path := record(t)
+	recorder, err := New(path, Options{Mode: ModeReplay, Match: MatchStrict})
+	assert.NoError(t, err)
+	c := newClient(t, recorder)
+
+	vol, err := c.GetVolume(context.Background(), volID)
+	assert.NoError(t, err)
+	assert.Equal(t, "vol-1", vol.Name)
+
+	// the next recorded request is POST /host
+	_, err = c.GetVolume(context.Background(), volID)
+	assert.True(t, errors.Is(err, ErrNoInteraction))
+}
+
+func TestRecorder_ReplayStrictBody(t *testing.T) {
+	path := record(t)
+	recorder, err := New(path, Options{Mode: ModeReplay, Match: MatchStrict})
+	assert.NoError(t, err)
+	c := newClient(t, recorder)
+	_, err = c.GetVolume(context.Background(), volID)
+	assert.NoError(t, err)
+
+	name := "other-host"
+	_, err = c.CreateHost(context.Background(), &gopowerstore.HostCreate{Name: &name})
+	assert.True(t, errors.Is(err, ErrNoInteraction))
+}
+
+func TestRecorder_ReplayLenient(t *testing.T) {
+	path := record(t)
+	recorder, err := New(path, Options{Mode: ModeReplay, Match: MatchLenient})
+	assert.NoError(t, err)
+	c := newClient(t, recorder)
+
+	// any order, any body and repeated requests are served
+	name := "other-host"
+	resp, err := c.CreateHost(context.Background(), &gopowerstore.HostCreate{Name: &name})
+	assert.NoError(t, err)
+	assert.Equal(t, "host-1", resp.ID)
+	for i := 0; i < 2; i++ {
+		vol, err := c.GetVolume(context.Background(), volID)
+		assert.NoError(t, err)
+		assert.Equal(t, volID, vol.ID)
+	}
+	_, err = c.GetHost(context.Background(), "host-1")
+	assert.True(t, errors.Is(err, ErrNoInteraction))
+}
+
+func TestNew_Errors(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), Options{})
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "invalid.json")
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err = New(path, Options{})
+	assert.ErrorContains(t, err, "invalid file")
+}
+
+func TestParseMode(t *testing.T) {
+	mode, err := ParseMode("Record")
+	assert.NoError(t, err)
+	assert.Equal(t, ModeRecord, mode)
+	mode, err = ParseMode("")
+	assert.NoError(t, err)
+	assert.Equal(t, ModeReplay, mode)
+	_, err = ParseMode("rewind")
+	assert.Error(t, err)
+
+	match, err := ParseMatch("strict")
+	assert.NoError(t, err)
+	assert.Equal(t, MatchStrict, match)
+	match, err = ParseMatch("")
+	assert.NoError(t, err)
+	assert.Equal(t, MatchLenient, match)
+	_, err = ParseMatch("fuzzy")
+	assert.Error(t, err)
+}
+
+func TestScrubJSON(t *testing.T) {
+	assert.Equal(t, `{"chap_mode":"Single","nested":[{"password":"******"}]}`,
+		ScrubJSON(`{"chap_mode": "Single", "nested": [{"password": "x"}]}`))
+	unchanged := `{"name": "vol"}`
+	assert.Equal(t, unchanged, ScrubJSON(unchanged))
+	assert.Equal(t, `broken {"password": "******", "name": "a"`, ScrubJSON(`broken {"password": "x", "name": "a"`))
+}
+
+func TestChain(t *testing.T) {
+	upper := func(i Interaction) Interaction {
+		i.Response.Body = strings.ToUpper(i.Response.Body)
+		return i
+	}
+	i := Chain(DefaultScrubber, upper)(Interaction{Response: Response{Body: `{"token": "abc", "name": "vol"}`}})
+	assert.Equal(t, `{"NAME":"VOL","TOKEN":"******"}`, i.Response.Body)
+}
+
+func TestRecorder_ReplayResponse(t *testing.T) {
+	path := record(t)
+	recorder, err := New(path, Options{Match: MatchLenient})
+	assert.NoError(t, err)
+	req, _ := http.NewRequest("GET", "https://other.host/api/rest/volume/"+volID+"?select=%2A", nil)
+	resp, err := recorder.RoundTrip(req)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "vol-1")
+}
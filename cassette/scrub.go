@@ -0,0 +1,115 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cassette
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+)
+
+// Redacted replaces scrubbed values
+const Redacted = "******"
+
+// Scrubber removes secrets from interaction before it is saved
+type Scrubber func(Interaction) Interaction
+
+// sensitiveHeaders are replaced in requests and responses
+var sensitiveHeaders = []string{"Authorization", "Dell-Emc-Token", "Cookie", "Set-Cookie", "Proxy-Authorization"}
+
+// sensitiveKey matches names of JSON properties which hold secrets, e.g. password or chap_mutual_password
+var sensitiveKey = regexp.MustCompile(`(?i)(password|passwd|secret|token)`)
+
+// sensitiveText replaces secrets in bodies which are not valid JSON
+var sensitiveText = regexp.MustCompile(`(?i)("[^"]*(?:password|passwd|secret|token)[^"]*"\s*:\s*)"[^"]*"`)
+
+// DefaultScrubber removes credentials, session tokens and cookies from headers and
+// replaces values of JSON properties like password, chap_single_password or *_secret
+func DefaultScrubber(i Interaction) Interaction {
+	i.Request.Headers = scrubHeaders(i.Request.Headers)
+	i.Response.Headers = scrubHeaders(i.Response.Headers)
+	i.Request.Body = ScrubJSON(i.Request.Body)
+	i.Response.Body = ScrubJSON(i.Response.Body)
+	return i
+}
+
+// Chain returns Scrubber which applies scrubbers in order
+func Chain(scrubbers ...Scrubber) Scrubber {
+	return func(i Interaction) Interaction {
+		for _, s := range scrubbers {
+			i = s(i)
+		}
+		return i
+	}
+}
+
+func scrubHeaders(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+	h = h.Clone()
+	for _, name := range sensitiveHeaders {
+		if len(h.Values(name)) != 0 {
+			h.Set(name, Redacted)
+		}
+	}
+	return h
+}
+
+// ScrubJSON replaces string values of sensitive properties in JSON document
+func ScrubJSON(body string) string {
+	if body == "" {
+		return body
+	}
+	var v interface{}
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return sensitiveText.ReplaceAllString(body, `${1}"`+Redacted+`"`)
+	}
+	if !scrubValue(v) {
+		return body
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return string(data)
+}
+
+// scrubValue replaces secrets in decoded JSON in place and returns true if anything was replaced
+func scrubValue(v interface{}) bool {
+	changed := false
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			if _, isString := item.(string); isString && sensitiveKey.MatchString(k) {
+				if item != Redacted {
+					val[k] = Redacted
+					changed = true
+				}
+				continue
+			}
+			changed = scrubValue(item) || changed
+		}
+	case []interface{}:
+		for _, item := range val {
+			changed = scrubValue(item) || changed
+		}
+	}
+	return changed
+}
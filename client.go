@@ -20,9 +20,11 @@ package gopowerstore
 
 import (
 	"context"
+	"iter"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dell/gopowerstore/api"
@@ -36,6 +38,10 @@ const (
 	InsecureEnv               = "GOPOWERSTORE_INSECURE"
 	HTTPTimeoutEnv            = "GOPOWERSTORE_HTTP_TIMEOUT"
 	DebugEnv                  = "GOPOWERSTORE_DEBUG"
+	CACertEnv                 = "GOPOWERSTORE_CA_CERT"
+	ClientCertEnv             = "GOPOWERSTORE_CLIENT_CERT"
+	ClientKeyEnv              = "GOPOWERSTORE_CLIENT_KEY"
+	ProxyEnv                  = "GOPOWERSTORE_PROXY"
 	paginationDefaultPageSize = 1000
 )
 
@@ -45,6 +51,8 @@ type Client interface {
 	SetTraceID(ctx context.Context, value string) context.Context
 	SetCustomHTTPHeaders(headers http.Header)
 	GetCustomHTTPHeaders() http.Header
+	Ping(ctx context.Context) error
+	Close(ctx context.Context) error
 	GetVolume(ctx context.Context, id string) (Volume, error)
 	GetVolumeByName(ctx context.Context, name string) (Volume, error)
 	GetVolumes(ctx context.Context) ([]Volume, error)
@@ -59,6 +67,7 @@ type Client interface {
 	DeleteReplicationRule(ctx context.Context, id string) (resp EmptyResponse, err error)
 	DeleteProtectionPolicy(ctx context.Context, id string) (resp EmptyResponse, err error)
 	DeleteVolumeGroup(ctx context.Context, id string) (resp EmptyResponse, err error)
+	DeleteVolumeGroupAsync(ctx context.Context, id string) (AsyncResponse, error)
 	GetAppliance(ctx context.Context, id string) (ApplianceInstance, error)
 	GetApplianceByName(ctx context.Context, name string) (ApplianceInstance, error)
 	GetHost(ctx context.Context, id string) (Host, error)
@@ -81,6 +90,8 @@ type Client interface {
 	GetFCPort(ctx context.Context, id string) (resp FcPort, err error)
 	GetSoftwareInstalled(ctx context.Context) (resp []SoftwareInstalled, err error)
 	GetSoftwareMajorMinorVersion(ctx context.Context) (majorVersion float32, err error)
+	GetArrayVersion(ctx context.Context) (api.ArrayVersion, error)
+	RequireCapability(ctx context.Context, capability Capability) error
 	SetLogger(logger Logger)
 	CreateSnapshot(ctx context.Context, createSnapParams *SnapshotCreate, id string) (CreateResponse, error)
 	DeleteSnapshot(ctx context.Context, deleteParams *VolumeDelete, id string) (EmptyResponse, error)
@@ -96,16 +107,22 @@ type Client interface {
 	GetNfsServer(ctx context.Context, id string) (NFSServerInstance, error)
 	ListFS(ctx context.Context) ([]FileSystem, error)
 	GetInProgressJobsByFsName(ctx context.Context, name string) ([]Job, error)
+	GetJob(ctx context.Context, id string) (Job, error)
+	ListJobs(ctx context.Context, filter map[string]string) ([]Job, error)
+	GetJobSteps(ctx context.Context, id string) ([]Job, error)
+	WaitForJob(ctx context.Context, id string, opts *WaitForJobOptions) (Job, error)
 	GetFSByName(ctx context.Context, name string) (FileSystem, error)
 	GetFS(ctx context.Context, id string) (FileSystem, error)
 	GetFileInterface(ctx context.Context, id string) (FileInterface, error)
 	GetNFSExport(ctx context.Context, id string) (resp NFSExport, err error)
 	GetNFSExportByFilter(ctx context.Context, filter map[string]string) ([]NFSExport, error)
+	GetNFSExportsByFilters(ctx context.Context, filters ...api.Filter) ([]NFSExport, error)
 	GetNFSExportByName(ctx context.Context, name string) (NFSExport, error)
 	GetNFSExportByFileSystemID(ctx context.Context, fsID string) (NFSExport, error)
 	CreateNAS(ctx context.Context, createParams *NASCreate) (CreateResponse, error)
 	DeleteNAS(ctx context.Context, id string) (EmptyResponse, error)
 	CreateFS(ctx context.Context, createParams *FsCreate) (CreateResponse, error)
+	CreateFSAsync(ctx context.Context, createParams *FsCreate) (AsyncResponse, error)
 	DeleteFS(ctx context.Context, id string) (EmptyResponse, error)
 	CreateNFSExport(ctx context.Context, createParams *NFSExportCreate) (CreateResponse, error)
 	DeleteNFSExport(ctx context.Context, id string) (EmptyResponse, error)
@@ -118,7 +135,9 @@ type Client interface {
 	GetFsSnapshot(ctx context.Context, snapID string) (FileSystem, error)
 	CreateFsFromSnapshot(ctx context.Context, createParams *FsClone, snapID string) (CreateResponse, error)
 	GetFsByFilter(ctx context.Context, filter map[string]string) ([]FileSystem, error)
+	GetFsByFilters(ctx context.Context, filters ...api.Filter) ([]FileSystem, error)
 	CloneVolume(ctx context.Context, createParams *VolumeClone, volID string) (CreateResponse, error)
+	CloneVolumeAsync(ctx context.Context, createParams *VolumeClone, volID string) (AsyncResponse, error)
 	ModifyVolume(ctx context.Context, modifyParams *VolumeModify, volID string) (EmptyResponse, error)
 	ModifyFS(ctx context.Context, modifyParams *FSModify, volID string) (EmptyResponse, error)
 	CloneFS(ctx context.Context, createParams *FsClone, fsID string) (CreateResponse, error)
@@ -148,6 +167,7 @@ type Client interface {
 	GetReplicationSessionByLocalResourceID(ctx context.Context, id string) (ReplicationSession, error)
 	GetAllRemoteSystems(ctx context.Context) (resp []RemoteSystem, err error)
 	GetRemoteSystems(ctx context.Context, filters map[string]string) (resp []RemoteSystem, err error)
+	GetRemoteSystemsByFilters(ctx context.Context, filters ...api.Filter) ([]RemoteSystem, error)
 	GetCluster(ctx context.Context) (Cluster, error)
 	PerformanceMetricsByAppliance(ctx context.Context, entityID string, interval MetricsIntervalEnum) ([]PerformanceMetricsByApplianceResponse, error)
 	PerformanceMetricsByNode(ctx context.Context, entityID string, interval MetricsIntervalEnum) ([]PerformanceMetricsByNodeResponse, error)
@@ -209,6 +229,7 @@ type Client interface {
 	DeleteSMBShare(ctx context.Context, id string) (resp EmptyResponse, err error)
 	GetSMBShare(ctx context.Context, id string) (resp SMBShare, err error)
 	GetSMBShares(ctx context.Context, args map[string]string) (resp []SMBShare, err error)
+	GetSMBSharesByFilters(ctx context.Context, filters ...api.Filter) ([]SMBShare, error)
 	SetSMBShareACL(ctx context.Context, id string, acl *ModifySMBShareACL) (resp EmptyResponse, err error)
 	GetSMBShareACL(ctx context.Context, id string) (resp SMBShareACL, err error)
 }
@@ -240,44 +261,52 @@ func (c *ClientIMPL) SetLogger(logger Logger) {
 	c.API.SetLogger(api.Logger(logger))
 }
 
+// Ping checks that the array is reachable and the session is valid
+func (c *ClientIMPL) Ping(ctx context.Context) error {
+	return WrapErr(c.API.Ping(ctx))
+}
+
+// Close ends the login session on the array
+func (c *ClientIMPL) Close(ctx context.Context) error {
+	return WrapErr(c.API.Close(ctx))
+}
+
 // APIClient method returns powerstore API client may be useful for doing raw API requests
 func (c *ClientIMPL) APIClient() api.Client {
 	return c.API
 }
 
-// method allow to read paginated data from backend
-func (c *ClientIMPL) readPaginatedData(f func(int) (api.RespMeta, error)) error {
-	var err error
-	var meta api.RespMeta
-	meta, err = f(0)
-	if err != nil {
-		return err
-	}
-	if meta.Pagination.IsPaginate {
-		for {
-			nextOffset := meta.Pagination.Last + 1
-			if nextOffset >= meta.Pagination.Total {
-				break
-			}
-			meta, err = f(nextOffset)
-			err = WrapErr(err)
-			if err != nil {
-				apiError, ok := err.(*APIError)
-				if !ok {
-					return err
-				}
-				if apiError.BadRange() {
-					// could happen if some instances was deleted during pagination
-					break
-				}
+// Paginate returns iterator over all instances of a collection endpoint, see api.Paginate.
+// Errors are converted with WrapErr.
+func Paginate[T any](ctx context.Context, c Client, cfg RequestConfig, pageSize int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for item, err := range api.Paginate[T](ctx, c.APIClient(), cfg.RenderRequestConfig(), pageSize) {
+			if !yield(item, WrapErr(err)) {
+				return
 			}
 		}
 	}
-	return nil
 }
 
-// NewClient returns new PowerStore API client initialized from env vars
+// readPaginatedData reads all pages of a collection endpoint
+func readPaginatedData[T any](ctx context.Context, c Client, cfg RequestConfig) ([]T, error) {
+	return api.Collect(Paginate[T](ctx, c, cfg, paginationDefaultPageSize))
+}
+
+// NewClient returns new PowerStore API client initialized from env vars,
+// when GOPOWERSTORE_CONFIG is set the client of the default array in the config file is returned
 func NewClient() (Client, error) {
+	if path := os.Getenv(ConfigFileEnv); path != "" {
+		cfg, err := LoadConfig(path)
+		if err != nil {
+			return nil, err
+		}
+		array, err := cfg.DefaultArray()
+		if err != nil {
+			return nil, err
+		}
+		return array.NewClient()
+	}
 	options := NewClientOptions()
 	insecure, err := strconv.ParseBool(os.Getenv(InsecureEnv))
 	if err == nil {
@@ -288,6 +317,15 @@ func NewClient() (Client, error) {
 	if err == nil {
 		options.SetDefaultTimeout(time.Duration(httpTimeout) * time.Second)
 	}
+	if caCert := os.Getenv(CACertEnv); caCert != "" {
+		options.SetCACertificatesFile(caCert)
+	}
+	if clientCert, clientKey := os.Getenv(ClientCertEnv), os.Getenv(ClientKeyEnv); clientCert != "" || clientKey != "" {
+		options.SetClientCertificateFiles(clientCert, clientKey)
+	}
+	if proxy := os.Getenv(ProxyEnv); proxy != "" {
+		options.SetProxy(proxy)
+	}
 	return NewClientWithArgs(
 		os.Getenv(APIURLEnv),
 		os.Getenv(UsernameEnv),
@@ -295,13 +333,20 @@ func NewClient() (Client, error) {
 		options)
 }
 
-// NewClientWithArgs returns new PowerStore API client initialized from args
+// NewClientWithArgs returns new PowerStore API client initialized from args,
+// apiURL may be a comma separated list of management addresses of the same cluster,
+// the client uses the first one and fails over to the others when it becomes unreachable.
+// Only failed connection attempts trigger failover, a connection reset or a failed TLS handshake doesn't,
+// and the client fails over only after it has read identity of the cluster, which it does after the first login
 func NewClientWithArgs(
 	apiURL string,
 	username, password string, options *ClientOptions,
 ) (Client, error) {
-	client, err := api.New(apiURL, username, password,
-		options.Insecure(), options.DefaultTimeout(), options.RateLimit(), options.RequestIDKey())
+	cfg, err := newAPIConfig(apiURL, username, password, options)
+	if err != nil {
+		return nil, err
+	}
+	client, err := api.NewWithConfig(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -309,8 +354,79 @@ func NewClientWithArgs(
 	return &ClientIMPL{client}, nil
 }
 
+// NewClientWithContext returns new PowerStore API client which is already logged in,
+// invalid credentials are reported as *api.AuthError
+func NewClientWithContext(
+	ctx context.Context, apiURL string,
+	username, password string, options *ClientOptions,
+) (Client, error) {
+	cfg, err := newAPIConfig(apiURL, username, password, options)
+	if err != nil {
+		return nil, err
+	}
+	client, err := api.NewWithContext(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClientIMPL{client}, nil
+}
+
+func newAPIConfig(apiURL string, username, password string, options *ClientOptions) (api.Config, error) {
+	transport, err := options.TransportOptions()
+	if err != nil {
+		return api.Config{}, err
+	}
+	apiURLs := splitAPIURLs(apiURL)
+	return api.Config{
+		APIURL:         apiURLs[0],
+		FailoverURLs:   apiURLs[1:],
+		Username:       username,
+		Password:       password,
+		Credentials:    options.CredentialProvider(),
+		DefaultTimeout: options.DefaultTimeout(),
+		RateLimit:      options.RateLimit(),
+		RequestIDKey:   options.RequestIDKey(),
+		Transport:      transport,
+		TracerProvider: options.TracerProvider(),
+		// options are applied before the first login, so the login is sent with them
+		Configure: func(client *api.ClientIMPL) { applyClientOptions(client, options) },
+	}, nil
+}
+
+// splitAPIURLs splits comma separated list of management addresses, it always returns at least one item
+func splitAPIURLs(apiURL string) []string {
+	var res []string
+	for _, u := range strings.Split(apiURL, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			res = append(res, u)
+		}
+	}
+	if len(res) == 0 {
+		return []string{""}
+	}
+	return res
+}
+
+// applyClientOptions sets options which can be changed after api client is created
+func applyClientOptions(client *api.ClientIMPL, options *ClientOptions) {
+	client.SetRetryPolicy(options.RetryPolicy())
+	client.Use(options.Middlewares()...)
+	client.SetRequestCoalescing(options.RequestCoalescing())
+	client.SetDryRun(options.DryRun())
+	client.SetAuditSink(options.AuditSink())
+	client.SetCircuitBreaker(options.CircuitBreaker())
+	if hook := options.Metrics(); hook != nil {
+		client.SetMetrics(hook)
+	}
+	if cfg := options.RateLimiter(); cfg != nil {
+		client.SetRateLimiter(api.NewTokenBucketRateLimiter(*cfg, nil))
+	}
+}
+
 func NewMockClient(options *ClientOptions) Client {
 	client := api.MockClient(options.DefaultTimeout(), options.RateLimit(), options.RequestIDKey())
+	applyClientOptions(client, options)
 
 	return &ClientIMPL{client}
 }
@@ -1,6 +1,6 @@
 /*
  *
- * Copyright © 2020-2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+ * Copyright © 2020-2025 Dell Inc. or its subsidiaries. All Rights Reserved.
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -19,9 +19,15 @@
 package gopowerstore
 
 import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
 	"time"
 
 	"github.com/dell/gopowerstore/api"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ClientOptions defaults
@@ -44,6 +50,38 @@ type ClientOptions struct {
 	rateLimit      *int
 	// define field name in context which will be used for tracing
 	requestIDKey *api.ContextKey
+	// policy used to retry failed requests, retries are disabled when nil
+	retryPolicy *api.RetryPolicy
+	// PEM encoded CA bundle, either inline or read from file
+	caCertificates     []byte
+	caCertificatesFile string
+	// PEM encoded client certificate and key for mutual TLS, either inline or read from files
+	clientCertificate     []byte
+	clientKey             []byte
+	clientCertificateFile string
+	clientKeyFile         string
+	// URL of http proxy used to reach PowerStore
+	proxyURL string
+	// custom transport which replaces the default one
+	transport http.RoundTripper
+	// requests per second budgets, applied in addition to rateLimit
+	rateLimiter *api.RateLimiterConfig
+	// OpenTelemetry provider of spans
+	tracerProvider trace.TracerProvider
+	// receiver of client operational metrics
+	metrics api.MetricsHook
+	// provider of credentials which replaces username and password
+	credentialProvider api.CredentialProvider
+	// middlewares added to the chain of every request
+	middlewares []api.Middleware
+	// merge identical concurrent GET requests
+	requestCoalescing bool
+	// plan which records mutating requests instead of sending them
+	dryRun *api.DryRunPlan
+	// receiver of records of mutating requests
+	auditSink api.AuditSink
+	// circuit breaker settings, the breaker is disabled when nil
+	circuitBreaker *api.CircuitBreakerConfig
 }
 
 // Insecure returns insecure client option
@@ -70,6 +108,46 @@ func (co *ClientOptions) RateLimit() int {
 	return *co.rateLimit
 }
 
+// RateLimiter returns requests per second budgets, nil means requests per second are not limited
+func (co *ClientOptions) RateLimiter() *api.RateLimiterConfig {
+	return co.rateLimiter
+}
+
+// Metrics returns receiver of client operational metrics, nil means metrics are disabled
+func (co *ClientOptions) Metrics() api.MetricsHook {
+	return co.metrics
+}
+
+// CredentialProvider returns provider of credentials, nil means username and password passed to the client are used
+func (co *ClientOptions) CredentialProvider() api.CredentialProvider {
+	return co.credentialProvider
+}
+
+// Middlewares returns middlewares added to the chain of every request
+func (co *ClientOptions) Middlewares() []api.Middleware {
+	return co.middlewares
+}
+
+// RequestCoalescing returns true if identical concurrent GET requests are merged
+func (co *ClientOptions) RequestCoalescing() bool {
+	return co.requestCoalescing
+}
+
+// DryRun returns plan which records mutating requests in dry-run mode, nil if dry-run mode is disabled
+func (co *ClientOptions) DryRun() *api.DryRunPlan {
+	return co.dryRun
+}
+
+// AuditSink returns receiver of records of mutating requests
+func (co *ClientOptions) AuditSink() api.AuditSink {
+	return co.auditSink
+}
+
+// CircuitBreaker returns circuit breaker settings, nil if the circuit breaker is disabled
+func (co *ClientOptions) CircuitBreaker() *api.CircuitBreakerConfig {
+	return co.circuitBreaker
+}
+
 // RequestIDKey returns client requestIDKey
 func (co *ClientOptions) RequestIDKey() api.ContextKey {
 	if co.requestIDKey == nil {
@@ -78,6 +156,60 @@ func (co *ClientOptions) RequestIDKey() api.ContextKey {
 	return *co.requestIDKey
 }
 
+// RetryPolicy returns client retry policy
+func (co *ClientOptions) RetryPolicy() *api.RetryPolicy {
+	return co.retryPolicy
+}
+
+// TracerProvider returns OpenTelemetry provider of spans, nil disables tracing
+func (co *ClientOptions) TracerProvider() trace.TracerProvider {
+	return co.tracerProvider
+}
+
+// TransportOptions returns settings of http transport, certificate files are read on each call
+func (co *ClientOptions) TransportOptions() (api.TransportOptions, error) {
+	opts := api.TransportOptions{
+		Insecure:     co.Insecure(),
+		RoundTripper: co.transport,
+	}
+
+	opts.CACertificates = co.caCertificates
+	if co.caCertificatesFile != "" {
+		data, err := os.ReadFile(co.caCertificatesFile)
+		if err != nil {
+			return opts, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		opts.CACertificates = data
+	}
+
+	certPEM, keyPEM := co.clientCertificate, co.clientKey
+	if co.clientCertificateFile != "" || co.clientKeyFile != "" {
+		var err error
+		if certPEM, err = os.ReadFile(co.clientCertificateFile); err != nil {
+			return opts, fmt.Errorf("failed to read client certificate: %w", err)
+		}
+		if keyPEM, err = os.ReadFile(co.clientKeyFile); err != nil {
+			return opts, fmt.Errorf("failed to read client key: %w", err)
+		}
+	}
+	if len(certPEM) != 0 || len(keyPEM) != 0 {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return opts, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		opts.ClientCertificate = &cert
+	}
+
+	if co.proxyURL != "" {
+		proxy, err := url.Parse(co.proxyURL)
+		if err != nil {
+			return opts, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		opts.Proxy = http.ProxyURL(proxy)
+	}
+	return opts, nil
+}
+
 // SetInsecure sets insecure value
 func (co *ClientOptions) SetInsecure(value bool) *ClientOptions {
 	co.insecure = &value
@@ -90,7 +222,8 @@ func (co *ClientOptions) SetDefaultTimeout(value time.Duration) *ClientOptions {
 	return co
 }
 
-// SetRateLimit returns http client rate limit
+// SetRateLimit sets max number of concurrent requests, 0 removes the limit
+// which is useful when only requests per second are limited with SetRateLimiter
 func (co *ClientOptions) SetRateLimit(value int) *ClientOptions {
 	co.rateLimit = &value
 	return co
@@ -101,3 +234,107 @@ func (co *ClientOptions) SetRequestIDKey(value api.ContextKey) *ClientOptions {
 	co.requestIDKey = &value
 	return co
 }
+
+// SetRetryPolicy sets policy used to retry failed requests, use api.DefaultRetryPolicy for sane defaults
+func (co *ClientOptions) SetRetryPolicy(value *api.RetryPolicy) *ClientOptions {
+	co.retryPolicy = value
+	return co
+}
+
+// SetCACertificates sets PEM encoded CA bundle used to verify PowerStore certificate
+func (co *ClientOptions) SetCACertificates(value []byte) *ClientOptions {
+	co.caCertificates = value
+	return co
+}
+
+// SetCACertificatesFile sets path to PEM encoded CA bundle used to verify PowerStore certificate
+func (co *ClientOptions) SetCACertificatesFile(path string) *ClientOptions {
+	co.caCertificatesFile = path
+	return co
+}
+
+// SetClientCertificate sets PEM encoded certificate and key used for mutual TLS
+func (co *ClientOptions) SetClientCertificate(certPEM, keyPEM []byte) *ClientOptions {
+	co.clientCertificate = certPEM
+	co.clientKey = keyPEM
+	return co
+}
+
+// SetClientCertificateFiles sets paths to PEM encoded certificate and key used for mutual TLS
+func (co *ClientOptions) SetClientCertificateFiles(certFile, keyFile string) *ClientOptions {
+	co.clientCertificateFile = certFile
+	co.clientKeyFile = keyFile
+	return co
+}
+
+// SetProxy sets URL of http proxy used to reach PowerStore
+func (co *ClientOptions) SetProxy(proxyURL string) *ClientOptions {
+	co.proxyURL = proxyURL
+	return co
+}
+
+// SetTransport sets custom http transport, TLS and proxy options are ignored when it is set
+func (co *ClientOptions) SetTransport(value http.RoundTripper) *ClientOptions {
+	co.transport = value
+	return co
+}
+
+// SetRateLimiter sets requests per second budgets, see api.RateLimiterConfig
+func (co *ClientOptions) SetRateLimiter(value *api.RateLimiterConfig) *ClientOptions {
+	co.rateLimiter = value
+	return co
+}
+
+// SetTracerProvider sets OpenTelemetry provider of spans which are created for every API call
+func (co *ClientOptions) SetTracerProvider(value trace.TracerProvider) *ClientOptions {
+	co.tracerProvider = value
+	return co
+}
+
+// SetMetrics sets receiver of client operational metrics, see metrics.Collector for Prometheus
+func (co *ClientOptions) SetMetrics(value api.MetricsHook) *ClientOptions {
+	co.metrics = value
+	return co
+}
+
+// SetCredentialProvider sets provider of credentials which is called for every login, e.g. api.FileCredentials
+// picks up rotated passwords from Kubernetes secret mounts. Username and password passed to the client are ignored.
+func (co *ClientOptions) SetCredentialProvider(value api.CredentialProvider) *ClientOptions {
+	co.credentialProvider = value
+	return co
+}
+
+// SetMiddlewares sets middlewares which are added to the chain of every request, e.g. cache.Cache.Middleware
+func (co *ClientOptions) SetMiddlewares(value ...api.Middleware) *ClientOptions {
+	co.middlewares = value
+	return co
+}
+
+// SetRequestCoalescing enables merging of identical concurrent GET requests into a single API call,
+// every caller still gets its own copy of the response
+func (co *ClientOptions) SetRequestCoalescing(value bool) *ClientOptions {
+	co.requestCoalescing = value
+	return co
+}
+
+// SetDryRun enables dry-run mode, GET requests are still sent but POST, PATCH and DELETE requests
+// are recorded into the plan and succeed without reaching the array. Create requests return
+// placeholder ids, see api.IsDryRunID
+func (co *ClientOptions) SetDryRun(plan *api.DryRunPlan) *ClientOptions {
+	co.dryRun = plan
+	return co
+}
+
+// SetAuditSink sets receiver of a record for every POST, PATCH and DELETE request,
+// see audit.FileSink for a tamper-evident file trail
+func (co *ClientOptions) SetAuditSink(sink api.AuditSink) *ClientOptions {
+	co.auditSink = sink
+	return co
+}
+
+// SetCircuitBreaker enables circuit breaker which fails requests with api.ErrCircuitOpen after consecutive
+// connection errors, timeouts of the client or 5xx responses until the array answers a probe again
+func (co *ClientOptions) SetCircuitBreaker(config *api.CircuitBreakerConfig) *ClientOptions {
+	co.circuitBreaker = config
+	return co
+}
@@ -19,10 +19,16 @@
 package gopowerstore
 
 import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/dell/gopowerstore/api"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestClientOptions_Insecure(t *testing.T) {
@@ -50,3 +56,117 @@ func TestClientOptions_RateLimit(t *testing.T) {
 	co.SetRateLimit(value)
 	assert.Equal(t, value, co.RateLimit())
 }
+
+func TestClientOptions_RetryPolicy(t *testing.T) {
+	co := NewClientOptions()
+	assert.Nil(t, co.RetryPolicy())
+	value := api.DefaultRetryPolicy()
+	co.SetRetryPolicy(value)
+	assert.Equal(t, value, co.RetryPolicy())
+}
+
+func TestClientOptions_TransportOptions(t *testing.T) {
+	co := NewClientOptions()
+	opts, err := co.TransportOptions()
+	assert.Nil(t, err)
+	assert.False(t, opts.Insecure)
+	assert.Nil(t, opts.Proxy)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(caFile, []byte("ca"), 0o600))
+	rt := &http.Transport{}
+	co.SetInsecure(true).SetCACertificatesFile(caFile).SetProxy("http://proxy:3128").SetTransport(rt)
+	opts, err = co.TransportOptions()
+	assert.Nil(t, err)
+	assert.True(t, opts.Insecure)
+	assert.Equal(t, []byte("ca"), opts.CACertificates)
+	assert.NotNil(t, opts.Proxy)
+	assert.Equal(t, rt, opts.RoundTripper)
+
+	co.SetCACertificatesFile(filepath.Join(t.TempDir(), "missing.pem"))
+	_, err = co.TransportOptions()
+	assert.NotNil(t, err)
+
+	co = NewClientOptions().SetClientCertificate([]byte("cert"), []byte("key"))
+	_, err = co.TransportOptions()
+	assert.NotNil(t, err)
+
+	co = NewClientOptions().SetProxy("://bad")
+	_, err = co.TransportOptions()
+	assert.NotNil(t, err)
+}
+
+func TestClientOptions_RateLimiter(t *testing.T) {
+	co := NewClientOptions()
+	assert.Nil(t, co.RateLimiter())
+	value := &api.RateLimiterConfig{Global: api.RateLimitBudget{RequestsPerSecond: 10}}
+	co.SetRateLimiter(value)
+	assert.Equal(t, value, co.RateLimiter())
+	assert.NotNil(t, NewMockClient(co))
+}
+
+func TestClientOptions_TracerProvider(t *testing.T) {
+	co := NewClientOptions()
+	assert.Nil(t, co.TracerProvider())
+	tp := noop.NewTracerProvider()
+	co.SetTracerProvider(tp)
+	assert.Equal(t, tp, co.TracerProvider())
+	cfg, err := newAPIConfig("https://foo", "admin", "password", co)
+	assert.NoError(t, err)
+	assert.Equal(t, tp, cfg.TracerProvider)
+}
+
+func TestClientOptions_Metrics(t *testing.T) {
+	co := NewClientOptions()
+	assert.Nil(t, co.Metrics())
+	hook := api.NopMetrics{}
+	co.SetMetrics(hook)
+	assert.Equal(t, hook, co.Metrics())
+	// the hook is set once, by the options applied before the first login
+	cfg, err := newAPIConfig("https://foo", "admin", "password", co)
+	assert.NoError(t, err)
+	assert.Nil(t, cfg.Metrics)
+	assert.NotNil(t, cfg.Configure)
+	assert.NotNil(t, NewMockClient(co))
+}
+
+func TestClientOptions_DryRun(t *testing.T) {
+	co := NewClientOptions()
+	assert.Nil(t, co.DryRun())
+	plan := api.NewDryRunPlan()
+	co.SetDryRun(plan)
+	assert.Equal(t, plan, co.DryRun())
+
+	c := NewMockClient(co)
+	name := "dry-run-volume"
+	size := int64(1048576)
+	resp, err := c.CreateVolume(context.Background(), &VolumeCreate{Name: &name, Size: &size})
+	assert.NoError(t, err)
+	assert.True(t, api.IsDryRunID(resp.ID))
+	_, err = c.DeleteVolume(context.Background(), nil, resp.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "+ POST volume => dry-run-1\n    {\"name\":\"dry-run-volume\",\"size\":1048576}\n- DELETE volume/dry-run-1\n",
+		plan.Diff())
+}
+
+type nopAuditSink struct{}
+
+func (nopAuditSink) WriteAuditRecord(_ context.Context, _ api.AuditRecord) error { return nil }
+
+func TestClientOptions_AuditSink(t *testing.T) {
+	co := NewClientOptions()
+	assert.Nil(t, co.AuditSink())
+	sink := nopAuditSink{}
+	co.SetAuditSink(sink)
+	assert.Equal(t, sink, co.AuditSink())
+	assert.NotNil(t, NewMockClient(co))
+}
+
+func TestClientOptions_CircuitBreaker(t *testing.T) {
+	co := NewClientOptions()
+	assert.Nil(t, co.CircuitBreaker())
+	value := &api.CircuitBreakerConfig{FailureThreshold: 3}
+	co.SetCircuitBreaker(value)
+	assert.Equal(t, value, co.CircuitBreaker())
+	assert.NotNil(t, NewMockClient(co))
+}
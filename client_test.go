@@ -20,12 +20,15 @@ package gopowerstore
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/dell/gopowerstore/api"
+	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var C Client
@@ -58,3 +61,53 @@ func TestClientIMPL_SetTraceID(t *testing.T) {
 	ctx = C.SetTraceID(ctx, "123")
 	assert.Equal(t, "123", ctx.Value(api.ContextKey(clientOptionsDefaultRequestIDKey)))
 }
+
+func TestClientIMPL_Ping(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "login_session",
+		httpmock.NewStringResponder(http.StatusOK, `[{"id": "1"}]`))
+	assert.Nil(t, C.Ping(context.Background()))
+
+	httpmock.RegisterResponder("GET", "login_session",
+		httpmock.NewStringResponder(http.StatusNotFound, ""))
+	err := C.Ping(context.Background())
+	apiError, ok := err.(APIError)
+	assert.True(t, ok)
+	assert.True(t, apiError.NotFound())
+	assert.Nil(t, C.Close(context.Background()))
+}
+
+func TestNewClientWithContext(t *testing.T) {
+	_, err := NewClientWithContext(context.Background(), "api", "", "", NewClientOptions())
+	assert.NotNil(t, err)
+	_, err = NewClientWithContext(context.Background(), "api", "admin", "password",
+		NewClientOptions().SetCACertificatesFile("missing.pem"))
+	assert.NotNil(t, err)
+}
+
+func TestNewClientWithContext_OptionsBeforeLogin(t *testing.T) {
+	transport := httpmock.NewMockTransport()
+	transport.RegisterResponder("GET", "https://foo/api/rest/login_session",
+		httpmock.NewStringResponder(http.StatusOK, `[{"id": "1"}]`))
+	var paths []string
+	middleware := func(next api.Handler) api.Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			paths = append(paths, req.URL.Path)
+			return next(req)
+		}
+	}
+	options := NewClientOptions().SetDefaultTimeout(time.Second).SetTransport(transport).SetMiddlewares(middleware)
+
+	c, err := NewClientWithContext(context.Background(), "https://foo/api/rest", "admin", "password", options)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/api/rest/login_session"}, paths)
+	assert.NoError(t, c.Close(context.Background()))
+}
+
+func TestSplitAPIURLs(t *testing.T) {
+	assert.Equal(t, []string{"https://a/api/rest"}, splitAPIURLs("https://a/api/rest"))
+	assert.Equal(t, []string{"https://a/api/rest", "https://b/api/rest"},
+		splitAPIURLs(" https://a/api/rest, https://b/api/rest,"))
+	assert.Equal(t, []string{""}, splitAPIURLs(""))
+}
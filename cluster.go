@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/dell/gopowerstore/api"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -31,17 +32,11 @@ const (
 // GetCluster returns info about first cluster found
 func (c *ClientIMPL) GetCluster(ctx context.Context) (resp Cluster, err error) {
 	var systemList []Cluster
-	cluster := Cluster{}
-	qp := c.APIClient().QueryParamsWithFields(&cluster)
-
-	majorMinorVersion, err := c.GetSoftwareMajorMinorVersion(ctx)
+	arrayVersion, err := c.GetArrayVersion(ctx)
 	if err != nil {
 		log.Errorf("Couldn't find the array version %s", err.Error())
-	} else {
-		if majorMinorVersion >= 3.0 {
-			qp.Select("nvm_subsystem_nqn")
-		}
 	}
+	qp := c.APIClient().QueryParams().Select((&Cluster{}).FieldsForVersion(arrayVersion)...)
 	_, err = c.APIClient().Query(
 		ctx,
 		RequestConfig{
@@ -100,42 +95,24 @@ func (c *ClientIMPL) GetRemoteSystemByName(ctx context.Context, name string) (re
 // Queries all Remote Systems
 func (c *ClientIMPL) GetAllRemoteSystems(ctx context.Context) (resp []RemoteSystem, err error) {
 	sys := RemoteSystem{}
-	var retsys []RemoteSystem
-	qp := c.APIClient().QueryParamsWithFields(&sys)
-	_, err = c.APIClient().Query(
-		ctx,
-		RequestConfig{
-			Method:      "GET",
-			Endpoint:    remoteSystemURL,
-			QueryParams: qp,
-		},
-		&retsys)
-	err = WrapErr(err)
-	if err != nil {
-		return resp, err
-	}
-	return retsys, err
+	return readPaginatedData[RemoteSystem](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    remoteSystemURL,
+		QueryParams: c.APIClient().QueryParamsWithFields(&sys),
+	})
 }
 
 // Queries Remote Systems by filter
 func (c *ClientIMPL) GetRemoteSystems(ctx context.Context, filters map[string]string) (resp []RemoteSystem, err error) {
+	return c.GetRemoteSystemsByFilters(ctx, api.RawFilters(filters)...)
+}
+
+// GetRemoteSystemsByFilters queries Remote Systems matching filters
+func (c *ClientIMPL) GetRemoteSystemsByFilters(ctx context.Context, filters ...api.Filter) ([]RemoteSystem, error) {
 	sys := RemoteSystem{}
-	var retsys []RemoteSystem
-	qp := c.APIClient().QueryParamsWithFields(&sys)
-	for k, v := range filters {
-		qp.RawArg(k, v)
-	}
-	_, err = c.APIClient().Query(
-		ctx,
-		RequestConfig{
-			Method:      "GET",
-			Endpoint:    remoteSystemURL,
-			QueryParams: qp,
-		},
-		&retsys)
-	err = WrapErr(err)
-	if err != nil {
-		return resp, err
-	}
-	return retsys, err
+	return readPaginatedData[RemoteSystem](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    remoteSystemURL,
+		QueryParams: c.APIClient().QueryParamsWithFields(&sys).Filter(filters...),
+	})
 }
@@ -19,8 +19,10 @@ package gopowerstore
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/dell/gopowerstore/api"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
 )
@@ -42,6 +44,40 @@ func TestClientIMPL_GetAllRemoteSystems(t *testing.T) {
 	assert.Equal(t, volID, remoteSystems[0].ID)
 }
 
+func TestClientIMPL_GetAllRemoteSystems_Paginated(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", remoteSystemMockURL,
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("offset") == "0" {
+				resp := httpmock.NewStringResponse(206, fmt.Sprintf(`[{"id": "%s"}]`, volID))
+				resp.Header.Set("Content-Range", "0-0/2")
+				return resp, nil
+			}
+			resp := httpmock.NewStringResponse(206, fmt.Sprintf(`[{"id": "%s"}]`, volID2))
+			resp.Header.Set("Content-Range", "1-1/2")
+			return resp, nil
+		})
+	remoteSystems, err := C.GetAllRemoteSystems(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, remoteSystems, 2)
+	assert.Equal(t, volID2, remoteSystems[1].ID)
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+}
+
+func TestPaginate(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", remoteSystemMockURL,
+		httpmock.NewStringResponder(404, `{"messages": [{"message_l10n": "not found"}]}`))
+	for _, err := range Paginate[RemoteSystem](context.Background(), C,
+		RequestConfig{Method: "GET", Endpoint: remoteSystemURL}, 10) {
+		apiError, ok := err.(APIError)
+		assert.True(t, ok)
+		assert.True(t, apiError.NotFound())
+	}
+}
+
 func TestClientIMPL_GetRemoteSystems(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
@@ -65,6 +101,31 @@ func TestClientIMPL_GetCluster(t *testing.T) {
 	assert.Equal(t, volID, cluster.ID)
 }
 
+func TestClientIMPL_GetCluster_ArrayVersion(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	defer C.APIClient().(arrayVersionSetter).SetVersion(api.ArrayVersion{})
+	var selected string
+	httpmock.RegisterResponder("GET", clusterMockURL,
+		func(req *http.Request) (*http.Response, error) {
+			selected = req.URL.Query().Get("select")
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`[{"id": "%s"}]`, volID)), nil
+		})
+
+	httpmock.RegisterResponder("GET", apiSoftwareInstalledMockURL,
+		httpmock.NewStringResponder(200, `[{"id": "1", "is_cluster": true, "build_version": "2.1.0.0"}]`))
+	_, err := C.GetCluster(context.Background())
+	assert.Nil(t, err)
+	assert.NotContains(t, selected, "nvm_subsystem_nqn")
+
+	httpmock.RegisterResponder("GET", apiSoftwareInstalledMockURL,
+		httpmock.NewStringResponder(200, `[{"id": "1", "is_cluster": true, "build_version": "3.0.0.0"}]`))
+	_, err = C.GetCluster(context.Background())
+	assert.Nil(t, err)
+	assert.Contains(t, selected, "nvm_subsystem_nqn")
+	assert.Contains(t, C.APIClient().QueryParamsWithFields(&Cluster{}).Encode(), "nvm_subsystem_nqn")
+}
+
 func TestClientIMPL_GetRemoteSystem(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
@@ -16,6 +16,8 @@
 
 package gopowerstore
 
+import "github.com/dell/gopowerstore/api"
+
 // RemoteSystem details about a remote system
 type RemoteSystem struct {
 	// Unique identifier of the remote system instance.
@@ -79,12 +81,14 @@ type Cluster struct {
 	ID string `json:"id,omitempty"`
 	// User-specified name of the cluster
 	Name string `json:"name,omitempty"`
+	// Unique identifier of the cluster across all arrays, e.g. PS4ebb8d4e8488
+	GlobalID string `json:"global_id,omitempty"`
 	// Management IP address of the remote system instance
 	ManagementAddress string `json:"management_address,omitempty"`
 	// Current state of the cluster
 	State string `json:"state,omitempty"`
 	// NVMe Subsystem NQN for cluster
-	NVMeNQN string `json:"nvm_subsystem_nqn,omitempty"`
+	NVMeNQN string `json:"nvm_subsystem_nqn,omitempty" select:"min=3.0"`
 	// Current clock time for the system in UTC format.
 	SystemTime string `json:"system_time,omitempty"`
 }
@@ -93,3 +97,11 @@ type Cluster struct {
 func (r *Cluster) Fields() []string {
 	return []string{"id", "name", "management_address", "state", "system_time"}
 }
+
+// FieldsForVersion returns fields which the array version supports, Fields if the version is unknown
+func (r *Cluster) FieldsForVersion(version api.ArrayVersion) []string {
+	if version.IsZero() {
+		return r.Fields()
+	}
+	return api.SelectFieldsForArrayVersion(r, version)
+}
@@ -0,0 +1,615 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package gopowerstore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dell/gopowerstore/api"
+	"go.yaml.in/yaml/v3"
+)
+
+// ConfigFileEnv is env variable with path to the config file used by NewClient
+const ConfigFileEnv = "GOPOWERSTORE_CONFIG"
+
+// Config describes arrays the clients are built for, it is read from YAML or JSON:
+//
+//	defaults:
+//	  timeout: 30s
+//	  tls:
+//	    ca_file: /etc/powerstore/ca.pem
+//	arrays:
+//	  - name: primary
+//	    endpoints: [https://10.0.0.1/api/rest, https://10.0.0.2/api/rest]
+//	    username: ${POWERSTORE_USER}
+//	    password_file: /var/run/secrets/powerstore/password
+//	    default: true
+//
+// Values of defaults are used for every array which doesn't set them. String values may refer to env variables
+// as ${NAME} or ${NAME:-default}, $$ is replaced with $.
+type Config struct {
+	Arrays []ConfigArray `yaml:"arrays"`
+}
+
+// ConfigArray describes a single array in Config
+type ConfigArray struct {
+	// unique name of the array, the first endpoint is used when empty
+	Name string `yaml:"name"`
+	// API URL, e.g. https://10.0.0.1/api/rest
+	Endpoint string `yaml:"endpoint"`
+	// management addresses of the same cluster, the client fails over between them, see NewClientWithArgs
+	Endpoints []string `yaml:"endpoints"`
+	// global id of the cluster, e.g. PS4ebb8d4e8488
+	GlobalID     string `yaml:"global_id"`
+	Username     string `yaml:"username"`
+	UsernameFile string `yaml:"username_file"`
+	Password     string `yaml:"password"`
+	PasswordFile string `yaml:"password_file"`
+	// the array returned by ClientRegistry.Default
+	Default bool      `yaml:"default"`
+	TLS     ConfigTLS `yaml:"tls"`
+	// URL of http proxy used to reach the array
+	Proxy string `yaml:"proxy"`
+	// timeout of API calls, e.g. 30s
+	Timeout time.Duration `yaml:"timeout"`
+	// max number of concurrent requests, 0 removes the limit
+	RateLimit *int `yaml:"rate_limit"`
+	// requests per second budgets
+	RateLimiter *ConfigRateLimiter `yaml:"rate_limiter"`
+	// field name in context which holds the request id
+	RequestIDKey string `yaml:"request_id_key"`
+	// retry policy, unset fields take values of api.DefaultRetryPolicy
+	Retry *ConfigRetry `yaml:"retry"`
+	// circuit breaker which rejects requests while the array is unhealthy, disabled if unset
+	CircuitBreaker *ConfigCircuitBreaker `yaml:"circuit_breaker"`
+	// headers sent with every request
+	Headers map[string]string `yaml:"headers"`
+}
+
+// ConfigTLS describes TLS settings of an array
+type ConfigTLS struct {
+	Insecure bool `yaml:"insecure"`
+	// PEM encoded CA bundle, either inline or read from file
+	CA     string `yaml:"ca"`
+	CAFile string `yaml:"ca_file"`
+	// PEM encoded client certificate and key for mutual TLS, either inline or read from files
+	ClientCert     string `yaml:"client_cert"`
+	ClientKey      string `yaml:"client_key"`
+	ClientCertFile string `yaml:"client_cert_file"`
+	ClientKeyFile  string `yaml:"client_key_file"`
+}
+
+// ConfigRateLimiter describes requests per second budgets, see api.RateLimiterConfig
+type ConfigRateLimiter struct {
+	Global        ConfigRateLimitBudget            `yaml:"global"`
+	Endpoints     map[string]ConfigRateLimitBudget `yaml:"endpoints"`
+	Methods       map[string]ConfigRateLimitBudget `yaml:"methods"`
+	StatsInterval time.Duration                    `yaml:"stats_interval"`
+}
+
+// ConfigRateLimitBudget describes a single budget, see api.RateLimitBudget
+type ConfigRateLimitBudget struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	Burst             int     `yaml:"burst"`
+}
+
+// ConfigRetry describes retry policy, see api.RetryPolicy
+type ConfigRetry struct {
+	MaxAttempts          int           `yaml:"max_attempts"`
+	InitialBackoff       time.Duration `yaml:"initial_backoff"`
+	MaxBackoff           time.Duration `yaml:"max_backoff"`
+	Multiplier           float64       `yaml:"multiplier"`
+	Jitter               *float64      `yaml:"jitter"`
+	RetryableStatusCodes []int         `yaml:"retryable_status_codes"`
+	RetryOnNetworkErrors *bool         `yaml:"retry_on_network_errors"`
+	RetryNonIdempotent   bool          `yaml:"retry_non_idempotent"`
+}
+
+// ConfigCircuitBreaker describes circuit breaker, see api.CircuitBreakerConfig
+type ConfigCircuitBreaker struct {
+	FailureThreshold int           `yaml:"failure_threshold"`
+	OpenTimeout      time.Duration `yaml:"open_timeout"`
+	ProbeTimeout     time.Duration `yaml:"probe_timeout"`
+	FailureStatuses  []int         `yaml:"failure_statuses"`
+}
+
+// configFile is the top level of a config file before defaults are applied
+type configFile struct {
+	Defaults yaml.Node   `yaml:"defaults"`
+	Arrays   []yaml.Node `yaml:"arrays"`
+}
+
+// LoadConfig reads config from YAML or JSON file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	cfg, err := ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// ParseConfig parses YAML or JSON config, interpolates env variables, applies defaults and validates the result
+func ParseConfig(data []byte) (*Config, error) {
+	var file configFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	if err := expandNode(&file.Defaults); err != nil {
+		errs = append(errs, fmt.Errorf("defaults: %w", err))
+	}
+	cfg := &Config{}
+	for i := range file.Arrays {
+		node := &file.Arrays[i]
+		if err := expandNode(node); err != nil {
+			errs = append(errs, fmt.Errorf("arrays[%d]: %w", i, err))
+			continue
+		}
+		var array ConfigArray
+		if err := decodeStrict(mergeNodes(&file.Defaults, node), &array); err != nil {
+			errs = append(errs, fmt.Errorf("arrays[%d]: %w", i, err))
+			continue
+		}
+		cfg.Arrays = append(cfg.Arrays, array)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// decodeStrict decodes node into out failing on unknown fields
+func decodeStrict(node *yaml.Node, out interface{}) error {
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	return dec.Decode(out)
+}
+
+// mergeNodes returns mapping with keys of override and keys of base which override lacks, nested mappings are merged
+func mergeNodes(base, override *yaml.Node) *yaml.Node {
+	if base.Kind != yaml.MappingNode || override.Kind != yaml.MappingNode {
+		if override.Kind == 0 {
+			return base
+		}
+		return override
+	}
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: override.Tag}
+	merged.Content = append(merged.Content, override.Content...)
+	for i := 0; i+1 < len(base.Content); i += 2 {
+		key := base.Content[i]
+		j := mappingIndex(merged, key.Value)
+		if j < 0 {
+			merged.Content = append(merged.Content, key, base.Content[i+1])
+			continue
+		}
+		merged.Content[j+1] = mergeNodes(base.Content[i+1], merged.Content[j+1])
+	}
+	return merged
+}
+
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+var envReference = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// expandNode replaces references to env variables in scalar values of the node
+func expandNode(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		var errs []error
+		for _, n := range node.Content {
+			errs = append(errs, expandNode(n))
+		}
+		return errors.Join(errs...)
+	}
+	if !strings.Contains(node.Value, "$") {
+		return nil
+	}
+	var missing []string
+	node.Value = envReference.ReplaceAllStringFunc(node.Value, func(ref string) string {
+		if ref == "$$" {
+			return "$"
+		}
+		m := envReference.FindStringSubmatch(ref)
+		if value, ok := os.LookupEnv(m[1]); ok && value != "" {
+			return value
+		}
+		if m[2] == "" {
+			missing = append(missing, m[1])
+		}
+		return m[3]
+	})
+	if len(missing) > 0 {
+		return fmt.Errorf("line %d: env variable %s is not set", node.Line, strings.Join(missing, ", "))
+	}
+	if node.Style == 0 || node.Style == yaml.TaggedStyle {
+		// let the value be resolved again, e.g. rate_limit: ${RATE_LIMIT} is a number
+		node.Tag = ""
+	}
+	return nil
+}
+
+// Validate checks that the config describes arrays clients can be built for
+func (c *Config) Validate() error {
+	if len(c.Arrays) == 0 {
+		return errors.New("no arrays are configured")
+	}
+	var errs []error
+	names := map[string]int{}
+	defaultIndex := -1
+	for i, a := range c.Arrays {
+		name := a.name()
+		prefix := fmt.Sprintf("arrays[%d]", i)
+		if name != "" {
+			prefix = fmt.Sprintf("arrays[%d] (%s)", i, name)
+			if j, ok := names[name]; ok {
+				errs = append(errs, fmt.Errorf("%s: name is already used by arrays[%d]", prefix, j))
+			}
+			names[name] = i
+		}
+		if a.Default {
+			if defaultIndex >= 0 {
+				errs = append(errs, fmt.Errorf("%s: arrays[%d] is already the default one", prefix, defaultIndex))
+			}
+			defaultIndex = i
+		}
+		for _, err := range a.validate() {
+			errs = append(errs, fmt.Errorf("%s: %w", prefix, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (a *ConfigArray) name() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	if endpoints := a.endpoints(); len(endpoints) > 0 {
+		return endpoints[0]
+	}
+	return ""
+}
+
+func (a *ConfigArray) endpoints() []string {
+	if a.Endpoint != "" {
+		return append([]string{a.Endpoint}, a.Endpoints...)
+	}
+	return a.Endpoints
+}
+
+func (a *ConfigArray) validate() []error {
+	var errs []error
+	if len(a.endpoints()) == 0 {
+		errs = append(errs, errors.New("endpoint is required"))
+	}
+	for _, endpoint := range a.endpoints() {
+		u, err := url.Parse(endpoint)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("endpoint %q is not a http(s) URL", endpoint))
+		}
+	}
+	errs = append(errs, exclusive("username", a.Username, "username_file", a.UsernameFile, true)...)
+	errs = append(errs, exclusive("password", a.Password, "password_file", a.PasswordFile, true)...)
+	errs = append(errs, exclusive("tls.ca", a.TLS.CA, "tls.ca_file", a.TLS.CAFile, false)...)
+	errs = append(errs, exclusive("tls.client_cert", a.TLS.ClientCert, "tls.client_cert_file", a.TLS.ClientCertFile, false)...)
+	errs = append(errs, exclusive("tls.client_key", a.TLS.ClientKey, "tls.client_key_file", a.TLS.ClientKeyFile, false)...)
+	hasCert := a.TLS.ClientCert != "" || a.TLS.ClientCertFile != ""
+	hasKey := a.TLS.ClientKey != "" || a.TLS.ClientKeyFile != ""
+	if hasCert != hasKey {
+		errs = append(errs, errors.New("tls client certificate and key must be set together"))
+	}
+	if a.Proxy != "" {
+		if _, err := url.Parse(a.Proxy); err != nil {
+			errs = append(errs, fmt.Errorf("proxy is not a URL: %w", err))
+		}
+	}
+	if a.Timeout < 0 {
+		errs = append(errs, errors.New("timeout must not be negative"))
+	}
+	if a.RateLimit != nil && *a.RateLimit < 0 {
+		errs = append(errs, errors.New("rate_limit must not be negative"))
+	}
+	if a.RateLimiter != nil {
+		errs = append(errs, a.RateLimiter.validate()...)
+	}
+	if a.Retry != nil {
+		errs = append(errs, a.Retry.validate()...)
+	}
+	if a.CircuitBreaker != nil {
+		errs = append(errs, a.CircuitBreaker.validate()...)
+	}
+	for name := range a.Headers {
+		if name == "" || http.CanonicalHeaderKey(name) == "" || strings.ContainsAny(name, " :\r\n") {
+			errs = append(errs, fmt.Errorf("header name %q is invalid", name))
+		}
+	}
+	return errs
+}
+
+// exclusive checks that at most one of inline value and file is set, one of them is required if required is true
+func exclusive(name, value, fileName, file string, required bool) []error {
+	switch {
+	case value != "" && file != "":
+		return []error{fmt.Errorf("only one of %s and %s may be set", name, fileName)}
+	case required && value == "" && file == "":
+		return []error{fmt.Errorf("%s or %s is required", name, fileName)}
+	}
+	return nil
+}
+
+func (rl *ConfigRateLimiter) validate() []error {
+	var errs []error
+	check := func(name string, b ConfigRateLimitBudget) {
+		if b.RequestsPerSecond < 0 || b.Burst < 0 {
+			errs = append(errs, fmt.Errorf("rate_limiter.%s must not be negative", name))
+		}
+	}
+	check("global", rl.Global)
+	for endpoint, b := range rl.Endpoints {
+		check("endpoints."+endpoint, b)
+	}
+	for method, b := range rl.Methods {
+		check("methods."+method, b)
+	}
+	return errs
+}
+
+func (r *ConfigRetry) validate() []error {
+	var errs []error
+	if r.MaxAttempts < 0 {
+		errs = append(errs, errors.New("retry.max_attempts must not be negative"))
+	}
+	if r.InitialBackoff < 0 || r.MaxBackoff < 0 {
+		errs = append(errs, errors.New("retry backoff must not be negative"))
+	}
+	if r.Multiplier != 0 && r.Multiplier < 1 {
+		errs = append(errs, errors.New("retry.multiplier must be at least 1"))
+	}
+	if r.Jitter != nil && (*r.Jitter < 0 || *r.Jitter > 1) {
+		errs = append(errs, errors.New("retry.jitter must be between 0 and 1"))
+	}
+	for _, code := range r.RetryableStatusCodes {
+		if code < 100 || code > 599 {
+			errs = append(errs, fmt.Errorf("retry.retryable_status_codes: %d is not a http status", code))
+		}
+	}
+	return errs
+}
+
+func (cb *ConfigCircuitBreaker) validate() []error {
+	var errs []error
+	if cb.FailureThreshold < 0 || cb.OpenTimeout < 0 || cb.ProbeTimeout < 0 {
+		errs = append(errs, errors.New("circuit_breaker settings must not be negative"))
+	}
+	for _, code := range cb.FailureStatuses {
+		if code < 100 || code > 599 {
+			errs = append(errs, fmt.Errorf("circuit_breaker.failure_statuses: %d is not a http status", code))
+		}
+	}
+	return errs
+}
+
+// policy returns api.DefaultRetryPolicy with fields which are set in the config
+func (r *ConfigRetry) policy() *api.RetryPolicy {
+	p := api.DefaultRetryPolicy()
+	if r.MaxAttempts != 0 {
+		p.MaxAttempts = r.MaxAttempts
+	}
+	if r.InitialBackoff != 0 {
+		p.InitialBackoff = r.InitialBackoff
+	}
+	if r.MaxBackoff != 0 {
+		p.MaxBackoff = r.MaxBackoff
+	}
+	if r.Multiplier != 0 {
+		p.Multiplier = r.Multiplier
+	}
+	if r.Jitter != nil {
+		p.Jitter = *r.Jitter
+	}
+	if r.RetryableStatusCodes != nil {
+		p.RetryableStatusCodes = r.RetryableStatusCodes
+	}
+	if r.RetryOnNetworkErrors != nil {
+		p.RetryOnNetworkErrors = *r.RetryOnNetworkErrors
+	}
+	p.RetryNonIdempotent = r.RetryNonIdempotent
+	return p
+}
+
+func (rl *ConfigRateLimiter) config() *api.RateLimiterConfig {
+	budgets := func(m map[string]ConfigRateLimitBudget) map[string]api.RateLimitBudget {
+		if m == nil {
+			return nil
+		}
+		res := make(map[string]api.RateLimitBudget, len(m))
+		for k, b := range m {
+			res[k] = api.RateLimitBudget(b)
+		}
+		return res
+	}
+	return &api.RateLimiterConfig{
+		Global:        api.RateLimitBudget(rl.Global),
+		Endpoints:     budgets(rl.Endpoints),
+		Methods:       budgets(rl.Methods),
+		StatsInterval: rl.StatsInterval,
+	}
+}
+
+// Options returns client options described by the array config
+func (a *ConfigArray) Options() *ClientOptions {
+	options := NewClientOptions().SetInsecure(a.TLS.Insecure)
+	if a.Timeout != 0 {
+		options.SetDefaultTimeout(a.Timeout)
+	}
+	if a.RateLimit != nil {
+		options.SetRateLimit(*a.RateLimit)
+	}
+	if a.RateLimiter != nil {
+		options.SetRateLimiter(a.RateLimiter.config())
+	}
+	if a.RequestIDKey != "" {
+		options.SetRequestIDKey(api.ContextKey(a.RequestIDKey))
+	}
+	if a.Retry != nil {
+		options.SetRetryPolicy(a.Retry.policy())
+	}
+	if a.CircuitBreaker != nil {
+		options.SetCircuitBreaker(&api.CircuitBreakerConfig{
+			FailureThreshold: a.CircuitBreaker.FailureThreshold,
+			OpenTimeout:      a.CircuitBreaker.OpenTimeout,
+			ProbeTimeout:     a.CircuitBreaker.ProbeTimeout,
+			FailureStatuses:  a.CircuitBreaker.FailureStatuses,
+		})
+	}
+	if a.TLS.CA != "" {
+		options.SetCACertificates([]byte(a.TLS.CA))
+	}
+	if a.TLS.CAFile != "" {
+		options.SetCACertificatesFile(a.TLS.CAFile)
+	}
+	if a.TLS.ClientCert != "" {
+		options.SetClientCertificate([]byte(a.TLS.ClientCert), []byte(a.TLS.ClientKey))
+	}
+	if a.TLS.ClientCertFile != "" {
+		options.SetClientCertificateFiles(a.TLS.ClientCertFile, a.TLS.ClientKeyFile)
+	}
+	if a.Proxy != "" {
+		options.SetProxy(a.Proxy)
+	}
+	return options
+}
+
+// credentialProvider returns provider which reads secret files on every login, nil if no files are used
+func (a *ConfigArray) credentialProvider() api.CredentialProvider {
+	if a.UsernameFile == "" && a.PasswordFile == "" {
+		return nil
+	}
+	return &api.FileCredentials{
+		UsernameFile: a.UsernameFile,
+		PasswordFile: a.PasswordFile,
+		Username:     a.Username,
+		Password:     a.Password,
+	}
+}
+
+// ArrayConfig returns registry config of the array, secret files are read on every login,
+// so rotated credentials are picked up without rebuilding the client
+func (a *ConfigArray) ArrayConfig() ArrayConfig {
+	options := a.Options()
+	if provider := a.credentialProvider(); provider != nil {
+		options.SetCredentialProvider(provider)
+	}
+	return ArrayConfig{
+		Name:      a.name(),
+		Endpoint:  strings.Join(a.endpoints(), ","),
+		GlobalID:  a.GlobalID,
+		Username:  a.Username,
+		Password:  a.Password,
+		IsDefault: a.Default,
+		Options:   options,
+	}
+}
+
+// NewClient builds client of the array
+func (a *ConfigArray) NewClient() (Client, error) {
+	return a.newClient(a.ArrayConfig())
+}
+
+func (a *ConfigArray) newClient(cfg ArrayConfig) (Client, error) {
+	client, err := NewClientWithArgs(cfg.Endpoint, cfg.Username, cfg.Password, cfg.Options)
+	if err != nil {
+		return nil, err
+	}
+	if len(a.Headers) > 0 {
+		headers := http.Header{}
+		for name, value := range a.Headers {
+			headers.Set(name, value)
+		}
+		client.SetCustomHTTPHeaders(headers)
+	}
+	return client, nil
+}
+
+// NewClients builds clients of all arrays, the result is keyed by array name
+func (c *Config) NewClients() (map[string]Client, error) {
+	clients := make(map[string]Client, len(c.Arrays))
+	for i := range c.Arrays {
+		client, err := c.Arrays[i].NewClient()
+		if err != nil {
+			return nil, fmt.Errorf("array %s: %w", c.Arrays[i].name(), err)
+		}
+		clients[c.Arrays[i].name()] = client
+	}
+	return clients, nil
+}
+
+// NewRegistry builds clients of all arrays and registers them in a ClientRegistry
+func (c *Config) NewRegistry() (*ClientRegistry, error) {
+	configs := make([]ArrayConfig, 0, len(c.Arrays))
+	for i := range c.Arrays {
+		cfg := c.Arrays[i].ArrayConfig()
+		var err error
+		if cfg.Client, err = c.Arrays[i].newClient(cfg); err != nil {
+			return nil, fmt.Errorf("array %s: %w", c.Arrays[i].name(), err)
+		}
+		configs = append(configs, cfg)
+	}
+	return NewClientRegistry(configs)
+}
+
+// DefaultArray returns the array marked as default or the only configured one
+func (c *Config) DefaultArray() (*ConfigArray, error) {
+	for i := range c.Arrays {
+		if c.Arrays[i].Default {
+			return &c.Arrays[i], nil
+		}
+	}
+	if len(c.Arrays) == 1 {
+		return &c.Arrays[0], nil
+	}
+	return nil, ErrNoDefaultArray
+}
@@ -0,0 +1,248 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package gopowerstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dell/gopowerstore/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfigYAML = `
+defaults:
+  timeout: 30s
+  rate_limit: ${TEST_RATE_LIMIT}
+  tls:
+    insecure: true
+  headers:
+    X-Tenant: tenant-1
+arrays:
+  - name: primary
+    endpoints:
+      - https://10.0.0.1/api/rest
+      - https://10.0.0.2/api/rest
+    global_id: PS000000000001
+    username: ${TEST_USERNAME:-admin}
+    password_file: ${TEST_SECRETS}/password
+    default: true
+    retry:
+      max_attempts: 5
+      jitter: 0
+    circuit_breaker:
+      failure_threshold: 3
+      open_timeout: 1m
+      failure_statuses: [503]
+    rate_limiter:
+      global:
+        requests_per_second: 20
+      endpoints:
+        metrics:
+          requests_per_second: 1
+          burst: 2
+  - endpoint: https://10.0.0.3/api/rest
+    username: operator
+    password: "pa$$word"
+    timeout: 1m
+    request_id_key: trace.id
+    tls:
+      ca: ${TEST_CA:-}
+`
+
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestParseConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "password", "secret\n")
+	t.Setenv("TEST_RATE_LIMIT", "15")
+	t.Setenv("TEST_SECRETS", dir)
+
+	cfg, err := ParseConfig([]byte(testConfigYAML))
+	require.NoError(t, err)
+	require.Len(t, cfg.Arrays, 2)
+
+	primary := cfg.Arrays[0]
+	assert.Equal(t, "admin", primary.Username)
+	assert.Equal(t, 30*time.Second, primary.Timeout)
+	assert.Equal(t, 15, *primary.RateLimit)
+	assert.True(t, primary.TLS.Insecure)
+	assert.Equal(t, map[string]string{"X-Tenant": "tenant-1"}, primary.Headers)
+
+	arrayCfg := primary.ArrayConfig()
+	assert.Equal(t, "primary", arrayCfg.Name)
+	assert.Equal(t, "https://10.0.0.1/api/rest,https://10.0.0.2/api/rest", arrayCfg.Endpoint)
+	creds, err := arrayCfg.Options.CredentialProvider().Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, api.Credentials{Username: "admin", Password: "secret"}, creds)
+	assert.True(t, arrayCfg.IsDefault)
+	options := arrayCfg.Options
+	assert.Equal(t, 30*time.Second, options.DefaultTimeout())
+	assert.Equal(t, 15, options.RateLimit())
+	assert.True(t, options.Insecure())
+	assert.Equal(t, 5, options.RetryPolicy().MaxAttempts)
+	assert.Zero(t, options.RetryPolicy().Jitter)
+	assert.Equal(t, api.DefaultRetryPolicy().RetryableStatusCodes, options.RetryPolicy().RetryableStatusCodes)
+	assert.Equal(t, api.RateLimitBudget{RequestsPerSecond: 1, Burst: 2}, options.RateLimiter().Endpoints["metrics"])
+	assert.Equal(t, &api.CircuitBreakerConfig{
+		FailureThreshold: 3, OpenTimeout: time.Minute, FailureStatuses: []int{503},
+	}, options.CircuitBreaker())
+
+	secondary := cfg.Arrays[1]
+	arrayCfg = secondary.ArrayConfig()
+	assert.Nil(t, arrayCfg.Options.CredentialProvider())
+	assert.Equal(t, "https://10.0.0.3/api/rest", arrayCfg.Name)
+	assert.Equal(t, "pa$word", arrayCfg.Password)
+	assert.Equal(t, time.Minute, arrayCfg.Options.DefaultTimeout())
+	assert.Equal(t, api.ContextKey("trace.id"), arrayCfg.Options.RequestIDKey())
+	assert.Nil(t, arrayCfg.Options.RetryPolicy())
+	assert.Nil(t, arrayCfg.Options.CircuitBreaker())
+	assert.True(t, arrayCfg.Options.Insecure())
+
+	defaultArray, err := cfg.DefaultArray()
+	require.NoError(t, err)
+	assert.Equal(t, "primary", defaultArray.Name)
+}
+
+func TestParseConfig_JSON(t *testing.T) {
+	cfg, err := ParseConfig([]byte("{\n\t\"arrays\": [\n\t\t{\"endpoint\": \"https://a/api/rest\", " +
+		"\"username\": \"admin\", \"password\": \"password\", \"rate_limit\": 0, \"timeout\": \"5s\"}\n\t]\n}"))
+	require.NoError(t, err)
+	require.Len(t, cfg.Arrays, 1)
+	assert.Equal(t, 0, *cfg.Arrays[0].RateLimit)
+	assert.Equal(t, 5*time.Second, cfg.Arrays[0].Timeout)
+}
+
+func TestParseConfig_Errors(t *testing.T) {
+	tests := map[string]struct {
+		config string
+		errors []string
+	}{
+		"unknown top level field": {
+			config: "array: []",
+			errors: []string{"field array not found"},
+		},
+		"unknown array field": {
+			config: "arrays: [{endpoint: https://a/api/rest, username: a, password: p, pasword: p}]",
+			errors: []string{"arrays[0]: ", "field pasword not found"},
+		},
+		"missing env variable": {
+			config: "arrays: [{endpoint: https://a/api/rest, username: a, password: '${TEST_MISSING_PASSWORD}'}]",
+			errors: []string{"arrays[0]: line 1: env variable TEST_MISSING_PASSWORD is not set"},
+		},
+		"no arrays": {
+			config: "defaults: {timeout: 1s}",
+			errors: []string{"no arrays are configured"},
+		},
+		"invalid arrays": {
+			config: `
+arrays:
+  - name: a
+    endpoint: 10.0.0.1
+    username: admin
+    password: p
+    password_file: /secret
+    default: true
+  - name: a
+    username_file: /user
+    password: p
+    default: true
+    timeout: -1s
+    tls: {client_cert: cert}
+    retry: {jitter: 2, retryable_status_codes: [42]}
+    circuit_breaker: {open_timeout: -1s, failure_statuses: [5000]}
+`,
+			errors: []string{
+				`arrays[0] (a): endpoint "10.0.0.1" is not a http(s) URL`,
+				"arrays[0] (a): only one of password and password_file may be set",
+				"arrays[1] (a): name is already used by arrays[0]",
+				"arrays[1] (a): arrays[0] is already the default one",
+				"arrays[1] (a): endpoint is required",
+				"arrays[1] (a): timeout must not be negative",
+				"arrays[1] (a): tls client certificate and key must be set together",
+				"arrays[1] (a): retry.jitter must be between 0 and 1",
+				"arrays[1] (a): retry.retryable_status_codes: 42 is not a http status",
+				"arrays[1] (a): circuit_breaker settings must not be negative",
+				"arrays[1] (a): circuit_breaker.failure_statuses: 5000 is not a http status",
+			},
+		},
+		"invalid type": {
+			config: "arrays: [{endpoint: https://a/api/rest, username: a, password: p, rate_limit: many}]",
+			errors: []string{"arrays[0]: ", "cannot unmarshal !!str `many` into int"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(tc.config))
+			require.Error(t, err)
+			for _, msg := range tc.errors {
+				assert.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}
+
+func TestConfig_NewClients(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "password", "secret\n")
+	t.Setenv("TEST_RATE_LIMIT", "15")
+	t.Setenv("TEST_SECRETS", dir)
+	cfg, err := LoadConfig(writeTestFile(t, dir, "config.yaml", testConfigYAML))
+	require.NoError(t, err)
+
+	clients, err := cfg.NewClients()
+	require.NoError(t, err)
+	require.Len(t, clients, 2)
+	assert.Equal(t, "tenant-1", clients["primary"].GetCustomHTTPHeaders().Get("X-Tenant"))
+
+	registry, err := cfg.NewRegistry()
+	require.NoError(t, err)
+	status, err := registry.Status("PS000000000001")
+	require.NoError(t, err)
+	assert.Equal(t, "primary", status.Name)
+	assert.True(t, status.IsDefault)
+
+	// secret files are read on login, so the client is created even if they are missing
+	require.NoError(t, os.Remove(filepath.Join(dir, "password")))
+	_, err = cfg.NewClients()
+	assert.NoError(t, err)
+
+	_, err = LoadConfig(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read config")
+}
+
+func TestNewClient_ConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(ConfigFileEnv, writeTestFile(t, dir, "config.json",
+		`{"arrays": [{"endpoint": "https://a/api/rest", "username": "admin", "password": "password"}]}`))
+	_, err := NewClient()
+	assert.NoError(t, err)
+
+	t.Setenv(ConfigFileEnv, writeTestFile(t, dir, "invalid.yaml", "arrays: [{endpoint: https://a/api/rest}]"))
+	_, err = NewClient()
+	assert.ErrorContains(t, err, "username or username_file is required")
+}
@@ -37,36 +37,17 @@ func getFCPortDefaultQueryParams(c Client) api.QueryParamsEncoder {
 func (c *ClientIMPL) GetFCPorts(
 	ctx context.Context,
 ) (resp []FcPort, err error) {
-	err = c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []FcPort
-		qp := getFCPortDefaultQueryParams(c)
-
-		majorMinorVersion, err := c.GetSoftwareMajorMinorVersion(ctx)
-		if err != nil {
-			log.Errorf("Couldn't find the array version %s", err.Error())
-		} else {
-			if majorMinorVersion >= 3.0 {
-				qp.Select("wwn_nvme,wwn_node")
-			}
-		}
-		qp.Limit(paginationDefaultPageSize)
-		qp.Offset(offset)
-		qp.Order("id")
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    apiFCPortURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			resp = append(resp, page...)
-		}
-		return meta, err
+	arrayVersion, err := c.GetArrayVersion(ctx)
+	if err != nil {
+		log.Errorf("Couldn't find the array version %s", err.Error())
+	}
+	qp := c.APIClient().QueryParams().Select((&FcPort{}).FieldsForVersion(arrayVersion)...)
+	qp.Order("id")
+	return readPaginatedData[FcPort](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    apiFCPortURL,
+		QueryParams: qp,
 	})
-	return resp, err
 }
 
 // GetFCPort get FC port by id
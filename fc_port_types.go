@@ -18,6 +18,8 @@
 
 package gopowerstore
 
+import "github.com/dell/gopowerstore/api"
+
 // FcPort This resource type has queriable associations from appliance, hardware, hardware, hardware, fc_port
 type FcPort struct {
 	ApplianceID string `json:"appliance_id,omitempty"`
@@ -49,9 +51,9 @@ type FcPort struct {
 	// World Wide Name (WWN) of the port.
 	Wwn string `json:"wwn,omitempty"`
 	// World Wide Name (WWN) of NVME port
-	WwnNVMe string `json:"wwn_nvme,omitempty"`
+	WwnNVMe string `json:"wwn_nvme,omitempty" select:"min=3.0"`
 	// World Wide Name (WWN) of the Node of the port.
-	WwnNode string `json:"wwn_node,omitempty"`
+	WwnNode string `json:"wwn_node,omitempty" select:"min=3.0"`
 }
 
 // FcPortSpeedEnum Possible Fibre Channel port speeds. For the current_speed attribute, these values show the current transmission speed on the port.
@@ -98,3 +100,11 @@ func (h *FcPort) Fields() []string {
 		"port_index", "requested_speed", "sfp_id", "supported_speeds", "wwn",
 	}
 }
+
+// FieldsForVersion returns fields which the array version supports, Fields if the version is unknown
+func (h *FcPort) FieldsForVersion(version api.ArrayVersion) []string {
+	if version.IsZero() {
+		return h.Fields()
+	}
+	return api.SelectFieldsForArrayVersion(h, version)
+}
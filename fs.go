@@ -27,10 +27,8 @@ import (
 )
 
 const (
-	nasURL              = "nas_server"
-	fsURL               = "file_system"
-	jobsURL             = "job"
-	jobStatusInProgress = "IN_PROGRESS"
+	nasURL = "nas_server"
+	fsURL  = "file_system"
 )
 
 func getNASDefaultQueryParams(c Client) api.QueryParamsEncoder {
@@ -48,42 +46,17 @@ func getNfsServerDefaultQueryParams(c Client) api.QueryParamsEncoder {
 	return c.APIClient().QueryParamsWithFields(&nfsServer)
 }
 
-func getJobDefaultQueryParams(c Client) api.QueryParamsEncoder {
-	job := Job{}
-	return c.APIClient().QueryParamsWithFields(&job)
-}
-
 // GetNASServers query and return all NAS servers
 func (c *ClientIMPL) GetNASServers(ctx context.Context) ([]NAS, error) {
-	var result []NAS
-	var qp api.QueryParamsEncoder
-	var fields []string
-	err := c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []NAS
-		arrayVerion, err := c.GetSoftwareMajorMinorVersion(ctx)
-		if err != nil {
-			log.Errorf("Couldn't find the array version %s", err.Error())
-		}
-
-		fields = GetNASFields(arrayVerion)
-		qp = c.APIClient().QueryParams().Select(fields...)
-
-		qp.Offset(offset).Limit(paginationDefaultPageSize)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    nasURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			result = append(result, page...)
-		}
-		return meta, err
+	arrayVersion, err := c.GetArrayVersion(ctx)
+	if err != nil {
+		log.Errorf("Couldn't find the array version %s", err.Error())
+	}
+	return readPaginatedData[NAS](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    nasURL,
+		QueryParams: c.APIClient().QueryParams().Select(GetNASFieldsForVersion(arrayVersion)...),
 	})
-	return result, err
 }
 
 // GetNASByName query and return specific NAS by name
@@ -91,12 +64,12 @@ func (c *ClientIMPL) GetNASByName(ctx context.Context, name string) (resp NAS, e
 	var nasList []NAS
 	var qp api.QueryParamsEncoder
 	var fields []string
-	arrayVerion, err := c.GetSoftwareMajorMinorVersion(ctx)
+	arrayVersion, err := c.GetArrayVersion(ctx)
 	if err != nil {
 		log.Errorf("Couldn't find the array version %s", err.Error())
 	}
 
-	fields = GetNASFields(arrayVerion)
+	fields = GetNASFieldsForVersion(arrayVersion)
 	qp = c.APIClient().QueryParams().Select(fields...)
 
 	qp.RawArg("name", fmt.Sprintf("eq.%s", name))
@@ -122,12 +95,12 @@ func (c *ClientIMPL) GetNASByName(ctx context.Context, name string) (resp NAS, e
 func (c *ClientIMPL) GetNAS(ctx context.Context, id string) (resp NAS, err error) {
 	var qp api.QueryParamsEncoder
 	var fields []string
-	arrayVerion, err := c.GetSoftwareMajorMinorVersion(ctx)
+	arrayVersion, err := c.GetArrayVersion(ctx)
 	if err != nil {
 		log.Errorf("Couldn't find the array version %s", err.Error())
 	}
 
-	fields = GetNASFields(arrayVerion)
+	fields = GetNASFieldsForVersion(arrayVersion)
 	qp = c.APIClient().QueryParams().Select(fields...)
 
 	_, err = c.APIClient().Query(
@@ -184,49 +157,23 @@ func (c *ClientIMPL) DeleteNAS(ctx context.Context, id string) (resp EmptyRespon
 
 // ListFS returns a list of Filesystems
 func (c *ClientIMPL) ListFS(ctx context.Context) (resp []FileSystem, err error) {
-	err = c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []FileSystem
-		qp := getFSDefaultQueryParams(c)
-		qp.RawArg("filesystem_type", fmt.Sprintf("not.eq.%s", FileSystemTypeEnumSnapshot))
-		qp.Order("name")
-		qp.Offset(offset).Limit(paginationDefaultPageSize)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    fsURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			resp = append(resp, page...)
-		}
-		return meta, err
+	qp := getFSDefaultQueryParams(c)
+	qp.RawArg("filesystem_type", fmt.Sprintf("not.eq.%s", FileSystemTypeEnumSnapshot))
+	qp.Order("name")
+	return readPaginatedData[FileSystem](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    fsURL,
+		QueryParams: qp,
 	})
-	return resp, err
 }
 
 // GetInProgressJobsByFsName query and return all jobs that are in progress by name of the filesystem involved
 func (c *ClientIMPL) GetInProgressJobsByFsName(ctx context.Context, name string) (resp []Job, err error) {
-	var jobList []Job
-	qp := getJobDefaultQueryParams(c)
-	qp.RawArg("resource_name", fmt.Sprintf("eq.%s", name))
-	qp.RawArg("state", fmt.Sprintf("eq.%s", jobStatusInProgress))
-	qp.RawArg("resource_type", "eq.file_system")
-	_, err = c.APIClient().Query(
-		ctx,
-		RequestConfig{
-			Method:      "GET",
-			Endpoint:    jobsURL,
-			QueryParams: qp,
-		},
-		&jobList)
-	err = WrapErr(err)
-	if err != nil {
-		return resp, err
-	}
-	return jobList, err
+	return c.ListJobs(ctx, map[string]string{
+		"resource_name": fmt.Sprintf("eq.%s", name),
+		"state":         fmt.Sprintf("eq.%s", jobStatusInProgress),
+		"resource_type": "eq.file_system",
+	})
 }
 
 // GetFSByName query and return specific FS by name
@@ -332,55 +279,27 @@ func (c *ClientIMPL) GetFsSnapshot(ctx context.Context, snapID string) (resVol F
 
 // GetFsSnapshots returns all fs snapshots
 func (c *ClientIMPL) GetFsSnapshots(ctx context.Context) ([]FileSystem, error) {
-	var result []FileSystem
-	err := c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []FileSystem
-		qp := getFSDefaultQueryParams(c)
-		qp.RawArg("filesystem_type", fmt.Sprintf("eq.%s", FileSystemTypeEnumSnapshot))
-		qp.Order("name")
-		qp.Offset(offset).Limit(paginationDefaultPageSize)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    fsURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			result = append(result, page...)
-		}
-		return meta, err
+	qp := getFSDefaultQueryParams(c)
+	qp.RawArg("filesystem_type", fmt.Sprintf("eq.%s", FileSystemTypeEnumSnapshot))
+	qp.Order("name")
+	return readPaginatedData[FileSystem](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    fsURL,
+		QueryParams: qp,
 	})
-	return result, err
 }
 
 // GetFsSnapshotsByVolumeID returns a list of fs snapshots for specific volume
 func (c *ClientIMPL) GetFsSnapshotsByVolumeID(ctx context.Context, volID string) ([]FileSystem, error) {
-	var result []FileSystem
-	err := c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []FileSystem
-		qp := getFSDefaultQueryParams(c)
-		qp.RawArg("parent_id", fmt.Sprintf("eq.%s", volID))
-		qp.RawArg("filesystem_type", fmt.Sprintf("eq.%s", FileSystemTypeEnumSnapshot))
-		qp.Order("name")
-		qp.Offset(offset).Limit(paginationDefaultPageSize)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    fsURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			result = append(result, page...)
-		}
-		return meta, err
+	qp := getFSDefaultQueryParams(c)
+	qp.RawArg("parent_id", fmt.Sprintf("eq.%s", volID))
+	qp.RawArg("filesystem_type", fmt.Sprintf("eq.%s", FileSystemTypeEnumSnapshot))
+	qp.Order("name")
+	return readPaginatedData[FileSystem](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    fsURL,
+		QueryParams: qp,
 	})
-	return result, err
 }
 
 func (c *ClientIMPL) ModifyFS(ctx context.Context,
@@ -433,38 +352,26 @@ func (c *ClientIMPL) CloneFS(ctx context.Context,
 }
 
 func (c *ClientIMPL) GetFsByFilter(ctx context.Context, filter map[string]string) ([]FileSystem, error) {
-	var result []FileSystem
-	err := c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []FileSystem
-		qp := getFSDefaultQueryParams(c)
-		for k, v := range filter {
-			qp.RawArg(k, v)
-		}
-		qp.Offset(offset).Limit(paginationDefaultPageSize)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    fsURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			result = append(result, page...)
-		}
-		return meta, err
+	return c.GetFsByFilters(ctx, api.RawFilters(filter)...)
+}
+
+// GetFsByFilters query and return all filesystems matching filters
+func (c *ClientIMPL) GetFsByFilters(ctx context.Context, filters ...api.Filter) ([]FileSystem, error) {
+	return readPaginatedData[FileSystem](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    fsURL,
+		QueryParams: getFSDefaultQueryParams(c).Filter(filters...),
 	})
-	return result, err
 }
 
+// GetNASFields returns fields of NAS which the major.minor array version supports
+//
+// Deprecated: use GetNASFieldsForVersion
 func GetNASFields(arrayVerion float32) []string {
-	var fields []string
-	fields = []string{"id", "description", "name", "current_node_id", "operational_status", "current_preferred_IPv4_interface_id", "current_preferred_IPv6_interface_id", "nfs_servers(id,is_nfsv3_enabled,is_nfsv4_enabled)", "file_systems", "health_details", "preferred_node_id", "default_unix_user", "default_windows_user", "current_unix_directory_service", "is_username_translation_enabled", "is_auto_user_mapping_enabled", "production_IPv4_interface_id", "production_IPv6_interface_id", "backup_IPv4_interface_id", "backup_IPv6_interface_id", "protection_policy_id", "file_events_publishing_mode", "is_replication_destination", "is_production_mode_enabled", "operational_status_l10n", "current_unix_directory_service_l10n", "file_events_publishing_mode_l10n"}
-
-	if arrayVerion > 3.6 {
-		fields = append(fields, "is_dr_test")
-	}
+	return GetNASFieldsForVersion(api.ArrayVersionFromFloat(arrayVerion))
+}
 
-	return fields
+// GetNASFieldsForVersion returns fields of NAS which the array version supports
+func GetNASFieldsForVersion(version api.ArrayVersion) []string {
+	return api.SelectFieldsForArrayVersion(&NAS{}, version)
 }
@@ -21,8 +21,10 @@ package gopowerstore
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/dell/gopowerstore/api"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
 )
@@ -319,11 +321,30 @@ func TestClientIMPL_GetFsByFilter(t *testing.T) {
 	assert.Equal(t, fsID, resp[0].ID)
 }
 
+func TestClientIMPL_GetFsByFilters(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", fsMockURL,
+		func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			assert.Equal(t, []string{"gte.1024", "lte.4096"}, q["size_total"])
+			assert.Equal(t, "(name.like.csi-*,name.eq.\"a,b\")", q.Get("or"))
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`[{"id": "%s"}]`, fsID)), nil
+		})
+	resp, err := C.GetFsByFilters(context.Background(),
+		api.Ge("size_total", 1024), api.Le("size_total", 4096),
+		api.Or(api.Like("name", "csi-*"), api.Eq("name", "a,b")))
+	assert.Nil(t, err)
+	assert.Equal(t, fsID, resp[0].ID)
+}
+
 func Test_GetNASFields(t *testing.T) {
 	fields := GetNASFields(3.7)
-	assert.NotEmpty(t, fields)
+	assert.Contains(t, fields, "is_dr_test")
+	assert.Contains(t, fields, "nfs_servers(id,is_nfsv3_enabled,is_nfsv4_enabled)")
 	fields = GetNASFields(3.5)
 	assert.NotEmpty(t, fields)
+	assert.NotContains(t, fields, "is_dr_test")
 }
 
 func Test_NASServersErr(t *testing.T) {
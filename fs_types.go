@@ -177,14 +177,6 @@ func (fc *FsClone) MetaData() http.Header {
 	return fc.metadata
 }
 
-type Job struct {
-	ID           string `json:"id,omitempty"`
-	Action       string `json:"resource_action,omitempty"`
-	Type         string `json:"resource_type,omitempty"`
-	ResourceName string `json:"resource_name,omitempty"`
-	State        string `json:"state,omitempty"`
-}
-
 // Details about the FileSystem
 type FileSystem struct {
 	// File system id
@@ -284,7 +276,7 @@ type NAS struct {
 	// IPv6 file interface id nas server currently uses
 	CurrentPreferredIPv6InterfaceID string `json:"current_preferred_IPv6_interface_id,omitempty"`
 	// NfsServers define NFS server instance if nfs exports are present
-	NfsServers []NFSServerInstance `json:"nfs_servers"`
+	NfsServers []NFSServerInstance `json:"nfs_servers" select:"embed"`
 	// FileSystems define file system instance that are present on the NAS server
 	FileSystems []FileSystem `json:"file_systems"`
 	// HealthDetails represent health details of the NAS server
@@ -318,7 +310,7 @@ type NAS struct {
 	// Whether production mode is enabled.
 	IsProductionModeEnabled bool `json:"is_production_mode_enabled,omitempty"`
 	// Indicates if the NAS is in DR Test mode.
-	IsDRTest bool `json:"is_dr_test,omitempty"`
+	IsDRTest bool `json:"is_dr_test,omitempty" select:"min=3.7"`
 	// Localized operational status of the NAS server.
 	OperationalStatusL10n string `json:"operational_status_l10n,omitempty"`
 	// Localized Unix directory service of the NAS server.
@@ -332,6 +324,14 @@ func (n *NAS) Fields() []string {
 	return []string{"id", "description", "name", "current_node_id", "operational_status", "current_preferred_IPv4_interface_id", "current_preferred_IPv6_interface_id", "nfs_servers", "file_systems", "health_details", "preferred_node_id", "default_unix_user", "default_windows_user", "current_unix_directory_service", "is_username_translation_enabled", "is_auto_user_mapping_enabled", "production_IPv4_interface_id", "production_IPv6_interface_id", "backup_IPv4_interface_id", "backup_IPv6_interface_id", "protection_policy_id", "file_events_publishing_mode", "is_replication_destination", "is_production_mode_enabled", "is_dr_test", "operational_status_l10n", "current_unix_directory_service_l10n", "file_events_publishing_mode_l10n"}
 }
 
+// FieldsForVersion returns fields which the array version supports, Fields if the version is unknown
+func (n *NAS) FieldsForVersion(version api.ArrayVersion) []string {
+	if version.IsZero() {
+		return n.Fields()
+	}
+	return api.SelectFieldsForArrayVersion(n, version)
+}
+
 // Fields returns fields which must be requested to fill struct
 func (n *FileSystem) Fields() []string {
 	return []string{"description", "id", "name", "nas_server_id", "filesystem_type", "size_total", "size_used", "parent_id", "expiration_timestamp", "access_type", "config_type", "access_policy", "locking_policy", "folder_rename_policy", "is_async_MTime_enabled", "protection_policy_id", "file_events_publishing_mode", "host_io_size", "flr_attributes", "is_smb_sync_writes_enabled", "is_smb_no_notify_enabled", "is_smb_op_locks_enabled", "is_smb_notify_on_access_enabled", "is_smb_notify_on_write_enabled", "smb_notify_on_change_dir_depth", "is_quota_enabled", "grace_period", "default_hard_limit", "default_soft_limit", "creation_timestamp", "last_refresh_timestamp", "last_writable_timestamp", "is_modified", "creator_type"}
@@ -340,8 +340,3 @@ func (n *FileSystem) Fields() []string {
 func (n *NFSServerInstance) Fields() []string {
 	return []string{"id", "is_nfsv3_enabled", "is_nfsv4_enabled"}
 }
-
-// Fields returns fields which must be requested to fill struct
-func (j *Job) Fields() []string {
-	return []string{"id", "resource_action", "resource_type", "resource_name", "state"}
-}
@@ -1,6 +1,6 @@
 /*
  *
- * Copyright © 2020-2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+ * Copyright © 2020-2025 Dell Inc. or its subsidiaries. All Rights Reserved.
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -20,8 +20,6 @@ package gopowerstore
 
 import (
 	"net/http"
-	"regexp"
-	"strings"
 
 	"github.com/dell/gopowerstore/api"
 )
@@ -29,8 +27,15 @@ import (
 // RequestConfig represents options for request
 type RequestConfig api.RequestConfig
 
-// naslimitRegex is used to check if the error message contains a limit of file systems for the NAS server
-var naslimitRegex = regexp.MustCompile(`limit of \d+ file systems for the NAS server`)
+// visibilityHeader controls access to PowerStore endpoints which are hidden by default
+const visibilityHeader = "DELL-VISIBILITY"
+
+// visibilityHeaders returns per-request headers which expose endpoints of the given visibility level
+func visibilityHeaders(visibility string) http.Header {
+	h := http.Header{}
+	h.Set(visibilityHeader, visibility)
+	return h
+}
 
 // RenderRequestConfig returns internal struct with request config
 func (rc RequestConfig) RenderRequestConfig() api.RequestConfig {
@@ -46,6 +51,22 @@ type CreateResponse struct {
 // EmptyResponse is response without content
 type EmptyResponse string
 
+// Sentinel errors which APIError matches with errors.Is, see api.ErrorCondition
+var (
+	ErrNotFound                        = api.ErrNotFound
+	ErrBadRange                        = api.ErrBadRange
+	ErrNameAlreadyInUse                = api.ErrNameAlreadyInUse
+	ErrHostNotAttachedToVolume         = api.ErrHostNotAttachedToVolume
+	ErrVolumeAttachedToHost            = api.ErrVolumeAttachedToHost
+	ErrVolumeDetachedFromHost          = api.ErrVolumeDetachedFromHost
+	ErrHostAlreadyRemovedFromNFSExport = api.ErrHostAlreadyRemovedFromNFSExport
+	ErrHostAlreadyPresentInNFSExport   = api.ErrHostAlreadyPresentInNFSExport
+	ErrFailoverFromDestination         = api.ErrFailoverFromDestination
+	ErrReplicationSessionExists        = api.ErrReplicationSessionExists
+	ErrVolumeNotInVolumeGroup          = api.ErrVolumeNotInVolumeGroup
+	ErrFSCreationLimitReached          = api.ErrFSCreationLimitReached
+)
+
 // APIError represents API error
 type APIError struct {
 	*api.ErrorMsg
@@ -65,88 +86,104 @@ func WrapErr(err error) error {
 	return err
 }
 
+// Unwrap returns internal error, so errors.As can extract *api.ErrorMsg
+func (err APIError) Unwrap() error {
+	return err.ErrorMsg
+}
+
+// matches returns true if the error has condition. Conditions without a known error code are classified
+// by message_l10n, which is localized, so unclassified errors are matched by the fallback check of http status,
+// the predicate used before the conditions were introduced.
+func (err *APIError) matches(condition *api.ErrorCondition, fallback bool) bool {
+	if err.HasCondition(condition) {
+		return true
+	}
+	return fallback && !err.Classified()
+}
+
 // NotFound returns true if API error indicate that volume is not exists
 func (err *APIError) NotFound() bool {
-	return err.StatusCode == http.StatusNotFound
+	return err.HasCondition(ErrNotFound)
 }
 
 // VolumeNameIsAlreadyUse returns true if API error indicate that volume name is already in use
 func (err *APIError) VolumeNameIsAlreadyUse() bool {
-	return err.StatusCode == http.StatusUnprocessableEntity || err.StatusCode == http.StatusInternalServerError
+	return err.matches(ErrNameAlreadyInUse,
+		err.StatusCode == http.StatusUnprocessableEntity || err.StatusCode == http.StatusInternalServerError)
 }
 
 // SnapshotNameIsAlreadyUse returns true if API error indicate that snapshot name is already in use
 func (err *APIError) SnapshotNameIsAlreadyUse() bool {
-	return err.StatusCode == http.StatusBadRequest
+	return err.matches(ErrNameAlreadyInUse, err.StatusCode == http.StatusBadRequest)
 }
 
 // FSNameIsAlreadyUse returns true if API error indicate that fs name is already in use
 func (err *APIError) FSNameIsAlreadyUse() bool {
-	return err.StatusCode == http.StatusUnprocessableEntity
+	return err.matches(ErrNameAlreadyInUse, err.StatusCode == http.StatusUnprocessableEntity)
 }
 
 // HostIsNotAttachedToVolume returns true if API error indicate that host is not attached to volume
 func (err *APIError) HostIsNotAttachedToVolume() bool {
-	return err.StatusCode == http.StatusBadRequest
+	return err.matches(ErrHostNotAttachedToVolume, err.StatusCode == http.StatusBadRequest)
 }
 
 // VolumeIsNotAttachedToHost returns true if API error indicate that volume is not attached to host
 func (err *APIError) VolumeIsNotAttachedToHost() bool {
-	return err.StatusCode == http.StatusBadRequest
+	return err.matches(ErrHostNotAttachedToVolume, err.StatusCode == http.StatusBadRequest)
 }
 
 // HostIsNotExist returns true if API error indicate that host is not exists
 func (err *APIError) HostIsNotExist() bool {
-	return err.StatusCode == http.StatusNotFound || err.StatusCode == http.StatusBadRequest
+	return err.matches(ErrNotFound, err.StatusCode == http.StatusNotFound || err.StatusCode == http.StatusBadRequest)
 }
 
 // BadRange returns true if API error indicate that request was submitted with invalid range
 func (err *APIError) BadRange() bool {
-	return err.StatusCode == http.StatusRequestedRangeNotSatisfiable
+	return err.HasCondition(ErrBadRange)
 }
 
 // VolumeAttachedToHost returns true if API error indicate that operation can't be complete because
 // volume is attached to host
 func (err *APIError) VolumeAttachedToHost() bool {
-	return err.StatusCode == http.StatusUnprocessableEntity
+	return err.matches(ErrVolumeAttachedToHost, err.StatusCode == http.StatusUnprocessableEntity)
 }
 
 // VolumeDetachedFromHost returns true if API error indicate that volume is detached from host
 func (err *APIError) VolumeDetachedFromHost() bool {
-	return err.StatusCode == http.StatusUnprocessableEntity
+	return err.matches(ErrVolumeDetachedFromHost, err.StatusCode == http.StatusUnprocessableEntity)
 }
 
 // HostAlreadyRemovedFromNFSExport returns true if API error indicate that operation can't be complete because
 // host ip already removed from nfs export access
 func (err *APIError) HostAlreadyRemovedFromNFSExport() bool {
-	return err.StatusCode == http.StatusBadRequest
+	return err.matches(ErrHostAlreadyRemovedFromNFSExport, err.StatusCode == http.StatusBadRequest)
 }
 
 // HostAlreadyPresentInNFSExport returns true if API error indicate that operation can't be complete because
 // host ip already present in nfs export access
 func (err *APIError) HostAlreadyPresentInNFSExport() bool {
-	return err.StatusCode == http.StatusBadRequest
+	return err.matches(ErrHostAlreadyPresentInNFSExport, err.StatusCode == http.StatusBadRequest)
 }
 
 // UnableToFailoverFromDestination returns true if API error indicate that operation can't be complete because
 // it is impossible to failover from Destination
 func (err *APIError) UnableToFailoverFromDestination() bool {
-	return err.StatusCode == http.StatusBadRequest
+	return err.matches(ErrFailoverFromDestination, err.StatusCode == http.StatusBadRequest)
 }
 
 // ReplicationSessionAlreadyCreated returns true if API error indicate that replication session has already been created
 func (err *APIError) ReplicationSessionAlreadyCreated() bool {
-	return err.StatusCode == http.StatusBadRequest
+	return err.matches(ErrReplicationSessionExists, err.StatusCode == http.StatusBadRequest)
 }
 
 // VolumeAlreadyRemovedFromVolumeGroup returns true if API error indicate that volume is not part of the volume group
 func (err *APIError) VolumeAlreadyRemovedFromVolumeGroup() bool {
-	return err.StatusCode == http.StatusUnprocessableEntity && strings.Contains(err.Message, "not part")
+	return err.HasCondition(ErrVolumeNotInVolumeGroup)
 }
 
 // FSCreationLimitReached returns true if API error indicate that file system creation limit has been reached
 func (err *APIError) FSCreationLimitReached() bool {
-	return err.StatusCode == http.StatusUnprocessableEntity && naslimitRegex.MatchString(err.Message)
+	return err.HasCondition(ErrFSCreationLimitReached)
 }
 
 // NewNotFoundError returns new VolumeIsNotExistError
@@ -19,9 +19,13 @@
 package gopowerstore
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/dell/gopowerstore/api"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -107,3 +111,118 @@ func TestAPIError_FSCreationLimitReached(t *testing.T) {
 	apiError.Message = "   The limit of 125 file systems for the NAS server   "
 	assert.True(t, apiError.FSCreationLimitReached())
 }
+
+func TestAPIError_ExactMatch(t *testing.T) {
+	// error with known code matches its condition only, status based guesses are not used
+	apiError := NewAPIError()
+	apiError.StatusCode = http.StatusUnprocessableEntity
+	apiError.Code = "0xE0A08001000E"
+	assert.True(t, apiError.NotFound())
+	assert.False(t, apiError.VolumeNameIsAlreadyUse())
+	assert.False(t, apiError.VolumeAttachedToHost())
+
+	apiError.Code = ""
+	apiError.Message = "One or more volumes to be removed are not part of the volume group"
+	assert.True(t, apiError.VolumeAlreadyRemovedFromVolumeGroup())
+	assert.False(t, apiError.FSNameIsAlreadyUse())
+}
+
+func TestAPIError_HostIsNotExist(t *testing.T) {
+	apiError := NewAPIError()
+	apiError.StatusCode = http.StatusBadRequest
+	assert.True(t, apiError.HostIsNotExist())
+
+	apiError.StatusCode = http.StatusUnprocessableEntity
+	apiError.Code = "0xE04040020002"
+	apiError.Message = "Invalid instance."
+	assert.True(t, apiError.HostIsNotExist())
+
+	apiError = NewAPIError()
+	apiError.StatusCode = http.StatusUnprocessableEntity
+	apiError.Message = "The volume vol-1 is attached to one or more hosts, detach it before deletion."
+	assert.False(t, apiError.HostIsNotExist())
+	assert.True(t, apiError.VolumeAttachedToHost())
+	assert.False(t, apiError.VolumeDetachedFromHost())
+}
+
+func TestAPIError_ErrorsIsAs(t *testing.T) {
+	err := WrapErr(&api.ErrorMsg{StatusCode: http.StatusNotFound, Message: "not found"})
+	wrapped := fmt.Errorf("get volume: %w", err)
+	assert.True(t, errors.Is(wrapped, ErrNotFound))
+	assert.False(t, errors.Is(wrapped, ErrNameAlreadyInUse))
+
+	var apiError APIError
+	assert.True(t, errors.As(wrapped, &apiError))
+	assert.True(t, apiError.NotFound())
+
+	var errorMsg *api.ErrorMsg
+	assert.True(t, errors.As(wrapped, &errorMsg))
+	assert.Equal(t, http.StatusNotFound, errorMsg.StatusCode)
+
+	assert.True(t, errors.Is(NewNotFoundError(), ErrNotFound))
+}
+
+func TestFieldProviders_MatchStructs(t *testing.T) {
+	providers := []api.FieldProvider{
+		&ApplianceInstance{}, &ApplianceMetrics{}, &Cluster{}, &FcPort{}, &FileInterface{}, &FileSystem{},
+		&Host{}, &HostGroup{}, &HostVolumeMapping{}, &IPPoolAddress{}, &Job{}, &Limit{}, &NAS{},
+		&NFSExport{}, &NFSServerInstance{}, &ProtectionPolicy{}, &RemoteSystem{}, &ReplicationRule{},
+		&ReplicationSession{}, &SMBShare{}, &SnapshotRule{}, &SoftwareInstalled{}, StorageContainer{},
+		&Volume{}, &VolumeGroup{},
+	}
+	for _, fp := range providers {
+		t.Run(fmt.Sprintf("%T", fp), func(t *testing.T) {
+			vfp, ok := fp.(api.VersionedFieldProvider)
+			if !ok {
+				assert.NoError(t, api.ValidateSelectFields(fp, fp.Fields()))
+				return
+			}
+			// fields for unknown version are a subset of fields for the latest version
+			latest := vfp.FieldsForVersion(api.MustParseArrayVersion("99.0"))
+			assert.NoError(t, api.ValidateSelectFields(fp, latest))
+			selected := make(map[string]bool)
+			for _, f := range latest {
+				name, _, _ := strings.Cut(f, "(")
+				selected[name] = true
+			}
+			for _, f := range fp.Fields() {
+				assert.True(t, selected[f], f)
+			}
+		})
+	}
+}
+
+func TestFieldProviders_UnknownVersion(t *testing.T) {
+	for _, fp := range []api.VersionedFieldProvider{&Cluster{}, &FcPort{}, &NAS{}} {
+		assert.Equal(t, fp.Fields(), fp.FieldsForVersion(api.ArrayVersion{}), "%T", fp)
+	}
+	assert.Equal(t, []string{"id", "name", "management_address", "state", "system_time"}, (&Cluster{}).Fields())
+	assert.Equal(t, []string{
+		"id", "name", "global_id", "management_address", "state", "nvm_subsystem_nqn", "system_time",
+	}, (&Cluster{}).FieldsForVersion(api.MustParseArrayVersion("3.0")))
+	assert.NotContains(t, (&FcPort{}).FieldsForVersion(api.MustParseArrayVersion("2.1")), "wwn_nvme")
+	assert.Contains(t, (&FcPort{}).FieldsForVersion(api.MustParseArrayVersion("3.0")), "wwn_nvme")
+	assert.Contains(t, (&NAS{}).Fields(), "nfs_servers")
+	assert.Contains(t, (&NAS{}).FieldsForVersion(api.MustParseArrayVersion("3.6")),
+		"nfs_servers(id,is_nfsv3_enabled,is_nfsv4_enabled)")
+}
+
+func TestFieldProviders_Select(t *testing.T) {
+	assert.Equal(t, []string{
+		"id", "name", "rpo", "remote_system_id", "policies(id,name)", "alert_threshold", "is_read_only",
+		"is_replica", "managed_by", "managed_by_id", "remote_system(id,name)", "replication_sessions(id,state)",
+	}, (&ReplicationRule{}).Fields())
+	assert.Equal(t, []string{
+		"*", "virtual_machines(*)", "file_systems(*)", "performance_rules(*)", "replication_rules(*)",
+		"snapshot_rules(*)", "volumes(*)", "volume_groups(*)",
+	}, (&ProtectionPolicy{}).Fields())
+	assert.Equal(t, []string{
+		"*", "hosts(*)", "mapped_host_groups(*,volume(*))", "host_virtual_volume_mappings(*,virtual_volume(*))",
+	}, (&HostGroup{}).Fields())
+	assert.Equal(t, []string{
+		"*", "volumes(*)", "protection_policy(*)", "protection_data", "location_history", "migration_session(*)",
+	}, (&VolumeGroup{}).Fields())
+	assert.Contains(t, (&IPPoolAddress{}).Fields(), "ip_port(target_iqn,id)")
+	assert.Contains(t, (&HostVolumeMapping{}).Fields(), "volume(appliance_id)")
+	assert.Equal(t, []string{"*"}, (&Host{}).Fields())
+}
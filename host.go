@@ -42,27 +42,13 @@ func getHostVolumeMappingQueryParams(c Client) api.QueryParamsEncoder {
 
 // GetHosts returns hosts list
 func (c *ClientIMPL) GetHosts(ctx context.Context) (resp []Host, err error) {
-	err = c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []Host
-		qp := getHostDefaultQueryParams(c)
-		qp.Limit(paginationDefaultPageSize)
-		qp.Offset(offset)
-		qp.Order("name")
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    hostURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			resp = append(resp, page...)
-		}
-		return meta, err
+	qp := getHostDefaultQueryParams(c)
+	qp.Order("name")
+	return readPaginatedData[Host](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    hostURL,
+		QueryParams: qp,
 	})
-	return resp, err
 }
 
 // GetHost get host by id
@@ -149,28 +135,13 @@ func (c *ClientIMPL) ModifyHost(ctx context.Context,
 
 // GetHostVolumeMappings returns volume mapping
 func (c *ClientIMPL) GetHostVolumeMappings(ctx context.Context) (resp []HostVolumeMapping, err error) {
-	err = c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []HostVolumeMapping
-		qp := getHostVolumeMappingQueryParams(c)
-		qp.Limit(paginationDefaultPageSize)
-		qp.Offset(offset)
-		qp.Order("id")
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    hostMappingURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			resp = append(resp, page...)
-		}
-		return meta, err
+	qp := getHostVolumeMappingQueryParams(c)
+	qp.Order("id")
+	return readPaginatedData[HostVolumeMapping](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    hostMappingURL,
+		QueryParams: qp,
 	})
-
-	return resp, WrapErr(err)
 }
 
 // GetHostVolumeMapping returns volume mapping by id
@@ -191,28 +162,14 @@ func (c *ClientIMPL) GetHostVolumeMapping(ctx context.Context, id string) (resp
 func (c *ClientIMPL) GetHostVolumeMappingByVolumeID(
 	ctx context.Context, volumeID string,
 ) (resp []HostVolumeMapping, err error) {
-	err = c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []HostVolumeMapping
-		qp := getHostVolumeMappingQueryParams(c)
-		qp.RawArg("volume_id", fmt.Sprintf("eq.%s", volumeID))
-		qp.Order("id")
-		qp.Limit(paginationDefaultPageSize)
-		qp.Offset(offset)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    hostMappingURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			resp = append(resp, page...)
-		}
-		return meta, err
+	qp := getHostVolumeMappingQueryParams(c)
+	qp.RawArg("volume_id", fmt.Sprintf("eq.%s", volumeID))
+	qp.Order("id")
+	return readPaginatedData[HostVolumeMapping](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    hostMappingURL,
+		QueryParams: qp,
 	})
-	return resp, WrapErr(err)
 }
 
 // AttachVolumeToHost attaches volume to host
@@ -229,6 +186,7 @@ func (c *ClientIMPL) AttachVolumeToHost(
 			ID:       hostID,
 			Action:   "attach",
 			Body:     attachParams,
+			Priority: api.PriorityHigh,
 		},
 		&resp)
 	return resp, WrapErr(err)
@@ -248,6 +206,7 @@ func (c *ClientIMPL) DetachVolumeFromHost(
 			ID:       hostID,
 			Action:   "detach",
 			Body:     detachParams,
+			Priority: api.PriorityHigh,
 		},
 		&resp)
 	return resp, WrapErr(err)
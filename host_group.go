@@ -48,6 +48,7 @@ func (c *ClientIMPL) AttachVolumeToHostGroup(
 			ID:       hostGroupID,
 			Action:   "attach",
 			Body:     attachParams,
+			Priority: api.PriorityHigh,
 		},
 		&resp)
 	return resp, WrapErr(err)
@@ -67,6 +68,7 @@ func (c *ClientIMPL) DetachVolumeFromHostGroup(
 			ID:       hostGroupID,
 			Action:   "detach",
 			Body:     detachParams,
+			Priority: api.PriorityHigh,
 		},
 		&resp)
 	return resp, WrapErr(err)
@@ -113,28 +115,14 @@ func (c *ClientIMPL) GetHostGroup(ctx context.Context, id string) (resp HostGrou
 
 // GetHostGroups returns a list of host groups
 func (c *ClientIMPL) GetHostGroups(ctx context.Context) ([]HostGroup, error) {
-	var result []HostGroup
-	err := c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []HostGroup
-		hostGroup := HostGroup{}
-		qp := c.APIClient().QueryParamsWithFields(&hostGroup)
-		qp.Order("name")
-		qp.Offset(offset).Limit(paginationDefaultPageSize)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    hostGroupURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			result = append(result, page...)
-		}
-		return meta, err
+	hostGroup := HostGroup{}
+	qp := c.APIClient().QueryParamsWithFields(&hostGroup)
+	qp.Order("name")
+	return readPaginatedData[HostGroup](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    hostGroupURL,
+		QueryParams: qp,
 	})
-	return result, err
 }
 
 // CreateHostGroup creates new host group
@@ -18,6 +18,8 @@
 
 package gopowerstore
 
+import "github.com/dell/gopowerstore/api"
+
 // HostGroup hostgroup instance
 type HostGroup struct {
 	// A description for the hostgroup.
@@ -27,20 +29,20 @@ type HostGroup struct {
 	// The hostgroup name.
 	Name string `json:"name,omitempty"`
 	// Properties of a host.
-	Hosts []Host `json:"hosts,omitempty"`
+	Hosts []Host `json:"hosts,omitempty" select:"all"`
 	// Connectivity type for hosts and host groups.
 	HostConnectivity HostConnectivityEnum `json:"host_connectivity,omitempty"`
 	// HostConnectivityL10n Localized message string corresponding to host_connectivity
 	HostConnectivityL10n string `json:"host_connectivity_l10n,omitempty"`
 	// MappedHostGroups Details about a configured host or host group attached to a volume.
-	MappedHostGroups []MappedHostGroup `json:"mapped_host_groups,omitempty"`
+	MappedHostGroups []MappedHostGroup `json:"mapped_host_groups,omitempty" select:"embed,star"`
 	// HostVirtualVolumeMappings Virtual volume mapping details.
-	HostVirtualVolumeMappings []HostVirtualVolumeMapping `json:"host_virtual_volume_mappings,omitempty"`
+	HostVirtualVolumeMappings []HostVirtualVolumeMapping `json:"host_virtual_volume_mappings,omitempty" select:"embed,star"`
 }
 
 // Fields returns fields which must be requested to fill struct
 func (h *HostGroup) Fields() []string {
-	return []string{"*", "hosts(*)", "mapped_host_groups(*,volume(*))", "host_virtual_volume_mappings(*,virtual_volume(*))"}
+	return api.SelectAllFields(h)
 }
 
 // HostGroupCreate create hostgroup request
@@ -77,7 +79,7 @@ type MappedHostGroup struct {
 	// Unique identifier of the volume to which the host is attached.
 	VolumeID string `json:"volume_id,omitempty"`
 	// Details about a volume, including snapshots and clones of volumes.
-	Volume Volume `json:"volume,omitempty"`
+	Volume Volume `json:"volume,omitempty" select:"all"`
 }
 
 type HostVirtualVolumeMapping struct {
@@ -88,5 +90,5 @@ type HostVirtualVolumeMapping struct {
 	// Unique identifier of the virtual volume to which the host is attached.
 	VirtualVolumeID string `json:"virtual_volume_id,omitempty"`
 	// A virtual volume.
-	VirtualVolume VirtualVolume `json:"virtual_volume,omitempty"`
+	VirtualVolume VirtualVolume `json:"virtual_volume,omitempty" select:"all"`
 }
@@ -18,6 +18,8 @@
 
 package gopowerstore
 
+import "github.com/dell/gopowerstore/api"
+
 // OSTypeEnum Operating system of the host.
 type OSTypeEnum string
 
@@ -238,7 +240,7 @@ type Host struct {
 
 // Fields returns fields which must be requested to fill struct
 func (h *Host) Fields() []string {
-	return []string{"*"}
+	return api.SelectAllFields(h)
 }
 
 // HostVolumeMapping Details about a configured host or host group attached to a volume.
@@ -246,7 +248,7 @@ func (h *Host) Fields() []string {
 type HostVolumeMapping struct {
 	Volume struct {
 		ApplianceID string `json:"appliance_id,omitempty"`
-	} `json:"volume,omitempty"`
+	} `json:"volume,omitempty" select:"embed"`
 
 	// Unique identifier of a host group attached to a volume. The host_id and host_group_id cannot both be set.
 	HostGroupID string `json:"host_group_id,omitempty"`
@@ -262,10 +264,7 @@ type HostVolumeMapping struct {
 
 // Fields returns fields which must be requested to fill struct
 func (h *HostVolumeMapping) Fields() []string {
-	return []string{
-		"volume(appliance_id)", "host_group_id", "host_id",
-		"id", "logical_unit_number", "volume_id",
-	}
+	return api.SelectFields(h)
 }
 
 // HostVolumeAttach Volume id and optional logical unit number for attaching to host.
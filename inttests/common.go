@@ -22,6 +22,10 @@ import (
 	"context"
 	"crypto/rand"
 	"math/big"
+	mrand "math/rand/v2"
+	"os"
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/dell/gopowerstore"
@@ -47,8 +51,38 @@ func skipTestOnError(t *testing.T, err error) {
 	}
 }
 
+// seededNames generates resource names from a fixed seed when tests run with a cassette,
+// so replayed requests use the names which were recorded
+var (
+	seededNamesOnce sync.Once
+	seededNamesMu   sync.Mutex
+	seededNames     *mrand.Rand
+)
+
+func seededRand() *mrand.Rand {
+	seededNamesOnce.Do(func() {
+		if os.Getenv(cassetteEnv) == "" {
+			return
+		}
+		seed, err := strconv.ParseUint(os.Getenv(cassetteSeedEnv), 10, 64)
+		if err != nil {
+			seed = 1
+		}
+		seededNames = mrand.New(mrand.NewPCG(seed, seed)) // #nosec G404
+	})
+	return seededNames
+}
+
 func randString(n int) string {
 	b := make([]byte, n)
+	if r := seededRand(); r != nil {
+		seededNamesMu.Lock()
+		defer seededNamesMu.Unlock()
+		for i := range b {
+			b[i] = letters[r.IntN(len(letters))]
+		}
+		return string(b)
+	}
 	for i := range b {
 		if len(letters) > 0 {
 			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(letters))))
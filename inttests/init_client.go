@@ -20,22 +20,58 @@ package inttests
 
 import (
 	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/dell/gopowerstore"
+	"github.com/dell/gopowerstore/api"
+	"github.com/dell/gopowerstore/cassette"
+	"github.com/dell/gopowerstore/simulator"
 	"github.com/joho/godotenv"
 )
 
 const envVarsFile = "GOPOWERSTORE_TEST.env"
 
+// Env vars which run the tests against a cassette file instead of a live array
+const (
+	// path to the cassette file, cassettes are not used when it is empty
+	cassetteEnv = "GOPOWERSTORE_CASSETTE"
+	// record or replay, replay is the default
+	cassetteModeEnv = "GOPOWERSTORE_CASSETTE_MODE"
+	// strict or lenient, lenient is the default
+	cassetteMatchEnv = "GOPOWERSTORE_CASSETTE_MATCH"
+	// seed of generated resource names, the same seed must be used to record and to replay a cassette
+	cassetteSeedEnv = "GOPOWERSTORE_CASSETTE_SEED"
+)
+
+// replayAPIURL is used when the tests are replayed without array address, cassettes don't store it
+const replayAPIURL = "https://powerstore.invalid/api/rest"
+
+// replayTimeout is the request timeout of cassette clients when GOPOWERSTORE_HTTP_TIMEOUT is not set
+const replayTimeout = 60 * time.Second
+
 // C is global powerstore Client instance for testing
 var C gopowerstore.Client
 
+// all clients share one recorder, recorders of the same file would overwrite each other's interactions
+// and replay the same responses independently
+var (
+	recorderOnce sync.Once
+	recorder     *cassette.Recorder
+	recorderErr  error
+
+	simulatorOnce sync.Once
+	sim           *simulator.Server
+)
+
 func initClient() {
 	err := godotenv.Load(envVarsFile)
 	if err != nil {
 		log.Printf("%s file not found.", envVarsFile)
 	}
-	C, err = gopowerstore.NewClient()
+	C, err = newClient()
 	if err != nil {
 		panic(err)
 	}
@@ -50,10 +86,62 @@ func GetNewClient() (client gopowerstore.Client) {
 	if err != nil {
 		log.Printf("%s file not found.", envVarsFile)
 	}
-	client, err = gopowerstore.NewClient()
+	client, err = newClient()
 	if err != nil {
 		panic(err)
 	}
 
 	return client
 }
+
+// newClient returns client for the array or for the cassette if GOPOWERSTORE_CASSETTE is set
+func newClient() (gopowerstore.Client, error) {
+	path := os.Getenv(cassetteEnv)
+	if path == "" {
+		return gopowerstore.NewClient()
+	}
+	mode, err := cassette.ParseMode(os.Getenv(cassetteModeEnv))
+	if err != nil {
+		return nil, err
+	}
+	recorderOnce.Do(func() {
+		recorder, recorderErr = newRecorder(path, mode)
+	})
+	if recorderErr != nil {
+		return nil, recorderErr
+	}
+
+	apiURL, username, password := os.Getenv(gopowerstore.APIURLEnv), os.Getenv(gopowerstore.UsernameEnv),
+		os.Getenv(gopowerstore.PasswordEnv)
+	switch {
+	case mode == cassette.ModeReplay:
+		// credentials are scrubbed from cassettes, any values are accepted
+		apiURL, username, password = replayAPIURL, "admin", "password"
+	case apiURL == "":
+		// without an array the cassette is recorded against the simulator
+		simulatorOnce.Do(func() {
+			sim = simulator.New(simulator.Options{})
+		})
+		apiURL, username, password = sim.URL(), sim.Username(), sim.Password()
+	}
+	// cassettes are replayed without GOPOWERSTORE_HTTP_TIMEOUT
+	options := gopowerstore.NewClientOptions().SetTransport(recorder).SetDefaultTimeout(replayTimeout)
+	if timeout, err := strconv.ParseInt(os.Getenv(gopowerstore.HTTPTimeoutEnv), 10, 64); err == nil {
+		options.SetDefaultTimeout(time.Duration(timeout) * time.Second)
+	}
+	return gopowerstore.NewClientWithArgs(apiURL, username, password, options)
+}
+
+// newRecorder returns recorder of the cassette file for all clients of the tests
+func newRecorder(path string, mode cassette.Mode) (*cassette.Recorder, error) {
+	match, err := cassette.ParseMatch(os.Getenv(cassetteMatchEnv))
+	if err != nil {
+		return nil, err
+	}
+	insecure, _ := strconv.ParseBool(os.Getenv(gopowerstore.InsecureEnv))
+	httpClient, err := api.NewHTTPClient(api.TransportOptions{Insecure: insecure})
+	if err != nil {
+		return nil, err
+	}
+	return cassette.New(path, cassette.Options{Mode: mode, Match: match, Transport: httpClient.Transport})
+}
@@ -34,15 +34,11 @@ func (c *ClientIMPL) GetStorageISCSITargetAddresses(
 	qp := c.APIClient().QueryParamsWithFields(&ipPoolAddress)
 	qp.RawArg("purposes", fmt.Sprintf("cs.{%s}", IPPurposeTypeEnumStorageIscsiTarget))
 	qp.Order("id")
-	_, err = c.APIClient().Query(
-		ctx,
-		RequestConfig{
-			Method:      "GET",
-			Endpoint:    apiPoolAddressURL,
-			QueryParams: qp,
-		},
-		&resp)
-	err = WrapErr(err)
+	resp, err = readPaginatedData[IPPoolAddress](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    apiPoolAddressURL,
+		QueryParams: qp,
+	})
 	if err != nil {
 		return resp, err
 	}
@@ -56,19 +52,18 @@ func (c *ClientIMPL) GetStorageISCSITargetAddresses(
 func (c *ClientIMPL) GetStorageNVMETCPTargetAddresses(
 	ctx context.Context,
 ) (resp []IPPoolAddress, err error) {
+	if err = c.RequireCapability(ctx, CapabilityNVMeTCP); err != nil {
+		return resp, err
+	}
 	var ipPoolAddress IPPoolAddress
 	qp := c.APIClient().QueryParamsWithFields(&ipPoolAddress)
 	qp.RawArg("purposes", fmt.Sprintf("cs.{%s}", IPPurposeTypeEnumStorageNVMETCPPort))
 	qp.Order("id")
-	_, err = c.APIClient().Query(
-		ctx,
-		RequestConfig{
-			Method:      "GET",
-			Endpoint:    apiPoolAddressURL,
-			QueryParams: qp,
-		},
-		&resp)
-	err = WrapErr(err)
+	resp, err = readPaginatedData[IPPoolAddress](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    apiPoolAddressURL,
+		QueryParams: qp,
+	})
 	if err != nil {
 		return resp, err
 	}
@@ -18,6 +18,8 @@
 
 package gopowerstore
 
+import "github.com/dell/gopowerstore/api"
+
 // IPPurposeTypeEnum Network IP address purpose.
 type IPPurposeTypeEnum string
 
@@ -71,7 +73,7 @@ type IPPoolAddress struct {
 	// Unique identifier of the port that uses this IP address to provide access to storage network services, such as iSCSI. This attribute can be set only for an IP address used by networks of type Storage.
 	IPPortID string `json:"ip_port_id,omitempty"`
 	// IPPort instance
-	IPPort IPPortInstance `json:"ip_port,omitempty"`
+	IPPort IPPortInstance `json:"ip_port,omitempty" select:"fields=target_iqn|id"`
 	// Unique identifier of the network to which the IP address belongs.
 	NetworkID string `json:"network_id,omitempty"`
 	// Unique identifier of the cluster node to which the IP address belongs.
@@ -82,10 +84,7 @@ type IPPoolAddress struct {
 
 // Fields returns fields which must be requested to fill struct
 func (ip *IPPoolAddress) Fields() []string {
-	return []string{
-		"address", "appliance_id", "id", "ip_port_id",
-		"ip_port(target_iqn, id)", "network_id", "node_id", "purposes",
-	}
+	return api.SelectFields(ip)
 }
 
 // IPPortInstance ip port instance
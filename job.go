@@ -0,0 +1,186 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package gopowerstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dell/gopowerstore/api"
+)
+
+const (
+	jobsURL                    = "job"
+	jobStatusInProgress        = JobStateInProgress
+	defaultJobPollInterval     = 2 * time.Second
+	errMsgJobIDRequired        = "job ID is required"
+	errMsgJobMissingInResponse = "job ID is missing in async response"
+)
+
+func getJobDefaultQueryParams(c Client) api.QueryParamsEncoder {
+	job := Job{}
+	return c.APIClient().QueryParamsWithFields(&job)
+}
+
+func getAsyncQueryParams(c Client) api.QueryParamsEncoder {
+	return c.APIClient().QueryParams().Async(true)
+}
+
+// GetJob query and return specific job by ID
+func (c *ClientIMPL) GetJob(ctx context.Context, id string) (resp Job, err error) {
+	if id == "" {
+		return resp, errors.New(errMsgJobIDRequired)
+	}
+	_, err = c.APIClient().Query(
+		ctx,
+		RequestConfig{
+			Method:      "GET",
+			Endpoint:    jobsURL,
+			ID:          id,
+			QueryParams: getJobDefaultQueryParams(c),
+		},
+		&resp)
+	return resp, WrapErr(err)
+}
+
+// ListJobs query and return all jobs matching the filter, e.g. {"state": "eq.RUNNING"}
+func (c *ClientIMPL) ListJobs(ctx context.Context, filter map[string]string) ([]Job, error) {
+	qp := getJobDefaultQueryParams(c)
+	for k, v := range filter {
+		qp.RawArg(k, v)
+	}
+	return readPaginatedData[Job](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    jobsURL,
+		QueryParams: qp,
+	})
+}
+
+// GetJobSteps query and return steps of the job ordered by execution
+func (c *ClientIMPL) GetJobSteps(ctx context.Context, id string) ([]Job, error) {
+	if id == "" {
+		return nil, errors.New(errMsgJobIDRequired)
+	}
+	qp := getJobDefaultQueryParams(c)
+	qp.RawArg("parent_id", fmt.Sprintf("eq.%s", id))
+	qp.Order("step_order")
+	return readPaginatedData[Job](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    jobsURL,
+		QueryParams: qp,
+	})
+}
+
+// WaitForJob polls the job until it reaches a terminal state or ctx is done.
+// It returns *JobError if the job doesn't complete successfully.
+func (c *ClientIMPL) WaitForJob(ctx context.Context, id string, opts *WaitForJobOptions) (Job, error) {
+	pollInterval := defaultJobPollInterval
+	var onProgress func(Job)
+	if opts != nil {
+		if opts.PollInterval > 0 {
+			pollInterval = opts.PollInterval
+		}
+		onProgress = opts.OnProgress
+	}
+
+	for {
+		job, err := c.GetJob(ctx, id)
+		if err != nil {
+			// errors of requests cut off by ctx, e.g. waiting for the rate limit, don't always wrap it
+			if ctxErr := ctx.Err(); ctxErr != nil && !errors.Is(err, ctxErr) {
+				return job, fmt.Errorf("%w: %v", ctxErr, err)
+			}
+			return job, err
+		}
+		if onProgress != nil {
+			onProgress(job)
+		}
+		if job.StateEnum().IsTerminal() {
+			if job.StateEnum() == JobStateCompleted {
+				return job, nil
+			}
+			return job, newJobError(job)
+		}
+
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return job, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func newJobError(job Job) *JobError {
+	jobErr := &JobError{Job: job}
+	res, err := job.Result()
+	if err != nil || len(res.Messages) == 0 {
+		return jobErr
+	}
+	first := res.Messages[0]
+	jobErr.Err = &api.ErrorMsg{
+		Code:      first.Code,
+		Severity:  first.Severity,
+		Message:   first.Message,
+		Arguments: first.Arguments,
+		Messages:  res.Messages,
+	}
+	return jobErr
+}
+
+func (c *ClientIMPL) queryAsync(ctx context.Context, cfg RequestConfig) (resp AsyncResponse, err error) {
+	cfg.QueryParams = getAsyncQueryParams(c)
+	_, err = c.APIClient().Query(ctx, cfg, &resp)
+	if err == nil && resp.ID == "" {
+		err = errors.New(errMsgJobMissingInResponse)
+	}
+	return resp, WrapErr(err)
+}
+
+// CloneVolumeAsync starts cloning of the volume and returns ID of the job, see WaitForJob
+func (c *ClientIMPL) CloneVolumeAsync(ctx context.Context,
+	createParams *VolumeClone, volID string,
+) (AsyncResponse, error) {
+	return c.queryAsync(ctx, RequestConfig{
+		Method:   "POST",
+		Endpoint: volumeURL,
+		ID:       volID,
+		Action:   VolumeActionClone,
+		Body:     createParams,
+	})
+}
+
+// CreateFSAsync starts creation of the filesystem and returns ID of the job, see WaitForJob
+func (c *ClientIMPL) CreateFSAsync(ctx context.Context, createParams *FsCreate) (AsyncResponse, error) {
+	return c.queryAsync(ctx, RequestConfig{
+		Method:   "POST",
+		Endpoint: fsURL,
+		Body:     createParams,
+	})
+}
+
+// DeleteVolumeGroupAsync starts deletion of the volume group and returns ID of the job, see WaitForJob
+func (c *ClientIMPL) DeleteVolumeGroupAsync(ctx context.Context, id string) (AsyncResponse, error) {
+	return c.queryAsync(ctx, RequestConfig{
+		Method:   "DELETE",
+		ID:       id,
+		Endpoint: volumeGroupURL,
+	})
+}
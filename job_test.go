@@ -0,0 +1,165 @@
+/*
+ *
+ * Copyright © 2020-2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package gopowerstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIMPL_GetJob(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	respData := fmt.Sprintf(`{"id": "%s", "state": "RUNNING", "progress_percentage": 40, "resource_id": "%s"}`,
+		jobID, volID)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", jobsMockURL, jobID),
+		httpmock.NewStringResponder(200, respData))
+	job, err := C.GetJob(context.Background(), jobID)
+	assert.Nil(t, err)
+	assert.Equal(t, "RUNNING", job.State)
+	assert.Equal(t, JobStateRunning, job.StateEnum())
+	assert.Equal(t, 40, job.ProgressPercentage)
+	assert.Equal(t, []string{volID}, job.ResourceIDs())
+
+	_, err = C.GetJob(context.Background(), "")
+	assert.NotNil(t, err)
+}
+
+func TestClientIMPL_ListJobs(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", jobsMockURL,
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "eq.RUNNING", req.URL.Query().Get("state"))
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`[{"id": "%s"}, {"id": "%s"}]`, jobID, jobID2)), nil
+		})
+	jobs, err := C.ListJobs(context.Background(), map[string]string{"state": "eq.RUNNING"})
+	assert.Nil(t, err)
+	assert.Len(t, jobs, 2)
+}
+
+func TestClientIMPL_GetJobSteps(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", jobsMockURL,
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "eq."+jobID, req.URL.Query().Get("parent_id"))
+			assert.Equal(t, "step_order", req.URL.Query().Get("order"))
+			return httpmock.NewStringResponse(200,
+				fmt.Sprintf(`[{"id": "%s", "parent_id": "%s", "step_order": 1, "description_l10n": "Create volume"}]`,
+					jobID2, jobID)), nil
+		})
+	steps, err := C.GetJobSteps(context.Background(), jobID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Create volume", steps[0].Description)
+}
+
+func TestClientIMPL_WaitForJob(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	states := []string{
+		`{"id": "%s", "state": "QUEUED"}`,
+		`{"id": "%s", "state": "RUNNING", "progress_percentage": 50}`,
+		`{"id": "%s", "state": "COMPLETED", "progress_percentage": 100, "response_body": {"id": "` + volID2 + `"}}`,
+	}
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", jobsMockURL, jobID),
+		func(_ *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, fmt.Sprintf(states[calls], jobID))
+			calls++
+			return resp, nil
+		})
+
+	var progress []int
+	job, err := C.WaitForJob(context.Background(), jobID, &WaitForJobOptions{
+		PollInterval: time.Millisecond,
+		OnProgress:   func(job Job) { progress = append(progress, job.ProgressPercentage) },
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, JobStateCompleted, job.StateEnum())
+	assert.Equal(t, []int{0, 50, 100}, progress)
+	assert.Equal(t, []string{volID2}, job.ResourceIDs())
+}
+
+func TestClientIMPL_WaitForJob_Failed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	respData := fmt.Sprintf(`{"id": "%s", "state": "FAILED", "response_body": {"messages": [
+		{"code": "0xE0A08001000E", "severity": "Error", "message_l10n": "Could not find the volume"}]}}`, jobID)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", jobsMockURL, jobID),
+		httpmock.NewStringResponder(200, respData))
+
+	_, err := C.WaitForJob(context.Background(), jobID, nil)
+	var jobErr *JobError
+	assert.True(t, errors.As(err, &jobErr))
+	assert.Equal(t, JobStateFailed, jobErr.Job.StateEnum())
+	assert.Contains(t, err.Error(), "Could not find the volume")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestClientIMPL_WaitForJob_ContextDone(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/%s", jobsMockURL, jobID),
+		httpmock.NewStringResponder(200, fmt.Sprintf(`{"id": "%s", "state": "RUNNING"}`, jobID)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := C.WaitForJob(ctx, jobID, &WaitForJobOptions{PollInterval: time.Millisecond})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClientIMPL_AsyncVariants(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	asyncResponder := func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("is_async") != "true" {
+			return httpmock.NewStringResponse(201, fmt.Sprintf(`{"id": "%s"}`, volID2)), nil
+		}
+		return httpmock.NewStringResponse(202, fmt.Sprintf(`{"id": "%s"}`, jobID)), nil
+	}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/%s/clone", volumeMockURL, volID), asyncResponder)
+	httpmock.RegisterResponder("POST", fsMockURL, asyncResponder)
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/%s", volumeGroupMockURL, volumeGroupID), asyncResponder)
+
+	name := "clone"
+	resp, err := C.CloneVolumeAsync(context.Background(), &VolumeClone{Name: &name}, volID)
+	assert.Nil(t, err)
+	assert.Equal(t, jobID, resp.ID)
+
+	resp, err = C.CreateFSAsync(context.Background(), &FsCreate{Name: name})
+	assert.Nil(t, err)
+	assert.Equal(t, jobID, resp.ID)
+
+	resp, err = C.DeleteVolumeGroupAsync(context.Background(), volumeGroupID)
+	assert.Nil(t, err)
+	assert.Equal(t, jobID, resp.ID)
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/%s", volumeGroupMockURL, volumeGroupID),
+		httpmock.NewStringResponder(204, ""))
+	_, err = C.DeleteVolumeGroupAsync(context.Background(), volumeGroupID)
+	assert.NotNil(t, err)
+}
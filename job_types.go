@@ -0,0 +1,165 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package gopowerstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dell/gopowerstore/api"
+)
+
+// JobStateEnum is a state of asynchronous job
+type JobStateEnum string
+
+const (
+	JobStateQueued              JobStateEnum = "QUEUED"
+	JobStatePending             JobStateEnum = "PENDING"
+	JobStateRunning             JobStateEnum = "RUNNING"
+	JobStateInProgress          JobStateEnum = "IN_PROGRESS"
+	JobStateCompleted           JobStateEnum = "COMPLETED"
+	JobStateFailed              JobStateEnum = "FAILED"
+	JobStateUnrecoverableFailed JobStateEnum = "UNRECOVERABLE_FAILED"
+	JobStateCancelling          JobStateEnum = "CANCELLING"
+	JobStateCancelled           JobStateEnum = "CANCELLED"
+)
+
+// IsTerminal returns true if the job will not change its state anymore
+func (s JobStateEnum) IsTerminal() bool {
+	switch s {
+	case JobStateCompleted, JobStateFailed, JobStateUnrecoverableFailed, JobStateCancelled:
+		return true
+	}
+	return false
+}
+
+// AsyncResponse is returned by requests sent with is_async=true
+type AsyncResponse struct {
+	// Unique identifier of the job which performs the request.
+	ID string `json:"id,omitempty"`
+}
+
+// Job is an asynchronous operation performed by PowerStore
+type Job struct {
+	// Unique identifier of the job.
+	ID string `json:"id,omitempty"`
+	// Action performed by the job, e.g. create or clone.
+	Action string `json:"resource_action,omitempty"`
+	// Type of the resource the job operates on.
+	Type string `json:"resource_type,omitempty"`
+	// Unique identifier of the resource the job operates on.
+	ResourceID string `json:"resource_id,omitempty"`
+	// Name of the resource the job operates on.
+	ResourceName string `json:"resource_name,omitempty"`
+	// Localized description of the job or step.
+	Description string `json:"description_l10n,omitempty"`
+	// Current state of the job, see StateEnum.
+	State string `json:"state,omitempty"`
+	// Percentage of the job completed.
+	ProgressPercentage int `json:"progress_percentage,omitempty"`
+	// Unique identifier of the parent job, set for job steps.
+	ParentID string `json:"parent_id,omitempty"`
+	// Unique identifier of the top level job.
+	RootID string `json:"root_id,omitempty"`
+	// Order of the step within the parent job.
+	StepOrder int `json:"step_order,omitempty"`
+	// Date and time when the job started.
+	StartTime string `json:"start_time,omitempty"`
+	// Date and time when the job finished.
+	EndTime string `json:"end_time,omitempty"`
+	// Estimated date and time when the job finishes.
+	EstimatedCompletionTime string `json:"estimated_completion_time,omitempty"`
+	// Response of the request performed by the job, see Result.
+	ResponseBody json.RawMessage `json:"response_body,omitempty"`
+}
+
+// Fields returns fields which must be requested to fill struct
+func (j *Job) Fields() []string {
+	return []string{
+		"id", "resource_action", "resource_type", "resource_id", "resource_name", "description_l10n", "state",
+		"progress_percentage", "parent_id", "root_id", "step_order", "start_time", "end_time",
+		"estimated_completion_time", "response_body",
+	}
+}
+
+// StateEnum returns typed state of the job
+func (j *Job) StateEnum() JobStateEnum {
+	return JobStateEnum(j.State)
+}
+
+// JobResult is a response of the request performed by the job
+type JobResult struct {
+	// Unique identifier of the created resource.
+	ID string `json:"id,omitempty"`
+	// Error messages of the failed job.
+	Messages []api.ErrorMessage `json:"messages,omitempty"`
+}
+
+// Result parses response of the request performed by the job
+func (j *Job) Result() (JobResult, error) {
+	var res JobResult
+	if len(j.ResponseBody) == 0 || string(j.ResponseBody) == "null" {
+		return res, nil
+	}
+	err := json.Unmarshal(j.ResponseBody, &res)
+	return res, err
+}
+
+// ResourceIDs returns identifiers of resources affected or created by the job
+func (j *Job) ResourceIDs() []string {
+	var ids []string
+	if j.ResourceID != "" {
+		ids = append(ids, j.ResourceID)
+	}
+	if res, err := j.Result(); err == nil && res.ID != "" && res.ID != j.ResourceID {
+		ids = append(ids, res.ID)
+	}
+	return ids
+}
+
+// WaitForJobOptions controls how WaitForJob polls the job
+type WaitForJobOptions struct {
+	// delay between job state requests, defaults to 2 seconds
+	PollInterval time.Duration
+	// called every time the job state is read, e.g. to report progress
+	OnProgress func(job Job)
+}
+
+// JobError is returned by WaitForJob when the job doesn't complete successfully
+type JobError struct {
+	Job Job
+	// error reported by the job, if any
+	Err *api.ErrorMsg
+}
+
+func (e *JobError) Error() string {
+	msg := fmt.Sprintf("job %s %s", e.Job.ID, strings.ToLower(e.Job.State))
+	if e.Err != nil && e.Err.Error() != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Err.Error())
+	}
+	return msg
+}
+
+// Unwrap allows to match job failure with sentinel errors, e.g. ErrNameAlreadyInUse
+func (e *JobError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
@@ -30,15 +30,11 @@ const (
 )
 
 func (c *ClientIMPL) callGetLimit(ctx context.Context) (resp []Limit, err error) {
-	_, err = c.APIClient().Query(
-		ctx,
-		RequestConfig{
-			Method:      "GET",
-			Endpoint:    limitURL,
-			QueryParams: getLimitDefaultQueryParams(c),
-		},
-		&resp)
-	return resp, WrapErr(err)
+	return readPaginatedData[Limit](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    limitURL,
+		QueryParams: getLimitDefaultQueryParams(c),
+	})
 }
 
 // GetMaxVolumeSize - Returns the max size of a volume supported by the array
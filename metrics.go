@@ -22,7 +22,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
+
+	"github.com/dell/gopowerstore/api"
 )
 
 const (
@@ -43,6 +44,7 @@ func (c *ClientIMPL) metricsRequest(ctx context.Context, response interface{}, e
 				EntityID: entityID,
 				Interval: string(interval),
 			},
+			Priority: api.PriorityLow,
 		},
 		response)
 	if err != nil {
@@ -59,25 +61,19 @@ func (c *ClientIMPL) mirrorTransferRate(ctx context.Context, response interface{
 	qp.RawArg("order", "timestamp.desc")
 	qp.RawArg("select", "id,timestamp,synchronization_bandwidth,mirror_bandwidth,data_remaining")
 
-	customHeader := http.Header{}
-	customHeader.Add("DELL-VISIBILITY", "Internal")
-	apiClient := c.APIClient()
-	apiClient.SetCustomHTTPHeaders(customHeader)
-
 	_, err := c.APIClient().Query(
 		ctx,
 		RequestConfig{
 			Method:      "GET",
 			Endpoint:    mirrorURL,
 			QueryParams: qp,
+			Headers:     visibilityHeaders("Internal"),
+			Priority:    api.PriorityLow,
 		},
 		response)
 	if err != nil {
 		err = WrapErr(err)
 	}
-	customHeader.Del("DELL-VISIBILITY")
-	apiClient.SetCustomHTTPHeaders(customHeader)
-
 	return err
 }
 
@@ -0,0 +1,163 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package metrics exposes operational metrics of gopowerstore client to Prometheus.
+// It is a separate module, so only its users depend on the Prometheus client.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/dell/gopowerstore/api"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultNamespace prefixes names of collected metrics
+const DefaultNamespace = "gopowerstore"
+
+var _ api.MetricsHook = &Collector{}
+
+// Collector is api.MetricsHook which keeps metrics as Prometheus collectors,
+// register it with prometheus.Registerer and pass it to ClientOptions.SetMetrics
+type Collector struct {
+	requests      *prometheus.CounterVec
+	latency       *prometheus.HistogramVec
+	inFlight      *prometheus.GaugeVec
+	throttleWait  *prometheus.HistogramVec
+	timeouts      *prometheus.CounterVec
+	relogins      prometheus.Counter
+	responseBytes *prometheus.HistogramVec
+	circuitState  prometheus.Gauge
+	circuitTrips  *prometheus.CounterVec
+}
+
+// NewCollector returns Collector with metrics prefixed by namespace, DefaultNamespace is used if it is empty
+func NewCollector(namespace string) *Collector {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	labels := []string{"method", "endpoint"}
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Number of requests sent to PowerStore API by status code, 0 means no response.",
+		}, append(labels, "code")),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of PowerStore API requests including reading the response.",
+			Buckets:   prometheus.DefBuckets,
+		}, labels),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "requests_in_flight",
+			Help:      "Number of PowerStore API requests waiting for response.",
+		}, labels),
+		throttleWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "throttle_wait_seconds",
+			Help:      "Time requests spent waiting for the rate limiter and the concurrency limit.",
+			Buckets:   prometheus.DefBuckets,
+		}, labels),
+		timeouts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "timeouts_total",
+			Help:      "Number of PowerStore API calls failed because of a timeout.",
+		}, labels),
+		relogins: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "relogins_total",
+			Help:      "Number of login sessions created to replace expired ones.",
+		}),
+		responseBytes: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "response_size_bytes",
+			Help:      "Size of PowerStore API response bodies.",
+			Buckets:   prometheus.ExponentialBuckets(256, 4, 8),
+		}, labels),
+		circuitState: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "circuit_breaker_state",
+			Help:      "State of the circuit breaker: 0 closed, 1 open, 2 half-open.",
+		}),
+		circuitTrips: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "circuit_breaker_transitions_total",
+			Help:      "Number of circuit breaker state changes by the new state.",
+		}, []string{"state"}),
+	}
+}
+
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		c.requests, c.latency, c.inFlight, c.throttleWait, c.timeouts, c.relogins, c.responseBytes,
+		c.circuitState, c.circuitTrips,
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range c.collectors() {
+		m.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range c.collectors() {
+		m.Collect(ch)
+	}
+}
+
+// RequestStarted implements api.MetricsHook
+func (c *Collector) RequestStarted(method, endpoint string) {
+	c.inFlight.WithLabelValues(method, endpoint).Inc()
+}
+
+// RequestFinished implements api.MetricsHook
+func (c *Collector) RequestFinished(method, endpoint string, status int, latency time.Duration, responseSize int64) {
+	c.inFlight.WithLabelValues(method, endpoint).Dec()
+	c.requests.WithLabelValues(method, endpoint, strconv.Itoa(status)).Inc()
+	c.latency.WithLabelValues(method, endpoint).Observe(latency.Seconds())
+	if status != 0 {
+		c.responseBytes.WithLabelValues(method, endpoint).Observe(float64(responseSize))
+	}
+}
+
+// ThrottleWaited implements api.MetricsHook
+func (c *Collector) ThrottleWaited(method, endpoint string, wait time.Duration) {
+	c.throttleWait.WithLabelValues(method, endpoint).Observe(wait.Seconds())
+}
+
+// TimedOut implements api.MetricsHook
+func (c *Collector) TimedOut(method, endpoint string) {
+	c.timeouts.WithLabelValues(method, endpoint).Inc()
+}
+
+// Relogin implements api.MetricsHook
+func (c *Collector) Relogin() {
+	c.relogins.Inc()
+}
+
+// CircuitStateChanged implements api.MetricsHook
+func (c *Collector) CircuitStateChanged(_, to api.CircuitState) {
+	c.circuitState.Set(float64(to))
+	c.circuitTrips.WithLabelValues(to.String()).Inc()
+}
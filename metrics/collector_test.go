@@ -0,0 +1,86 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dell/gopowerstore/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollector(t *testing.T) {
+	c := NewCollector("")
+	registry := prometheus.NewPedanticRegistry()
+	assert.NoError(t, registry.Register(c))
+
+	c.RequestStarted("GET", "volume")
+	c.RequestStarted("GET", "volume")
+	assert.Equal(t, float64(2), testutil.ToFloat64(c.inFlight.WithLabelValues("GET", "volume")))
+	c.RequestFinished("GET", "volume", 200, 20*time.Millisecond, 512)
+	c.RequestFinished("GET", "volume", 0, time.Second, 0)
+	c.ThrottleWaited("GET", "volume", 5*time.Millisecond)
+	c.TimedOut("GET", "volume")
+	c.Relogin()
+	c.CircuitStateChanged(api.CircuitClosed, api.CircuitOpen)
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(c.inFlight.WithLabelValues("GET", "volume")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.requests.WithLabelValues("GET", "volume", "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.requests.WithLabelValues("GET", "volume", "0")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.timeouts.WithLabelValues("GET", "volume")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.relogins))
+	assert.Equal(t, float64(api.CircuitOpen), testutil.ToFloat64(c.circuitState))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.circuitTrips.WithLabelValues("open")))
+
+	expected := `
+# HELP gopowerstore_response_size_bytes Size of PowerStore API response bodies.
+# TYPE gopowerstore_response_size_bytes histogram
+gopowerstore_response_size_bytes_bucket{endpoint="volume",method="GET",le="256"} 0
+gopowerstore_response_size_bytes_bucket{endpoint="volume",method="GET",le="1024"} 1
+gopowerstore_response_size_bytes_bucket{endpoint="volume",method="GET",le="4096"} 1
+gopowerstore_response_size_bytes_bucket{endpoint="volume",method="GET",le="16384"} 1
+gopowerstore_response_size_bytes_bucket{endpoint="volume",method="GET",le="65536"} 1
+gopowerstore_response_size_bytes_bucket{endpoint="volume",method="GET",le="262144"} 1
+gopowerstore_response_size_bytes_bucket{endpoint="volume",method="GET",le="1.048576e+06"} 1
+gopowerstore_response_size_bytes_bucket{endpoint="volume",method="GET",le="4.194304e+06"} 1
+gopowerstore_response_size_bytes_bucket{endpoint="volume",method="GET",le="+Inf"} 1
+gopowerstore_response_size_bytes_sum{endpoint="volume",method="GET"} 512
+gopowerstore_response_size_bytes_count{endpoint="volume",method="GET"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"gopowerstore_response_size_bytes"))
+	count, err := testutil.GatherAndCount(registry)
+	assert.NoError(t, err)
+	// one series of every metric and two status codes of requests_total
+	assert.Equal(t, 10, count)
+}
+
+func TestNewCollector_Namespace(t *testing.T) {
+	c := NewCollector("array")
+	c.Relogin()
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(`
+# HELP array_relogins_total Number of login sessions created to replace expired ones.
+# TYPE array_relogins_total counter
+array_relogins_total 1
+`), "array_relogins_total"))
+}
@@ -154,6 +154,25 @@ func TestClientIMPL_VolumeMirrorTransferRate(t *testing.T) {
 	assert.Equal(t, volumeID, volMirr[0].ID)
 }
 
+func TestClientIMPL_VolumeMirrorTransferRate_Headers(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	customHeaders := http.Header{}
+	customHeaders.Set("foo", "bar")
+	C.SetCustomHTTPHeaders(customHeaders)
+	defer C.SetCustomHTTPHeaders(http.Header{})
+
+	httpmock.RegisterResponder("GET", metricsMockVolMirrURL,
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "Internal", req.Header.Get(visibilityHeader))
+			assert.Equal(t, "bar", req.Header.Get("foo"))
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`[{"id": "%s"}]`, volumeID)), nil
+		})
+	_, err := C.VolumeMirrorTransferRate(context.Background(), volumeID)
+	assert.Nil(t, err)
+	assert.Equal(t, customHeaders, C.GetCustomHTTPHeaders())
+}
+
 func TestClientIMPL_PerformanceMetricsByCluster(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
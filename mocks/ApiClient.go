@@ -17,6 +17,24 @@ type ApiClient struct {
 	mock.Mock
 }
 
+// Close provides a mock function with given fields: ctx
+func (_m *ApiClient) Close(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetCustomHTTPHeaders provides a mock function with given fields:
 func (_m *ApiClient) GetCustomHTTPHeaders() http.Header {
 	ret := _m.Called()
@@ -37,6 +55,24 @@ func (_m *ApiClient) GetCustomHTTPHeaders() http.Header {
 	return r0
 }
 
+// Ping provides a mock function with given fields: ctx
+func (_m *ApiClient) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Query provides a mock function with given fields: ctx, cfg, resp
 func (_m *ApiClient) Query(ctx context.Context, cfg api.RequestConfigRenderer, resp interface{}) (api.RespMeta, error) {
 	ret := _m.Called(ctx, cfg, resp)
@@ -153,6 +189,17 @@ func (_m *ApiClient) TraceID(ctx context.Context) string {
 	return r0
 }
 
+// Use provides a mock function with given fields: middlewares
+func (_m *ApiClient) Use(middlewares ...api.Middleware) {
+	_va := make([]interface{}, len(middlewares))
+	for _i := range middlewares {
+		_va[_i] = middlewares[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
+}
+
 // NewApiClient creates a new instance of ApiClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewApiClient(t interface {
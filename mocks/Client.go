@@ -179,6 +179,52 @@ func (_m *Client) CloneVolume(ctx context.Context, createParams *gopowerstore.Vo
 	return r0, r1
 }
 
+// CloneVolumeAsync provides a mock function with given fields: ctx, createParams, volID
+func (_m *Client) CloneVolumeAsync(ctx context.Context, createParams *gopowerstore.VolumeClone, volID string) (gopowerstore.AsyncResponse, error) {
+	ret := _m.Called(ctx, createParams, volID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloneVolumeAsync")
+	}
+
+	var r0 gopowerstore.AsyncResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gopowerstore.VolumeClone, string) (gopowerstore.AsyncResponse, error)); ok {
+		return rf(ctx, createParams, volID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gopowerstore.VolumeClone, string) gopowerstore.AsyncResponse); ok {
+		r0 = rf(ctx, createParams, volID)
+	} else {
+		r0 = ret.Get(0).(gopowerstore.AsyncResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gopowerstore.VolumeClone, string) error); ok {
+		r1 = rf(ctx, createParams, volID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Close provides a mock function with given fields: ctx
+func (_m *Client) Close(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ComputeDifferences provides a mock function with given fields: ctx, snapdiffParams, volID
 func (_m *Client) ComputeDifferences(ctx context.Context, snapdiffParams *gopowerstore.VolumeComputeDifferences, volID string) (gopowerstore.VolumeComputeDifferencesResponse, error) {
 	ret := _m.Called(ctx, snapdiffParams, volID)
@@ -441,6 +487,34 @@ func (_m *Client) CreateFS(ctx context.Context, createParams *gopowerstore.FsCre
 	return r0, r1
 }
 
+// CreateFSAsync provides a mock function with given fields: ctx, createParams
+func (_m *Client) CreateFSAsync(ctx context.Context, createParams *gopowerstore.FsCreate) (gopowerstore.AsyncResponse, error) {
+	ret := _m.Called(ctx, createParams)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFSAsync")
+	}
+
+	var r0 gopowerstore.AsyncResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gopowerstore.FsCreate) (gopowerstore.AsyncResponse, error)); ok {
+		return rf(ctx, createParams)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gopowerstore.FsCreate) gopowerstore.AsyncResponse); ok {
+		r0 = rf(ctx, createParams)
+	} else {
+		r0 = ret.Get(0).(gopowerstore.AsyncResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gopowerstore.FsCreate) error); ok {
+		r1 = rf(ctx, createParams)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateFsFromSnapshot provides a mock function with given fields: ctx, createParams, snapID
 func (_m *Client) CreateFsFromSnapshot(ctx context.Context, createParams *gopowerstore.FsClone, snapID string) (gopowerstore.CreateResponse, error) {
 	ret := _m.Called(ctx, createParams, snapID)
@@ -1309,6 +1383,34 @@ func (_m *Client) DeleteVolumeGroup(ctx context.Context, id string) (gopowerstor
 	return r0, r1
 }
 
+// DeleteVolumeGroupAsync provides a mock function with given fields: ctx, id
+func (_m *Client) DeleteVolumeGroupAsync(ctx context.Context, id string) (gopowerstore.AsyncResponse, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVolumeGroupAsync")
+	}
+
+	var r0 gopowerstore.AsyncResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (gopowerstore.AsyncResponse, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) gopowerstore.AsyncResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(gopowerstore.AsyncResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DetachVolumeFromHost provides a mock function with given fields: ctx, hostID, detachParams
 func (_m *Client) DetachVolumeFromHost(ctx context.Context, hostID string, detachParams *gopowerstore.HostVolumeDetach) (gopowerstore.EmptyResponse, error) {
 	ret := _m.Called(ctx, hostID, detachParams)
@@ -1535,6 +1637,34 @@ func (_m *Client) GetApplianceByName(ctx context.Context, name string) (gopowers
 	return r0, r1
 }
 
+// GetArrayVersion provides a mock function with given fields: ctx
+func (_m *Client) GetArrayVersion(ctx context.Context) (api.ArrayVersion, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArrayVersion")
+	}
+
+	var r0 api.ArrayVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (api.ArrayVersion, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) api.ArrayVersion); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(api.ArrayVersion)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCapacity provides a mock function with given fields: ctx
 func (_m *Client) GetCapacity(ctx context.Context) (int64, error) {
 	ret := _m.Called(ctx)
@@ -1783,6 +1913,43 @@ func (_m *Client) GetFsByFilter(ctx context.Context, filter map[string]string) (
 	return r0, r1
 }
 
+// GetFsByFilters provides a mock function with given fields: ctx, filters
+func (_m *Client) GetFsByFilters(ctx context.Context, filters ...api.Filter) ([]gopowerstore.FileSystem, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFsByFilters")
+	}
+
+	var r0 []gopowerstore.FileSystem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...api.Filter) ([]gopowerstore.FileSystem, error)); ok {
+		return rf(ctx, filters...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...api.Filter) []gopowerstore.FileSystem); ok {
+		r0 = rf(ctx, filters...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gopowerstore.FileSystem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...api.Filter) error); ok {
+		r1 = rf(ctx, filters...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFsSnapshot provides a mock function with given fields: ctx, snapID
 func (_m *Client) GetFsSnapshot(ctx context.Context, snapID string) (gopowerstore.FileSystem, error) {
 	ret := _m.Called(ctx, snapID)
@@ -2131,6 +2298,64 @@ func (_m *Client) GetHosts(ctx context.Context) ([]gopowerstore.Host, error) {
 	return r0, r1
 }
 
+// GetJob provides a mock function with given fields: ctx, id
+func (_m *Client) GetJob(ctx context.Context, id string) (gopowerstore.Job, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJob")
+	}
+
+	var r0 gopowerstore.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (gopowerstore.Job, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) gopowerstore.Job); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(gopowerstore.Job)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJobSteps provides a mock function with given fields: ctx, id
+func (_m *Client) GetJobSteps(ctx context.Context, id string) ([]gopowerstore.Job, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJobSteps")
+	}
+
+	var r0 []gopowerstore.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]gopowerstore.Job, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []gopowerstore.Job); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gopowerstore.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMaxVolumeSize provides a mock function with given fields: ctx
 func (_m *Client) GetMaxVolumeSize(ctx context.Context) (int64, error) {
 	ret := _m.Called(ctx)
@@ -2359,6 +2584,43 @@ func (_m *Client) GetNFSExportByName(ctx context.Context, name string) (gopowers
 	return r0, r1
 }
 
+// GetNFSExportsByFilters provides a mock function with given fields: ctx, filters
+func (_m *Client) GetNFSExportsByFilters(ctx context.Context, filters ...api.Filter) ([]gopowerstore.NFSExport, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNFSExportsByFilters")
+	}
+
+	var r0 []gopowerstore.NFSExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...api.Filter) ([]gopowerstore.NFSExport, error)); ok {
+		return rf(ctx, filters...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...api.Filter) []gopowerstore.NFSExport); ok {
+		r0 = rf(ctx, filters...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gopowerstore.NFSExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...api.Filter) error); ok {
+		r1 = rf(ctx, filters...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNfsServer provides a mock function with given fields: ctx, id
 func (_m *Client) GetNfsServer(ctx context.Context, id string) (gopowerstore.NFSServerInstance, error) {
 	ret := _m.Called(ctx, id)
@@ -2559,6 +2821,43 @@ func (_m *Client) GetRemoteSystems(ctx context.Context, filters map[string]strin
 	return r0, r1
 }
 
+// GetRemoteSystemsByFilters provides a mock function with given fields: ctx, filters
+func (_m *Client) GetRemoteSystemsByFilters(ctx context.Context, filters ...api.Filter) ([]gopowerstore.RemoteSystem, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRemoteSystemsByFilters")
+	}
+
+	var r0 []gopowerstore.RemoteSystem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...api.Filter) ([]gopowerstore.RemoteSystem, error)); ok {
+		return rf(ctx, filters...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...api.Filter) []gopowerstore.RemoteSystem); ok {
+		r0 = rf(ctx, filters...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gopowerstore.RemoteSystem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...api.Filter) error); ok {
+		r1 = rf(ctx, filters...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetReplicationRule provides a mock function with given fields: ctx, id
 func (_m *Client) GetReplicationRule(ctx context.Context, id string) (gopowerstore.ReplicationRule, error) {
 	ret := _m.Called(ctx, id)
@@ -2787,6 +3086,43 @@ func (_m *Client) GetSMBShares(ctx context.Context, args map[string]string) ([]g
 	return r0, r1
 }
 
+// GetSMBSharesByFilters provides a mock function with given fields: ctx, filters
+func (_m *Client) GetSMBSharesByFilters(ctx context.Context, filters ...api.Filter) ([]gopowerstore.SMBShare, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSMBSharesByFilters")
+	}
+
+	var r0 []gopowerstore.SMBShare
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...api.Filter) ([]gopowerstore.SMBShare, error)); ok {
+		return rf(ctx, filters...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...api.Filter) []gopowerstore.SMBShare); ok {
+		r0 = rf(ctx, filters...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gopowerstore.SMBShare)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...api.Filter) error); ok {
+		r1 = rf(ctx, filters...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSnapshot provides a mock function with given fields: ctx, snapID
 func (_m *Client) GetSnapshot(ctx context.Context, snapID string) (gopowerstore.Volume, error) {
 	ret := _m.Called(ctx, snapID)
@@ -3451,6 +3787,36 @@ func (_m *Client) ListFS(ctx context.Context) ([]gopowerstore.FileSystem, error)
 	return r0, r1
 }
 
+// ListJobs provides a mock function with given fields: ctx, filter
+func (_m *Client) ListJobs(ctx context.Context, filter map[string]string) ([]gopowerstore.Job, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListJobs")
+	}
+
+	var r0 []gopowerstore.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]string) ([]gopowerstore.Job, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]string) []gopowerstore.Job); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gopowerstore.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, map[string]string) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ModifyFS provides a mock function with given fields: ctx, modifyParams, volID
 func (_m *Client) ModifyFS(ctx context.Context, modifyParams *gopowerstore.FSModify, volID string) (gopowerstore.EmptyResponse, error) {
 	ret := _m.Called(ctx, modifyParams, volID)
@@ -4417,6 +4783,24 @@ func (_m *Client) PerformanceMetricsSmbByNode(ctx context.Context, entityID stri
 	return r0, r1
 }
 
+// Ping provides a mock function with given fields: ctx
+func (_m *Client) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveMembersFromVolumeGroup provides a mock function with given fields: ctx, params, id
 func (_m *Client) RemoveMembersFromVolumeGroup(ctx context.Context, params *gopowerstore.VolumeGroupMembers, id string) (gopowerstore.EmptyResponse, error) {
 	ret := _m.Called(ctx, params, id)
@@ -4445,6 +4829,24 @@ func (_m *Client) RemoveMembersFromVolumeGroup(ctx context.Context, params *gopo
 	return r0, r1
 }
 
+// RequireCapability provides a mock function with given fields: ctx, capability
+func (_m *Client) RequireCapability(ctx context.Context, capability gopowerstore.Capability) error {
+	ret := _m.Called(ctx, capability)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequireCapability")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, gopowerstore.Capability) error); ok {
+		r0 = rf(ctx, capability)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetCustomHTTPHeaders provides a mock function with given fields: headers
 func (_m *Client) SetCustomHTTPHeaders(headers http.Header) {
 	_m.Called(headers)
@@ -4771,6 +5173,34 @@ func (_m *Client) VolumeMirrorTransferRate(ctx context.Context, entityID string)
 	return r0, r1
 }
 
+// WaitForJob provides a mock function with given fields: ctx, id, opts
+func (_m *Client) WaitForJob(ctx context.Context, id string, opts *gopowerstore.WaitForJobOptions) (gopowerstore.Job, error) {
+	ret := _m.Called(ctx, id, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WaitForJob")
+	}
+
+	var r0 gopowerstore.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *gopowerstore.WaitForJobOptions) (gopowerstore.Job, error)); ok {
+		return rf(ctx, id, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *gopowerstore.WaitForJobOptions) gopowerstore.Job); ok {
+		r0 = rf(ctx, id, opts)
+	} else {
+		r0 = ret.Get(0).(gopowerstore.Job)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *gopowerstore.WaitForJobOptions) error); ok {
+		r1 = rf(ctx, id, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // WearMetricsByDrive provides a mock function with given fields: ctx, entityID, interval
 func (_m *Client) WearMetricsByDrive(ctx context.Context, entityID string, interval gopowerstore.MetricsIntervalEnum) ([]gopowerstore.WearMetricsByDriveResponse, error) {
 	ret := _m.Called(ctx, entityID, interval)
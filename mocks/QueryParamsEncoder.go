@@ -50,6 +50,32 @@ func (_m *QueryParamsEncoder) Encode() string {
 	return r0
 }
 
+// Filter provides a mock function with given fields: _a0
+func (_m *QueryParamsEncoder) Filter(_a0 ...api.Filter) api.QueryParamsEncoder {
+	_va := make([]interface{}, len(_a0))
+	for _i := range _a0 {
+		_va[_i] = _a0[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Filter")
+	}
+
+	var r0 api.QueryParamsEncoder
+	if rf, ok := ret.Get(0).(func(...api.Filter) api.QueryParamsEncoder); ok {
+		r0 = rf(_a0...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(api.QueryParamsEncoder)
+		}
+	}
+
+	return r0
+}
+
 // Limit provides a mock function with given fields: _a0
 func (_m *QueryParamsEncoder) Limit(_a0 int) api.QueryParamsEncoder {
 	ret := _m.Called(_a0)
@@ -57,29 +57,16 @@ func (c *ClientIMPL) GetNFSExport(ctx context.Context, id string) (resp NFSExpor
 
 // GetNFSExportByFilter query and return NFS export by filter
 func (c *ClientIMPL) GetNFSExportByFilter(ctx context.Context, filter map[string]string) ([]NFSExport, error) {
-	var result []NFSExport
-	err := c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []NFSExport
-		qp := getNFSExportDefaultQueryParams(c)
-		for k, v := range filter {
-			qp.RawArg(k, v)
-		}
-		qp.Offset(offset).Limit(paginationDefaultPageSize)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    nfsURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			result = append(result, page...)
-		}
-		return meta, err
+	return c.GetNFSExportsByFilters(ctx, api.RawFilters(filter)...)
+}
+
+// GetNFSExportsByFilters query and return all NFS exports matching filters
+func (c *ClientIMPL) GetNFSExportsByFilters(ctx context.Context, filters ...api.Filter) ([]NFSExport, error) {
+	return readPaginatedData[NFSExport](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    nfsURL,
+		QueryParams: getNFSExportDefaultQueryParams(c).Filter(filters...),
 	})
-	return result, err
 }
 
 // GetNFSExportByName query and return specific NFS export by name
@@ -0,0 +1,406 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package gopowerstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ClientRegistry defaults
+const (
+	registryDefaultHealthCheckInterval = time.Minute
+	registryDefaultHealthCheckTimeout  = 30 * time.Second
+	registryDefaultFailureThreshold    = 1
+	// separator of parts of resource ids which carry the array, e.g. <volume id>/<global id>/<protocol>
+	resourceIDSeparator = "/"
+)
+
+// Errors returned by ClientRegistry
+var (
+	ErrArrayNotFound  = errors.New("array is not registered")
+	ErrArrayAmbiguous = errors.New("identifier matches several arrays")
+	ErrNoDefaultArray = errors.New("no default array is registered")
+	ErrArrayMismatch  = errors.New("endpoint serves an array with another global id")
+)
+
+// ArrayConfig describes an array managed by ClientRegistry
+type ArrayConfig struct {
+	// unique name of the array in the registry, Endpoint is used when empty
+	Name string
+	// API URL, e.g. https://10.0.0.1/api/rest
+	Endpoint string
+	// global id of the cluster, e.g. PS4ebb8d4e8488, it is discovered by health checks when empty
+	GlobalID string
+	Username string
+	Password string
+	// the array used for names and resource ids which don't identify an array
+	IsDefault bool
+	// options of the client created for the array, defaults are used when nil
+	Options *ClientOptions
+	// client of the array, when set it is used instead of creating a client from the config
+	Client Client
+}
+
+// ArrayHealth is the result of the latest health check of an array
+type ArrayHealth struct {
+	// false until the first successful check
+	Healthy bool
+	// time of the latest check, zero if the array wasn't checked yet
+	CheckedAt time.Time
+	// error of the latest check
+	Err error
+	// number of failed checks since the latest successful one
+	ConsecutiveFailures int
+	// cluster as returned by the latest successful check
+	Cluster Cluster
+	// release version of the software installed on the cluster
+	SoftwareVersion string
+}
+
+// ArrayStatus describes a registered array
+type ArrayStatus struct {
+	Name      string
+	Endpoint  string
+	GlobalID  string
+	ClusterID string
+	IsDefault bool
+	Health    ArrayHealth
+}
+
+// HealthCheckOptions configure background health checks of ClientRegistry
+type HealthCheckOptions struct {
+	// time between checks, one minute when zero
+	Interval time.Duration
+	// timeout of a check of a single array, 30 seconds when zero
+	Timeout time.Duration
+	// number of consecutive failed checks after which a healthy array is marked unhealthy, one when zero
+	FailureThreshold int
+}
+
+type registeredArray struct {
+	config ArrayConfig
+	client Client
+	health ArrayHealth
+}
+
+// ClientRegistry holds clients of several arrays, resolves arrays by name, global id or cluster id
+// and tracks their health
+type ClientRegistry struct {
+	mu     sync.RWMutex
+	arrays []*registeredArray
+	// index of the default array, -1 if there is none
+	defaultIndex     int
+	failureThreshold int
+
+	stopChecks context.CancelFunc
+	checksDone chan struct{}
+}
+
+// NewClientRegistry creates clients of the arrays, the only array is the default one if none is marked
+func NewClientRegistry(configs []ArrayConfig) (*ClientRegistry, error) {
+	r := &ClientRegistry{defaultIndex: -1, failureThreshold: registryDefaultFailureThreshold}
+	names := map[string]bool{}
+	for i, cfg := range configs {
+		name := arrayName(cfg)
+		if name == "" {
+			return nil, fmt.Errorf("array %d has neither name nor endpoint", i)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("array %s is registered twice", name)
+		}
+		names[name] = true
+		if cfg.IsDefault {
+			if r.defaultIndex >= 0 {
+				return nil, fmt.Errorf("arrays %s and %s are both marked as default", arrayName(configs[r.defaultIndex]), name)
+			}
+			r.defaultIndex = i
+		}
+	}
+	for _, cfg := range configs {
+		cfg.Name = arrayName(cfg)
+		client := cfg.Client
+		if client == nil {
+			options := cfg.Options
+			if options == nil {
+				options = NewClientOptions()
+			}
+			var err error
+			if client, err = NewClientWithArgs(cfg.Endpoint, cfg.Username, cfg.Password, options); err != nil {
+				err = fmt.Errorf("failed to create client of array %s: %w", cfg.Name, err)
+				// clients which are already created are not returned to the caller, close their sessions
+				return nil, errors.Join(err, r.Close(context.Background()))
+			}
+		}
+		r.arrays = append(r.arrays, &registeredArray{config: cfg, client: client})
+	}
+	if r.defaultIndex < 0 && len(r.arrays) == 1 {
+		r.defaultIndex = 0
+		r.arrays[0].config.IsDefault = true
+	}
+	return r, nil
+}
+
+// arrayName returns name of the array in the registry
+func arrayName(cfg ArrayConfig) string {
+	if cfg.Name != "" {
+		return cfg.Name
+	}
+	return cfg.Endpoint
+}
+
+// Client returns client of the array with the name, global id or cluster id
+func (r *ClientRegistry) Client(id string) (Client, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	a, err := r.resolve(id)
+	if err != nil {
+		return nil, err
+	}
+	return a.client, nil
+}
+
+// Default returns client of the default array
+func (r *ClientRegistry) Default() (Client, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.defaultIndex < 0 {
+		return nil, ErrNoDefaultArray
+	}
+	return r.arrays[r.defaultIndex].client, nil
+}
+
+// ClientForResource returns client of the array which owns the resource and the id of the resource on the array.
+// Resource ids may carry the array as <id>/<array>[/<protocol>] like volume handles of CSI drivers,
+// where array is a name, global id or cluster id. Resources with plain ids belong to the default array.
+func (r *ClientRegistry) ClientForResource(resourceID string) (Client, string, error) {
+	parts := strings.Split(resourceID, resourceIDSeparator)
+	if len(parts) == 1 {
+		client, err := r.Default()
+		return client, resourceID, err
+	}
+	client, err := r.Client(parts[1])
+	return client, parts[0], err
+}
+
+// resolve finds the array by name, then by global id and then by cluster id
+func (r *ClientRegistry) resolve(id string) (*registeredArray, error) {
+	matchers := []func(a *registeredArray) bool{
+		func(a *registeredArray) bool { return a.config.Name == id },
+		func(a *registeredArray) bool { return strings.EqualFold(a.globalID(), id) },
+		func(a *registeredArray) bool { return a.health.Cluster.ID == id },
+	}
+	for _, match := range matchers {
+		var found []*registeredArray
+		for _, a := range r.arrays {
+			if id != "" && match(a) {
+				found = append(found, a)
+			}
+		}
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return found[0], nil
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrArrayAmbiguous, id)
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrArrayNotFound, id)
+}
+
+// globalID returns configured global id or the discovered one
+func (a *registeredArray) globalID() string {
+	if a.config.GlobalID != "" {
+		return a.config.GlobalID
+	}
+	return a.health.Cluster.GlobalID
+}
+
+func (a *registeredArray) status() ArrayStatus {
+	return ArrayStatus{
+		Name:      a.config.Name,
+		Endpoint:  a.config.Endpoint,
+		GlobalID:  a.globalID(),
+		ClusterID: a.health.Cluster.ID,
+		IsDefault: a.config.IsDefault,
+		Health:    a.health,
+	}
+}
+
+// Arrays returns status of all arrays in the order they were registered
+func (r *ClientRegistry) Arrays() []ArrayStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	res := make([]ArrayStatus, 0, len(r.arrays))
+	for _, a := range r.arrays {
+		res = append(res, a.status())
+	}
+	return res
+}
+
+// Status returns status of the array with the name, global id or cluster id
+func (r *ClientRegistry) Status(id string) (ArrayStatus, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	a, err := r.resolve(id)
+	if err != nil {
+		return ArrayStatus{}, err
+	}
+	return a.status(), nil
+}
+
+// CheckHealth checks all arrays concurrently and discovers their cluster and global ids,
+// it returns errors of arrays which failed the check
+func (r *ClientRegistry) CheckHealth(ctx context.Context) error {
+	return r.checkAll(ctx, registryDefaultHealthCheckTimeout)
+}
+
+func (r *ClientRegistry) checkAll(ctx context.Context, timeout time.Duration) error {
+	r.mu.RLock()
+	arrays := append([]*registeredArray(nil), r.arrays...)
+	r.mu.RUnlock()
+
+	errs := make([]error, len(arrays))
+	var wg sync.WaitGroup
+	for i, a := range arrays {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			if err := r.check(checkCtx, a); err != nil {
+				errs[i] = fmt.Errorf("array %s: %w", a.config.Name, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// check queries cluster and installed software of the array and updates its health
+func (r *ClientRegistry) check(ctx context.Context, a *registeredArray) error {
+	cluster, err := a.client.GetCluster(ctx)
+	var version string
+	if err == nil {
+		var software []SoftwareInstalled
+		software, err = a.client.GetSoftwareInstalled(ctx)
+		for _, s := range software {
+			if s.IsCluster {
+				version = s.ReleaseVersion
+			}
+		}
+	}
+	if err == nil && a.config.GlobalID != "" && cluster.GlobalID != "" &&
+		!strings.EqualFold(a.config.GlobalID, cluster.GlobalID) {
+		err = fmt.Errorf("%w: expected %s, got %s", ErrArrayMismatch, a.config.GlobalID, cluster.GlobalID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	wasHealthy := a.health.Healthy
+	a.health.CheckedAt = time.Now()
+	a.health.Err = err
+	if err != nil {
+		a.health.ConsecutiveFailures++
+		if a.health.ConsecutiveFailures >= r.failureThreshold {
+			a.health.Healthy = false
+		}
+	} else {
+		a.health.ConsecutiveFailures = 0
+		a.health.Healthy = true
+		a.health.Cluster = cluster
+		a.health.SoftwareVersion = version
+	}
+	switch {
+	case wasHealthy && !a.health.Healthy:
+		log.Warnf("PowerStore array %s is unhealthy: %s", a.config.Name, err.Error())
+	case !wasHealthy && a.health.Healthy:
+		log.Infof("PowerStore array %s is healthy, cluster %s, version %s", a.config.Name, cluster.Name, version)
+	}
+	return err
+}
+
+// StartHealthChecks checks all arrays right away and then periodically until ctx is done or Close is called,
+// calling it again restarts checks with new options
+func (r *ClientRegistry) StartHealthChecks(ctx context.Context, opts HealthCheckOptions) {
+	r.stopHealthChecks()
+	if opts.Interval <= 0 {
+		opts.Interval = registryDefaultHealthCheckInterval
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = registryDefaultHealthCheckTimeout
+	}
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = registryDefaultFailureThreshold
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	r.mu.Lock()
+	r.failureThreshold = opts.FailureThreshold
+	r.stopChecks = cancel
+	r.checksDone = done
+	r.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+		for {
+			_ = r.checkAll(ctx, opts.Timeout)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (r *ClientRegistry) stopHealthChecks() {
+	r.mu.Lock()
+	stop, done := r.stopChecks, r.checksDone
+	r.stopChecks, r.checksDone = nil, nil
+	r.mu.Unlock()
+	if stop != nil {
+		stop()
+		<-done
+	}
+}
+
+// Close stops health checks and closes sessions of all clients
+func (r *ClientRegistry) Close(ctx context.Context) error {
+	r.stopHealthChecks()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var errs []error
+	for _, a := range r.arrays {
+		if err := a.client.Close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("array %s: %w", a.config.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
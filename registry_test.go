@@ -0,0 +1,232 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package gopowerstore
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registryTestClient serves cluster and software_installed of a single array
+type registryTestClient struct {
+	Client
+	mu      sync.Mutex
+	cluster Cluster
+	version string
+	err     error
+	closed  bool
+}
+
+func (c *registryTestClient) GetCluster(_ context.Context) (Cluster, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cluster, c.err
+}
+
+func (c *registryTestClient) GetSoftwareInstalled(_ context.Context) ([]SoftwareInstalled, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return []SoftwareInstalled{{IsCluster: true, ReleaseVersion: c.version}}, c.err
+}
+
+func (c *registryTestClient) Close(_ context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func (c *registryTestClient) setErr(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+func newTestRegistry(t *testing.T) (*ClientRegistry, *registryTestClient, *registryTestClient) {
+	a := &registryTestClient{cluster: Cluster{ID: "0", GlobalID: "PS000000000001", Name: "a"}, version: "4.0.0.0"}
+	b := &registryTestClient{cluster: Cluster{ID: "0", GlobalID: "PS000000000002", Name: "b"}, version: "4.1.0.0"}
+	r, err := NewClientRegistry([]ArrayConfig{
+		{Name: "array-a", GlobalID: "PS000000000001", Client: a, IsDefault: true},
+		{Name: "array-b", Client: b},
+	})
+	require.NoError(t, err)
+	return r, a, b
+}
+
+func TestNewClientRegistry(t *testing.T) {
+	_, err := NewClientRegistry([]ArrayConfig{{Name: "a", Endpoint: "https://a/api/rest"}, {Name: "a", Endpoint: "https://b/api/rest"}})
+	assert.ErrorContains(t, err, "registered twice")
+
+	_, err = NewClientRegistry([]ArrayConfig{
+		{Endpoint: "https://a/api/rest", IsDefault: true},
+		{Endpoint: "https://b/api/rest", IsDefault: true},
+	})
+	assert.ErrorContains(t, err, "both marked as default")
+
+	_, err = NewClientRegistry([]ArrayConfig{{Endpoint: "https://a/api/rest", Username: "admin"}})
+	assert.ErrorContains(t, err, "failed to create client of array https://a/api/rest")
+
+	// clients created before the failure are closed
+	a := &registryTestClient{}
+	_, err = NewClientRegistry([]ArrayConfig{
+		{Name: "array-a", Client: a},
+		{Name: "array-b", Endpoint: "https://b/api/rest", Username: "admin"},
+	})
+	assert.ErrorContains(t, err, "failed to create client of array array-b")
+	assert.True(t, a.closed)
+
+	r, err := NewClientRegistry([]ArrayConfig{{Endpoint: "https://a/api/rest", Username: "admin", Password: "password"}})
+	require.NoError(t, err)
+	arrays := r.Arrays()
+	require.Len(t, arrays, 1)
+	assert.Equal(t, "https://a/api/rest", arrays[0].Name)
+	assert.True(t, arrays[0].IsDefault)
+	_, err = r.Default()
+	assert.NoError(t, err)
+}
+
+func TestClientRegistry_Client(t *testing.T) {
+	r, a, b := newTestRegistry(t)
+
+	c, err := r.Client("array-b")
+	require.NoError(t, err)
+	assert.Same(t, b, c)
+	c, err = r.Client("ps000000000001")
+	require.NoError(t, err)
+	assert.Same(t, a, c)
+
+	// global id of array-b is unknown until discovery
+	_, err = r.Client("PS000000000002")
+	assert.ErrorIs(t, err, ErrArrayNotFound)
+	require.NoError(t, r.CheckHealth(context.Background()))
+	c, err = r.Client("PS000000000002")
+	require.NoError(t, err)
+	assert.Same(t, b, c)
+
+	// both clusters have id 0
+	_, err = r.Client("0")
+	assert.ErrorIs(t, err, ErrArrayAmbiguous)
+
+	c, err = r.Default()
+	require.NoError(t, err)
+	assert.Same(t, a, c)
+}
+
+func TestClientRegistry_ClientForResource(t *testing.T) {
+	r, a, b := newTestRegistry(t)
+	require.NoError(t, r.CheckHealth(context.Background()))
+
+	c, id, err := r.ClientForResource("vol-1")
+	require.NoError(t, err)
+	assert.Same(t, a, c)
+	assert.Equal(t, "vol-1", id)
+
+	c, id, err = r.ClientForResource("vol-2/PS000000000002/scsi")
+	require.NoError(t, err)
+	assert.Same(t, b, c)
+	assert.Equal(t, "vol-2", id)
+
+	_, _, err = r.ClientForResource("vol-3/PS000000000003")
+	assert.ErrorIs(t, err, ErrArrayNotFound)
+
+	r, err = NewClientRegistry([]ArrayConfig{{Name: "a", Client: a}, {Name: "b", Client: b}})
+	require.NoError(t, err)
+	_, _, err = r.ClientForResource("vol-1")
+	assert.ErrorIs(t, err, ErrNoDefaultArray)
+}
+
+func TestClientRegistry_CheckHealth(t *testing.T) {
+	r, a, b := newTestRegistry(t)
+	status, err := r.Status("array-a")
+	require.NoError(t, err)
+	assert.False(t, status.Health.Healthy)
+	assert.True(t, status.Health.CheckedAt.IsZero())
+
+	b.setErr(errors.New("connection refused"))
+	err = r.CheckHealth(context.Background())
+	assert.ErrorContains(t, err, "array array-b: connection refused")
+
+	status, err = r.Status("array-a")
+	require.NoError(t, err)
+	assert.True(t, status.Health.Healthy)
+	assert.Equal(t, "4.0.0.0", status.Health.SoftwareVersion)
+	assert.Equal(t, "0", status.ClusterID)
+	status, err = r.Status("array-b")
+	require.NoError(t, err)
+	assert.False(t, status.Health.Healthy)
+	assert.Equal(t, 1, status.Health.ConsecutiveFailures)
+
+	// endpoint of array-a now serves another cluster
+	a.mu.Lock()
+	a.cluster.GlobalID = "PS000000000009"
+	a.mu.Unlock()
+	b.setErr(nil)
+	err = r.CheckHealth(context.Background())
+	assert.ErrorIs(t, err, ErrArrayMismatch)
+	arrays := r.Arrays()
+	assert.False(t, arrays[0].Health.Healthy)
+	assert.True(t, arrays[1].Health.Healthy)
+	assert.Equal(t, "PS000000000002", arrays[1].GlobalID)
+}
+
+func TestClientRegistry_StartHealthChecks(t *testing.T) {
+	r, _, b := newTestRegistry(t)
+	b.setErr(errors.New("connection refused"))
+	r.StartHealthChecks(context.Background(), HealthCheckOptions{Interval: 10 * time.Millisecond, FailureThreshold: 2})
+
+	assert.Eventually(t, func() bool {
+		status, _ := r.Status("array-b")
+		return status.Health.ConsecutiveFailures >= 2
+	}, time.Second, 5*time.Millisecond)
+	b.setErr(nil)
+	assert.Eventually(t, func() bool {
+		status, _ := r.Status("array-b")
+		return status.Health.Healthy
+	}, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, r.Close(context.Background()))
+
+	// healthy array stays healthy until the threshold is reached
+	b.setErr(errors.New("timeout"))
+	assert.Error(t, r.CheckHealth(context.Background()))
+	status, _ := r.Status("array-b")
+	assert.True(t, status.Health.Healthy)
+	assert.Equal(t, 1, status.Health.ConsecutiveFailures)
+	assert.Error(t, r.CheckHealth(context.Background()))
+	status, _ = r.Status("array-b")
+	assert.False(t, status.Health.Healthy)
+}
+
+func TestClientRegistry_Close(t *testing.T) {
+	r, a, b := newTestRegistry(t)
+	r.StartHealthChecks(context.Background(), HealthCheckOptions{Interval: time.Millisecond})
+	require.NoError(t, r.Close(context.Background()))
+	assert.True(t, a.closed)
+	assert.True(t, b.closed)
+
+	checkedAt := r.Arrays()[0].Health.CheckedAt
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, checkedAt, r.Arrays()[0].Health.CheckedAt)
+}
@@ -19,8 +19,6 @@ package gopowerstore
 import (
 	"context"
 	"fmt"
-
-	"github.com/dell/gopowerstore/api"
 )
 
 type ActionType string
@@ -171,28 +169,14 @@ func (c *ClientIMPL) GetProtectionPolicy(ctx context.Context, id string) (resp P
 
 // GetProtectionPolicies returns a list of protection policies
 func (c *ClientIMPL) GetProtectionPolicies(ctx context.Context) ([]ProtectionPolicy, error) {
-	var result []ProtectionPolicy
-	err := c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []ProtectionPolicy
-		policy := ProtectionPolicy{}
-		qp := c.APIClient().QueryParamsWithFields(&policy)
-		qp.Order("name")
-		qp.Offset(offset).Limit(paginationDefaultPageSize)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    policyURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			result = append(result, page...)
-		}
-		return meta, err
+	policy := ProtectionPolicy{}
+	qp := c.APIClient().QueryParamsWithFields(&policy)
+	qp.Order("name")
+	return readPaginatedData[ProtectionPolicy](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    policyURL,
+		QueryParams: qp,
 	})
-	return result, err
 }
 
 func (c *ClientIMPL) GetReplicationSessionByLocalResourceID(ctx context.Context, id string) (resp ReplicationSession, err error) {
@@ -296,26 +280,12 @@ func (c *ClientIMPL) ModifyReplicationRule(ctx context.Context, modifyParams *Re
 
 // GetReplicationRules returns a list of replication rules
 func (c *ClientIMPL) GetReplicationRules(ctx context.Context) ([]ReplicationRule, error) {
-	var result []ReplicationRule
-	err := c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []ReplicationRule
-		policy := ReplicationRule{}
-		qp := c.APIClient().QueryParamsWithFields(&policy)
-		qp.Order("name")
-		qp.Offset(offset).Limit(paginationDefaultPageSize)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    replicationRuleURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			result = append(result, page...)
-		}
-		return meta, err
+	policy := ReplicationRule{}
+	qp := c.APIClient().QueryParamsWithFields(&policy)
+	qp.Order("name")
+	return readPaginatedData[ReplicationRule](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    replicationRuleURL,
+		QueryParams: qp,
 	})
-	return result, err
 }
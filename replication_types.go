@@ -16,7 +16,11 @@
 
 package gopowerstore
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/dell/gopowerstore/api"
+)
 
 type (
 	RPOEnum     string
@@ -81,18 +85,18 @@ type ReplicationRule struct {
 	Rpo RPOEnum `json:"rpo"`
 	// RemoteSystemID - unique identifier of the remote system to which this rule will replicate the associated resources.
 	RemoteSystemID     string               `json:"remote_system_id"`
-	ProtectionPolicies []ProtectionPolicy   `json:"policies"`
+	ProtectionPolicies []ProtectionPolicy   `json:"policies" select:"fields=id|name"`
 	AlertThreshold     int                  `json:"alert_threshold"`
 	IsReadOnly         bool                 `json:"is_read_only,omitempty"`
 	IsReplica          bool                 `json:"is_replica,omitempty"`
 	ManagedBy          string               `json:"managed_by,omitempty"`
 	ManagedByID        string               `json:"managed_by_id,omitempty"`
-	RemoteSystem       RemoteSystem         `json:"remote_system,omitempty"`
-	ReplicationSession []ReplicationSession `json:"replication_sessions,omitempty"`
+	RemoteSystem       RemoteSystem         `json:"remote_system,omitempty" select:"fields=id|name"`
+	ReplicationSession []ReplicationSession `json:"replication_sessions,omitempty" select:"fields=id|state"`
 }
 
 func (rule *ReplicationRule) Fields() []string {
-	return []string{"id", "name", "rpo", "remote_system_id", "alert_threshold", "is_read_only", "is_replica", "managed_by", "managed_by_id", "policies(id,name)", "remote_system(id,name)", "replication_sessions(id,state)"}
+	return api.SelectFields(rule)
 }
 
 // VirtualMachines - Details of virtual machine
@@ -149,17 +153,17 @@ type ProtectionPolicy struct {
 	IsReplica        bool               `json:"is_replica"`
 	TypeL10          string             `json:"type_l10"`
 	ManagedByL10     string             `json:"managed_by_l10n"`
-	VirtualMachines  []VirtualMachines  `json:"virtual_machines"`
-	FileSystems      []FileSystems      `json:"file_systems"`
-	PerformanceRules []PerformanceRules `json:"performance_rules"`
-	ReplicationRules []ReplicationRule  `json:"replication_rules"`
-	SnapshotRules    []SnapshotRule     `json:"snapshot_rules"`
-	Volumes          []Volume           `json:"volumes"`
-	VolumeGroups     []VolumeGroup      `json:"volume_groups"`
+	VirtualMachines  []VirtualMachines  `json:"virtual_machines" select:"all"`
+	FileSystems      []FileSystems      `json:"file_systems" select:"all"`
+	PerformanceRules []PerformanceRules `json:"performance_rules" select:"all"`
+	ReplicationRules []ReplicationRule  `json:"replication_rules" select:"all"`
+	SnapshotRules    []SnapshotRule     `json:"snapshot_rules" select:"all"`
+	Volumes          []Volume           `json:"volumes" select:"all"`
+	VolumeGroups     []VolumeGroup      `json:"volume_groups" select:"all"`
 }
 
 func (policy *ProtectionPolicy) Fields() []string {
-	return []string{"*", "replication_rules(*)", "snapshot_rules(*)", "virtual_machines(*)", "file_systems(*)", "performance_rules(*)", "volumes(*)", "volume_groups(*)"}
+	return api.SelectAllFields(policy)
 }
 
 type StorageElementPair struct {
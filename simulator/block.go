@@ -0,0 +1,579 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	volumeCollection      = "volume"
+	hostCollection        = "host"
+	hostGroupCollection   = "host_group"
+	mappingCollection     = "host_volume_mapping"
+	volumeGroupCollection = "volume_group"
+
+	volumeTypePrimary  = "Primary"
+	volumeTypeClone    = "Clone"
+	volumeTypeSnapshot = "Snapshot"
+
+	// volume sizes are multiples of the array block size
+	volumeSizeAlignment = 8192
+	// internal key of the volume group the volume belongs to
+	keyVolumeGroup = "_volume_group_id"
+)
+
+func volumeResource() *resource {
+	return &resource{
+		name: volumeCollection,
+		relations: map[string]relation{
+			"mapped_volumes": hasMany(mappingCollection, "volume_id"),
+			"volume_groups":  hasOne(volumeGroupCollection, keyVolumeGroup),
+		},
+		create: createVolume,
+		modify: modifyVolume,
+		delete: func(s *Server, inst, _ instance) *apiError {
+			return s.deleteVolume(inst)
+		},
+		actions: map[string]actionFunc{
+			"snapshot": snapshotVolume,
+			"clone":    cloneVolume,
+		},
+	}
+}
+
+func isSnapshot(inst instance) bool {
+	return str(inst, "type") == volumeTypeSnapshot
+}
+
+func validateVolumeSize(v any) (int64, *apiError) {
+	size, ok := intOf(v)
+	if !ok || size <= 0 {
+		return 0, errBadRequest("The size must be a positive number of bytes.")
+	}
+	if size%volumeSizeAlignment != 0 {
+		return 0, errBadRequest("The size %d must be a multiple of %d bytes.", size, volumeSizeAlignment)
+	}
+	return size, nil
+}
+
+// newVolume returns a volume instance with defaults of an array
+func (s *Server) newVolume(name, volumeType string, size int64) instance {
+	s.store.nsid++
+	return instance{
+		"name":                         name,
+		"description":                  "",
+		"type":                         volumeType,
+		"state":                        "Ready",
+		"size":                         size,
+		"logical_used":                 int64(0),
+		"wwn":                          "naa.68ccf098" + newHex(24),
+		"nguid":                        "nguid." + newHex(32),
+		"nsid":                         s.store.nsid,
+		"appliance_id":                 "A1",
+		"node_affinity":                "System_Select_At_Attach",
+		"creation_timestamp":           timestamp(),
+		"protection_policy_id":         nil,
+		"performance_policy_id":        "default_medium",
+		"is_replication_destination":   false,
+		"migration_session_id":         nil,
+		"metro_replication_session_id": nil,
+		"app_type":                     nil,
+		"app_type_other":               nil,
+		"protection_data": instance{
+			"source_id":            nil,
+			"parent_id":            nil,
+			"creator_type":         "User",
+			"expiration_timestamp": nil,
+		},
+	}
+}
+
+func createVolume(s *Server, body instance) (string, *apiError) {
+	name, apiErr := requireName(body)
+	if apiErr != nil {
+		return "", apiErr
+	}
+	size, apiErr := validateVolumeSize(body["size"])
+	if apiErr != nil {
+		return "", apiErr
+	}
+	if s.volumeNameTaken(name, "") {
+		return "", errNameInUse(http.StatusUnprocessableEntity, "volume", name)
+	}
+	var group instance
+	if id := str(body, "volume_group_id"); id != "" {
+		if group, apiErr = s.requireInstance(volumeGroupCollection, id); apiErr != nil {
+			return "", apiErr
+		}
+	}
+	hostID, hostGroupID := str(body, "host_id"), str(body, "host_group_id")
+	if hostID != "" {
+		if _, apiErr = s.requireInstance(hostCollection, hostID); apiErr != nil {
+			return "", apiErr
+		}
+	}
+	if hostGroupID != "" {
+		if _, apiErr = s.requireInstance(hostGroupCollection, hostGroupID); apiErr != nil {
+			return "", apiErr
+		}
+	}
+
+	vol := s.newVolume(name, volumeTypePrimary, size)
+	copyFields(vol, body, "description", "protection_policy_id", "performance_policy_id",
+		"app_type", "app_type_other", "metadata", "appliance_id")
+	if group != nil {
+		vol[keyVolumeGroup] = str(group, "id")
+	}
+	id := s.store.insert(volumeCollection, vol)
+	lun, _ := intOf(body["logical_unit_number"])
+	if hostID != "" {
+		apiErr = s.mapVolume(hostID, "", vol, lun)
+	} else if hostGroupID != "" {
+		apiErr = s.mapVolume("", hostGroupID, vol, lun)
+	}
+	if apiErr != nil {
+		s.store.remove(volumeCollection, id)
+		return "", apiErr
+	}
+	return id, nil
+}
+
+// volumeNameTaken checks names of volumes and clones, snapshot names are unique per source volume
+func (s *Server) volumeNameTaken(name, exceptID string) bool {
+	return s.nameTaken(volumeCollection, name, exceptID, func(inst instance) bool {
+		return !isSnapshot(inst)
+	})
+}
+
+func (s *Server) snapshotNameTaken(sourceID, name, exceptID string) bool {
+	return s.nameTaken(volumeCollection, name, exceptID, func(inst instance) bool {
+		return isSnapshot(inst) && sourceOf(inst) == sourceID
+	})
+}
+
+func sourceOf(inst instance) string {
+	data, _ := inst["protection_data"].(instance)
+	return str(data, "source_id")
+}
+
+func modifyVolume(s *Server, vol, body instance) *apiError {
+	id := str(vol, "id")
+	if name := str(body, "name"); name != "" && name != str(vol, "name") {
+		taken := s.volumeNameTaken(name, id)
+		if isSnapshot(vol) {
+			taken = s.snapshotNameTaken(sourceOf(vol), name, id)
+		}
+		if taken {
+			return errNameInUse(http.StatusUnprocessableEntity, "volume", name)
+		}
+		vol["name"] = name
+	}
+	if v, ok := body["size"]; ok && v != nil {
+		size, apiErr := validateVolumeSize(v)
+		if apiErr != nil {
+			return apiErr
+		}
+		current, _ := intOf(vol["size"])
+		if size < current {
+			return errUnprocessable("The new size %d must not be less than the current size %d.", size, current)
+		}
+		if size != current && isSnapshot(vol) {
+			return errUnprocessable("The size of snapshot %s can't be changed.", id)
+		}
+		vol["size"] = size
+	}
+	copyFields(vol, body, "description", "performance_policy_id", "app_type", "app_type_other")
+	if _, ok := body["protection_policy_id"]; ok {
+		vol["protection_policy_id"] = strOrNil(body, "protection_policy_id")
+	}
+	if _, ok := body["expiration_timestamp"]; ok {
+		vol["protection_data"].(instance)["expiration_timestamp"] = strOrNil(body, "expiration_timestamp")
+	}
+	return nil
+}
+
+// deleteVolume deletes the volume and its snapshots, mapped volumes, members of volume groups
+// and volumes with replication sessions can't be deleted
+func (s *Server) deleteVolume(vol instance) *apiError {
+	id := str(vol, "id")
+	if len(s.store.find(mappingCollection, byField("volume_id", id))) != 0 {
+		return errUnprocessable("The volume %s is attached to one or more hosts, detach it before deletion.", id)
+	}
+	if groupID := str(vol, keyVolumeGroup); groupID != "" && !isSnapshot(vol) {
+		return errUnprocessable("The volume %s is a member of volume group %s, remove it from the group before deletion.",
+			id, groupID)
+	}
+	if len(s.store.find(replicationSessionCollection, byField("local_resource_id", id))) != 0 {
+		return errUnprocessable("The volume %s has a replication session.", id)
+	}
+	for _, snap := range s.store.find(volumeCollection, func(inst instance) bool {
+		return isSnapshot(inst) && sourceOf(inst) == id
+	}) {
+		s.store.remove(volumeCollection, str(snap, "id"))
+	}
+	s.store.remove(volumeCollection, id)
+	return nil
+}
+
+func (s *Server) newSnapshot(source instance, name string, body instance) instance {
+	snap := s.newVolume(name, volumeTypeSnapshot, 0)
+	snap["size"] = source["size"]
+	snap["description"] = str(body, "description")
+	snap["performance_policy_id"] = source["performance_policy_id"]
+	data := snap["protection_data"].(instance)
+	data["source_id"] = str(source, "id")
+	data["parent_id"] = str(source, "id")
+	data["expiration_timestamp"] = strOrNil(body, "expiration_timestamp")
+	if creator := str(body, "creator_type"); creator != "" {
+		data["creator_type"] = creator
+	}
+	return snap
+}
+
+func snapshotVolume(s *Server, vol, body instance) (any, *apiError) {
+	id := str(vol, "id")
+	name := str(body, "name")
+	if name == "" {
+		name = fmt.Sprintf("%s.%s", str(vol, "name"), timestamp())
+	}
+	if s.snapshotNameTaken(id, name, "") {
+		return nil, errNameInUse(http.StatusBadRequest, "snapshot", name)
+	}
+	return createdResponse{ID: s.store.insert(volumeCollection, s.newSnapshot(vol, name, body))}, nil
+}
+
+// cloneVolume creates a volume from the volume or snapshot
+func cloneVolume(s *Server, source, body instance) (any, *apiError) {
+	name, apiErr := requireName(body)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if s.volumeNameTaken(name, "") {
+		return nil, errNameInUse(http.StatusUnprocessableEntity, "volume", name)
+	}
+	size, _ := intOf(source["size"])
+	clone := s.newVolume(name, volumeTypeClone, size)
+	clone["description"] = str(body, "description")
+	copyFields(clone, body, "performance_policy_id", "protection_policy_id")
+	data := clone["protection_data"].(instance)
+	data["parent_id"] = str(source, "id")
+	data["source_id"] = str(source, "id")
+	if isSnapshot(source) {
+		data["source_id"] = sourceOf(source)
+	}
+	return createdResponse{ID: s.store.insert(volumeCollection, clone)}, nil
+}
+
+func hostResource() *resource {
+	return &resource{
+		name: hostCollection,
+		relations: map[string]relation{
+			"host_group":   hasOne(hostGroupCollection, "host_group_id"),
+			"mapped_hosts": hasMany(mappingCollection, "host_id"),
+		},
+		create: createHost,
+		modify: modifyHost,
+		delete: func(s *Server, host, _ instance) *apiError {
+			id := str(host, "id")
+			if len(s.store.find(mappingCollection, byField("host_id", id))) != 0 {
+				return errUnprocessable("The host %s has attached volumes, detach them before deletion.", id)
+			}
+			if groupID := str(host, "host_group_id"); groupID != "" {
+				return errUnprocessable("The host %s is a member of host group %s.", id, groupID)
+			}
+			s.store.remove(hostCollection, id)
+			return nil
+		},
+		actions: map[string]actionFunc{
+			"attach": func(s *Server, host, body instance) (any, *apiError) {
+				vol, apiErr := s.requireInstance(volumeCollection, str(body, "volume_id"))
+				if apiErr != nil {
+					return nil, apiErr
+				}
+				lun, _ := intOf(body["logical_unit_number"])
+				return nil, s.mapVolume(str(host, "id"), "", vol, lun)
+			},
+			"detach": func(s *Server, host, body instance) (any, *apiError) {
+				return nil, s.unmapVolume(str(host, "id"), "", str(body, "volume_id"))
+			},
+		},
+	}
+}
+
+// newInitiators validates initiators of the request, port names must be unique across hosts
+func (s *Server) newInitiators(v any, exceptHostID string) ([]any, *apiError) {
+	list, _ := v.([]any)
+	res := make([]any, 0, len(list))
+	for _, item := range list {
+		in, _ := item.(instance)
+		port := str(in, "port_name")
+		if port == "" {
+			return nil, errBadRequest("The initiator port_name is required.")
+		}
+		if host := s.initiatorHost(port); host != "" && host != exceptHostID {
+			return nil, errUnprocessable("The initiator %s is already used by host %s.", port, host)
+		}
+		initiator := instance{"port_name": port, "port_type": in["port_type"], "active_sessions": []any{}}
+		copyFields(initiator, in, "chap_single_username", "chap_mutual_username")
+		res = append(res, initiator)
+	}
+	return res, nil
+}
+
+func (s *Server) initiatorHost(port string) string {
+	for _, host := range s.store.list(hostCollection) {
+		for _, in := range host["host_initiators"].([]any) {
+			if str(in.(instance), "port_name") == port {
+				return str(host, "id")
+			}
+		}
+	}
+	return ""
+}
+
+func createHost(s *Server, body instance) (string, *apiError) {
+	name, apiErr := requireName(body)
+	if apiErr != nil {
+		return "", apiErr
+	}
+	if str(body, "os_type") == "" {
+		return "", errBadRequest("The os_type is required.")
+	}
+	if s.nameTaken(hostCollection, name, "", nil) {
+		return "", errNameInUse(http.StatusUnprocessableEntity, "host", name)
+	}
+	initiators, apiErr := s.newInitiators(body["initiators"], "")
+	if apiErr != nil {
+		return "", apiErr
+	}
+	host := instance{
+		"name":              name,
+		"description":       str(body, "description"),
+		"os_type":           str(body, "os_type"),
+		"host_group_id":     nil,
+		"host_connectivity": "Local_Only",
+		"type":              "External",
+		"host_initiators":   initiators,
+	}
+	copyFields(host, body, "host_connectivity", "metadata")
+	return s.store.insert(hostCollection, host), nil
+}
+
+func modifyHost(s *Server, host, body instance) *apiError {
+	id := str(host, "id")
+	if name := str(body, "name"); name != "" && name != str(host, "name") {
+		if s.nameTaken(hostCollection, name, id, nil) {
+			return errNameInUse(http.StatusUnprocessableEntity, "host", name)
+		}
+		host["name"] = name
+	}
+	initiators := host["host_initiators"].([]any)
+	for _, port := range strList(body["remove_initiators"]) {
+		found := false
+		for i, in := range initiators {
+			if str(in.(instance), "port_name") == port {
+				initiators = append(initiators[:i:i], initiators[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errBadRequest("The initiator %s is not part of host %s.", port, id)
+		}
+	}
+	added, apiErr := s.newInitiators(body["add_initiators"], id)
+	if apiErr != nil {
+		return apiErr
+	}
+	for _, in := range added {
+		for _, existing := range initiators {
+			if str(existing.(instance), "port_name") == str(in.(instance), "port_name") {
+				return errUnprocessable("The initiator %s is already part of host %s.", str(in.(instance), "port_name"), id)
+			}
+		}
+	}
+	host["host_initiators"] = append(initiators, added...)
+	copyFields(host, body, "description", "host_connectivity")
+	return nil
+}
+
+func hostGroupResource() *resource {
+	return &resource{
+		name: hostGroupCollection,
+		relations: map[string]relation{
+			"hosts":              hasMany(hostCollection, "host_group_id"),
+			"mapped_host_groups": hasMany(mappingCollection, "host_group_id"),
+		},
+		create: createHostGroup,
+		modify: modifyHostGroup,
+		delete: func(s *Server, group, _ instance) *apiError {
+			id := str(group, "id")
+			if len(s.store.find(mappingCollection, byField("host_group_id", id))) != 0 {
+				return errUnprocessable("The host group %s has attached volumes, detach them before deletion.", id)
+			}
+			for _, host := range s.store.find(hostCollection, byField("host_group_id", id)) {
+				host["host_group_id"] = nil
+			}
+			s.store.remove(hostGroupCollection, id)
+			return nil
+		},
+		actions: map[string]actionFunc{
+			"attach": func(s *Server, group, body instance) (any, *apiError) {
+				vol, apiErr := s.requireInstance(volumeCollection, str(body, "volume_id"))
+				if apiErr != nil {
+					return nil, apiErr
+				}
+				lun, _ := intOf(body["logical_unit_number"])
+				return nil, s.mapVolume("", str(group, "id"), vol, lun)
+			},
+			"detach": func(s *Server, group, body instance) (any, *apiError) {
+				return nil, s.unmapVolume("", str(group, "id"), str(body, "volume_id"))
+			},
+		},
+	}
+}
+
+// addHostsToGroup checks that all hosts exist and don't belong to any group before adding them
+func (s *Server) addHostsToGroup(groupID string, hostIDs []string) *apiError {
+	hosts := make([]instance, 0, len(hostIDs))
+	for _, id := range hostIDs {
+		host, apiErr := s.requireInstance(hostCollection, id)
+		if apiErr != nil {
+			return apiErr
+		}
+		if current := str(host, "host_group_id"); current != "" {
+			return errUnprocessable("The host %s is already a member of host group %s.", id, current)
+		}
+		hosts = append(hosts, host)
+	}
+	for _, host := range hosts {
+		host["host_group_id"] = groupID
+	}
+	return nil
+}
+
+func createHostGroup(s *Server, body instance) (string, *apiError) {
+	name, apiErr := requireName(body)
+	if apiErr != nil {
+		return "", apiErr
+	}
+	if s.nameTaken(hostGroupCollection, name, "", nil) {
+		return "", errNameInUse(http.StatusUnprocessableEntity, "host group", name)
+	}
+	group := instance{
+		"name":              name,
+		"description":       str(body, "description"),
+		"host_connectivity": "Local_Only",
+	}
+	copyFields(group, body, "host_connectivity")
+	id := newID()
+	group["id"] = id
+	if apiErr = s.addHostsToGroup(id, strList(body["host_ids"])); apiErr != nil {
+		return "", apiErr
+	}
+	return s.store.insert(hostGroupCollection, group), nil
+}
+
+func modifyHostGroup(s *Server, group, body instance) *apiError {
+	id := str(group, "id")
+	if name := str(body, "name"); name != "" && name != str(group, "name") {
+		if s.nameTaken(hostGroupCollection, name, id, nil) {
+			return errNameInUse(http.StatusUnprocessableEntity, "host group", name)
+		}
+		group["name"] = name
+	}
+	for _, hostID := range strList(body["remove_host_ids"]) {
+		host, ok := s.store.get(hostCollection, hostID)
+		if !ok || str(host, "host_group_id") != id {
+			return errBadRequest("The host %s is not part of host group %s.", hostID, id)
+		}
+	}
+	if apiErr := s.addHostsToGroup(id, strList(body["add_host_ids"])); apiErr != nil {
+		return apiErr
+	}
+	for _, hostID := range strList(body["remove_host_ids"]) {
+		host, _ := s.store.get(hostCollection, hostID)
+		host["host_group_id"] = nil
+	}
+	copyFields(group, body, "description", "host_connectivity")
+	return nil
+}
+
+func hostVolumeMappingResource() *resource {
+	return &resource{
+		name: mappingCollection,
+		relations: map[string]relation{
+			"volume":     hasOne(volumeCollection, "volume_id"),
+			"host":       hasOne(hostCollection, "host_id"),
+			"host_group": hasOne(hostGroupCollection, "host_group_id"),
+		},
+	}
+}
+
+// mapVolume maps the volume to a host or a host group, the first free LUN is used when lun is zero
+func (s *Server) mapVolume(hostID, hostGroupID string, vol instance, lun int64) *apiError {
+	volID := str(vol, "id")
+	if isSnapshot(vol) {
+		return errUnprocessable("The snapshot %s can't be attached, attach a clone of it instead.", volID)
+	}
+	kind, key, target := mappingTarget(hostID, hostGroupID)
+	mappings := s.store.find(mappingCollection, byField(key, target))
+	used := map[int64]bool{}
+	for _, m := range mappings {
+		if str(m, "volume_id") == volID {
+			return errUnprocessable("The volume %s is already attached to %s %s.", volID, kind, target)
+		}
+		n, _ := intOf(m["logical_unit_number"])
+		used[n] = true
+	}
+	if lun == 0 {
+		for lun = 1; used[lun]; lun++ {
+		}
+	} else if used[lun] {
+		return errUnprocessable("The logical unit number %d is already used by %s %s.", lun, kind, target)
+	}
+	s.store.insert(mappingCollection, instance{
+		"host_id":             nullable(hostID),
+		"host_group_id":       nullable(hostGroupID),
+		"volume_id":           volID,
+		"logical_unit_number": lun,
+	})
+	return nil
+}
+
+// mappingTarget returns kind, mapping key and id of the host or the host group
+func mappingTarget(hostID, hostGroupID string) (kind, key, target string) {
+	if hostGroupID != "" {
+		return "host group", "host_group_id", hostGroupID
+	}
+	return "host", "host_id", hostID
+}
+
+func (s *Server) unmapVolume(hostID, hostGroupID, volID string) *apiError {
+	kind, key, target := mappingTarget(hostID, hostGroupID)
+	for _, m := range s.store.find(mappingCollection, byField(key, target)) {
+		if str(m, "volume_id") == volID {
+			s.store.remove(mappingCollection, str(m, "id"))
+			return nil
+		}
+	}
+	return errBadRequest("The volume %s is not attached to %s %s.", volID, kind, target)
+}
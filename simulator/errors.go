@@ -0,0 +1,83 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dell/gopowerstore/api"
+)
+
+// notFoundCode is the code PowerStore reports for missing instances. Other errors have no registered
+// codes, their messages follow the wording which api classifies, e.g. "The name %s is already in use".
+const notFoundCode = "0xE0A08001000E"
+
+type errorBody struct {
+	Messages []api.ErrorMessage `json:"messages"`
+}
+
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func newError(status int, format string, args ...any) *apiError {
+	return &apiError{status: status, message: fmt.Sprintf(format, args...)}
+}
+
+func errNotFound(kind, id string) *apiError {
+	err := newError(http.StatusNotFound, "The requested %s %s was not found.", kind, id)
+	err.code = notFoundCode
+	return err
+}
+
+func errBadRequest(format string, args ...any) *apiError {
+	return newError(http.StatusBadRequest, format, args...)
+}
+
+func errUnprocessable(format string, args ...any) *apiError {
+	return newError(http.StatusUnprocessableEntity, format, args...)
+}
+
+func errMethodNotAllowed(method, endpoint string) *apiError {
+	return newError(http.StatusMethodNotAllowed, "Method %s is not supported by %s.", method, endpoint)
+}
+
+func errUnauthorized() *apiError {
+	return newError(http.StatusUnauthorized, "Invalid username or password.")
+}
+
+func errForbidden(reason string) *apiError {
+	return newError(http.StatusForbidden, "Access denied: %s.", reason)
+}
+
+func errNameInUse(status int, kind, name string) *apiError {
+	return newError(status, "The name %s is already in use by another %s.", name, kind)
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	writeJSON(w, err.status, errorBody{Messages: []api.ErrorMessage{{
+		Code:      err.code,
+		Severity:  "Error",
+		Message:   err.message,
+		Arguments: []string{},
+	}}})
+}
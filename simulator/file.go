@@ -0,0 +1,514 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	nasServerCollection  = "nas_server"
+	nfsServerCollection  = "nfs_server"
+	fileSystemCollection = "file_system"
+	nfsExportCollection  = "nfs_export"
+	smbShareCollection   = "smb_share"
+
+	fsTypePrimary  = "Primary"
+	fsTypeSnapshot = "Snapshot"
+
+	// file systems of arrays are at least 3 GiB
+	minFileSystemSize = 3 * 1024 * 1024 * 1024
+	// internal key of ACL entries of SMB shares
+	keyACL = "_aces"
+)
+
+// host lists of NFS exports which are changed with add_ and remove_ prefixed keys
+var nfsHostLists = []string{
+	"no_access_hosts",
+	"read_only_hosts",
+	"read_only_root_hosts",
+	"read_write_hosts",
+	"read_write_root_hosts",
+}
+
+func nasServerResource() *resource {
+	return &resource{
+		name: nasServerCollection,
+		relations: map[string]relation{
+			"nfs_servers":  hasMany(nfsServerCollection, "nas_server_id"),
+			"file_systems": hasMany(fileSystemCollection, "nas_server_id"),
+		},
+		create: func(s *Server, body instance) (string, *apiError) {
+			name, apiErr := requireName(body)
+			if apiErr != nil {
+				return "", apiErr
+			}
+			if s.nameTaken(nasServerCollection, name, "", nil) {
+				return "", errNameInUse(http.StatusUnprocessableEntity, "NAS server", name)
+			}
+			return s.store.insert(nasServerCollection, instance{
+				"name":                                name,
+				"description":                         str(body, "description"),
+				"operational_status":                  "Started",
+				"current_node_id":                     "N1",
+				"preferred_node_id":                   "N1",
+				"current_unix_directory_service":      "Local_Files",
+				"file_events_publishing_mode":         "None",
+				"current_preferred_IPv4_interface_id": nil,
+				"current_preferred_IPv6_interface_id": nil,
+				"is_replication_destination":          false,
+				"is_username_translation_enabled":     false,
+				"is_auto_user_mapping_enabled":        false,
+				"protection_policy_id":                nil,
+			}), nil
+		},
+		modify: func(_ *Server, nas, body instance) *apiError {
+			copyFields(nas, body, "description", "current_unix_directory_service", "file_events_publishing_mode",
+				"preferred_node_id", "protection_policy_id")
+			return nil
+		},
+		delete: func(s *Server, nas, _ instance) *apiError {
+			id := str(nas, "id")
+			if len(s.store.find(fileSystemCollection, byField("nas_server_id", id))) != 0 {
+				return errUnprocessable("The NAS server %s has file systems, delete them before deletion.", id)
+			}
+			for _, nfs := range s.store.find(nfsServerCollection, byField("nas_server_id", id)) {
+				s.store.remove(nfsServerCollection, str(nfs, "id"))
+			}
+			s.store.remove(nasServerCollection, id)
+			return nil
+		},
+	}
+}
+
+func nfsServerResource() *resource {
+	return &resource{
+		name: nfsServerCollection,
+		relations: map[string]relation{
+			"nas_server": hasOne(nasServerCollection, "nas_server_id"),
+		},
+		create: func(s *Server, body instance) (string, *apiError) {
+			nasID := str(body, "nas_server_id")
+			if _, apiErr := s.requireInstance(nasServerCollection, nasID); apiErr != nil {
+				return "", apiErr
+			}
+			if len(s.store.find(nfsServerCollection, byField("nas_server_id", nasID))) != 0 {
+				return "", errUnprocessable("The NAS server %s already has an NFS server.", nasID)
+			}
+			nfs := instance{
+				"nas_server_id":    nasID,
+				"host_name":        nil,
+				"is_nfsv3_enabled": true,
+				"is_nfsv4_enabled": false,
+			}
+			copyFields(nfs, body, "host_name", "is_nfsv3_enabled", "is_nfsv4_enabled")
+			return s.store.insert(nfsServerCollection, nfs), nil
+		},
+		delete: func(s *Server, nfs, _ instance) *apiError {
+			s.store.remove(nfsServerCollection, str(nfs, "id"))
+			return nil
+		},
+	}
+}
+
+func fileSystemResource() *resource {
+	return &resource{
+		name: fileSystemCollection,
+		relations: map[string]relation{
+			"nas_server":  hasOne(nasServerCollection, "nas_server_id"),
+			"nfs_exports": hasMany(nfsExportCollection, "file_system_id"),
+			"smb_shares":  hasMany(smbShareCollection, "file_system_id"),
+		},
+		create: createFileSystem,
+		modify: modifyFileSystem,
+		delete: func(s *Server, fs, _ instance) *apiError {
+			return s.deleteFileSystem(fs)
+		},
+		actions: map[string]actionFunc{
+			"snapshot": snapshotFileSystem,
+			"clone":    cloneFileSystem,
+		},
+	}
+}
+
+func isFsSnapshot(inst instance) bool {
+	return str(inst, "filesystem_type") == fsTypeSnapshot
+}
+
+// fsNameTaken checks names of file systems of the NAS server, snapshot names are unique per parent
+func (s *Server) fsNameTaken(nasID, name, exceptID string) bool {
+	return s.nameTaken(fileSystemCollection, name, exceptID, func(inst instance) bool {
+		return !isFsSnapshot(inst) && str(inst, "nas_server_id") == nasID
+	})
+}
+
+func (s *Server) newFileSystem(name, fsType string, nasID string, size int64) instance {
+	now := timestamp()
+	return instance{
+		"name":                        name,
+		"description":                 "",
+		"nas_server_id":               nasID,
+		"filesystem_type":             fsType,
+		"size_total":                  size,
+		"size_used":                   int64(0),
+		"parent_id":                   nil,
+		"config_type":                 "General",
+		"access_policy":               "Native",
+		"locking_policy":              "Advisory",
+		"folder_rename_policy":        "All_Forbidden",
+		"is_async_MTime_enabled":      false,
+		"protection_policy_id":        nil,
+		"file_events_publishing_mode": "None",
+		"host_io_size":                "VMware_8K",
+		"access_type":                 nil,
+		"expiration_timestamp":        nil,
+		"creator_type":                "User",
+		"is_quota_enabled":            false,
+		"grace_period":                int64(604800),
+		"default_hard_limit":          int64(0),
+		"default_soft_limit":          int64(0),
+		"is_modified":                 false,
+		"creation_timestamp":          now,
+		"last_writable_timestamp":     now,
+		"last_refresh_timestamp":      nil,
+	}
+}
+
+func createFileSystem(s *Server, body instance) (string, *apiError) {
+	name, apiErr := requireName(body)
+	if apiErr != nil {
+		return "", apiErr
+	}
+	nasID := str(body, "nas_server_id")
+	if _, apiErr = s.requireInstance(nasServerCollection, nasID); apiErr != nil {
+		return "", apiErr
+	}
+	size, ok := intOf(body["size_total"])
+	if !ok || size < minFileSystemSize {
+		return "", errBadRequest("The size_total must be at least %d bytes.", int64(minFileSystemSize))
+	}
+	if s.fsNameTaken(nasID, name, "") {
+		return "", errNameInUse(http.StatusUnprocessableEntity, "file system", name)
+	}
+	if limit := s.opts.FileSystemLimit; limit > 0 &&
+		len(s.store.find(fileSystemCollection, func(inst instance) bool {
+			return !isFsSnapshot(inst) && str(inst, "nas_server_id") == nasID
+		})) >= limit {
+		return "", errUnprocessable("The limit of %d file systems for the NAS server %s has been reached.", limit, nasID)
+	}
+	fs := s.newFileSystem(name, fsTypePrimary, nasID, size)
+	copyFields(fs, body, "description", "config_type", "access_policy", "locking_policy", "folder_rename_policy",
+		"is_async_MTime_enabled", "protection_policy_id", "file_events_publishing_mode", "host_io_size",
+		"flr_attributes", "is_smb_sync_writes_enabled", "is_smb_no_notify_enabled", "is_smb_op_locks_enabled",
+		"is_smb_notify_on_access_enabled", "is_smb_notify_on_write_enabled", "smb_notify_on_change_dir_depth")
+	return s.store.insert(fileSystemCollection, fs), nil
+}
+
+func modifyFileSystem(s *Server, fs, body instance) *apiError {
+	id := str(fs, "id")
+	if v, ok := body["size_total"]; ok {
+		size, ok := intOf(v)
+		used, _ := intOf(fs["size_used"])
+		switch {
+		case isFsSnapshot(fs):
+			return errUnprocessable("The size of snapshot %s can't be changed.", id)
+		case !ok || size < minFileSystemSize:
+			return errBadRequest("The size_total must be at least %d bytes.", int64(minFileSystemSize))
+		case size < used:
+			return errUnprocessable("The new size %d is less than the used size %d.", size, used)
+		}
+		fs["size_total"] = size
+	}
+	copyFields(fs, body, "description", "access_policy", "locking_policy", "folder_rename_policy",
+		"is_async_MTime_enabled", "protection_policy_id", "file_events_publishing_mode", "expiration_timestamp",
+		"is_smb_sync_writes_enabled", "is_smb_no_notify_enabled", "is_smb_op_locks_enabled",
+		"is_smb_notify_on_access_enabled", "is_smb_notify_on_write_enabled", "smb_notify_on_change_dir_depth",
+		"is_quota_enabled", "grace_period", "default_hard_limit", "default_soft_limit")
+	return nil
+}
+
+// deleteFileSystem deletes the file system and its snapshots, file systems with exports or shares
+// can't be deleted
+func (s *Server) deleteFileSystem(fs instance) *apiError {
+	id := str(fs, "id")
+	if len(s.store.find(nfsExportCollection, byField("file_system_id", id))) != 0 {
+		return errUnprocessable("The file system %s has NFS exports, delete them before deletion.", id)
+	}
+	if len(s.store.find(smbShareCollection, byField("file_system_id", id))) != 0 {
+		return errUnprocessable("The file system %s has SMB shares, delete them before deletion.", id)
+	}
+	if len(s.store.find(replicationSessionCollection, byField("local_resource_id", id))) != 0 {
+		return errUnprocessable("The file system %s has a replication session.", id)
+	}
+	for _, snap := range s.store.find(fileSystemCollection, func(inst instance) bool {
+		return isFsSnapshot(inst) && str(inst, "parent_id") == id
+	}) {
+		if apiErr := s.deleteFileSystem(snap); apiErr != nil {
+			return apiErr
+		}
+	}
+	s.store.remove(fileSystemCollection, id)
+	return nil
+}
+
+func snapshotFileSystem(s *Server, fs, body instance) (any, *apiError) {
+	id := str(fs, "id")
+	name := str(body, "name")
+	if name == "" {
+		name = fmt.Sprintf("%s.%s", str(fs, "name"), timestamp())
+	}
+	if s.nameTaken(fileSystemCollection, name, "", func(inst instance) bool {
+		return isFsSnapshot(inst) && str(inst, "parent_id") == id
+	}) {
+		return nil, errNameInUse(http.StatusUnprocessableEntity, "snapshot", name)
+	}
+	size, _ := intOf(fs["size_total"])
+	snap := s.newFileSystem(name, fsTypeSnapshot, str(fs, "nas_server_id"), size)
+	snap["parent_id"] = id
+	snap["description"] = str(body, "description")
+	snap["access_type"] = "Snapshot"
+	copyFields(snap, body, "access_type", "expiration_timestamp")
+	return createdResponse{ID: s.store.insert(fileSystemCollection, snap)}, nil
+}
+
+// cloneFileSystem creates a file system from the file system or snapshot
+func cloneFileSystem(s *Server, source, body instance) (any, *apiError) {
+	name, apiErr := requireName(body)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	nasID := str(source, "nas_server_id")
+	if s.fsNameTaken(nasID, name, "") {
+		return nil, errNameInUse(http.StatusUnprocessableEntity, "file system", name)
+	}
+	size, _ := intOf(source["size_total"])
+	clone := s.newFileSystem(name, fsTypePrimary, nasID, size)
+	clone["parent_id"] = str(source, "id")
+	clone["description"] = str(body, "description")
+	return createdResponse{ID: s.store.insert(fileSystemCollection, clone)}, nil
+}
+
+func nfsExportResource() *resource {
+	return &resource{
+		name: nfsExportCollection,
+		relations: map[string]relation{
+			"file_system": hasOne(fileSystemCollection, "file_system_id"),
+		},
+		create: createNFSExport,
+		modify: modifyNFSExport,
+		delete: func(s *Server, export, _ instance) *apiError {
+			s.store.remove(nfsExportCollection, str(export, "id"))
+			return nil
+		},
+	}
+}
+
+// validateSharePath checks that the path of an export or a share is absolute
+func validateSharePath(path string) *apiError {
+	if !strings.HasPrefix(path, "/") {
+		return errBadRequest("The path %q must be absolute.", path)
+	}
+	return nil
+}
+
+func createNFSExport(s *Server, body instance) (string, *apiError) {
+	name, apiErr := requireName(body)
+	if apiErr != nil {
+		return "", apiErr
+	}
+	fsID := str(body, "file_system_id")
+	if _, apiErr = s.requireInstance(fileSystemCollection, fsID); apiErr != nil {
+		return "", apiErr
+	}
+	if apiErr = validateSharePath(str(body, "path")); apiErr != nil {
+		return "", apiErr
+	}
+	if s.nameTaken(nfsExportCollection, name, "", nil) {
+		return "", errNameInUse(http.StatusUnprocessableEntity, "NFS export", name)
+	}
+	export := instance{
+		"name":               name,
+		"description":        str(body, "description"),
+		"file_system_id":     fsID,
+		"path":               str(body, "path"),
+		"default_access":     "No_Access",
+		"min_security":       "Sys",
+		"anonymous_UID":      int64(-2),
+		"anonymous_GID":      int64(-2),
+		"is_no_SUID":         false,
+		"nfs_owner_username": "0",
+	}
+	for _, list := range nfsHostLists {
+		export[list] = anyList(strList(body[list]))
+	}
+	copyFields(export, body, "default_access", "min_security", "anonymous_UID", "anonymous_GID", "is_no_SUID")
+	return s.store.insert(nfsExportCollection, export), nil
+}
+
+// modifyNFSExport replaces host lists and adds or removes hosts, adding a present host
+// or removing an absent one fails like on arrays
+func modifyNFSExport(_ *Server, export, body instance) *apiError {
+	id := str(export, "id")
+	lists := map[string][]string{}
+	for _, list := range nfsHostLists {
+		hosts := strList(export[list])
+		if v, ok := body[list]; ok {
+			hosts = strList(v)
+		}
+		for _, host := range strList(body["remove_"+list]) {
+			i := indexOf(hosts, host)
+			if i < 0 {
+				return errBadRequest("The host %s is already removed from %s of NFS export %s.", host, list, id)
+			}
+			hosts = append(hosts[:i:i], hosts[i+1:]...)
+		}
+		for _, host := range strList(body["add_"+list]) {
+			if contains(hosts, host) {
+				return errBadRequest("The host %s is already present in %s of NFS export %s.", host, list, id)
+			}
+			hosts = append(hosts, host)
+		}
+		lists[list] = hosts
+	}
+	for list, hosts := range lists {
+		export[list] = anyList(hosts)
+	}
+	copyFields(export, body, "description", "default_access", "min_security", "anonymous_UID", "anonymous_GID",
+		"is_no_SUID")
+	return nil
+}
+
+func indexOf(list []string, value string) int {
+	for i, v := range list {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+func smbShareResource() *resource {
+	return &resource{
+		name: smbShareCollection,
+		relations: map[string]relation{
+			"file_system": hasOne(fileSystemCollection, "file_system_id"),
+		},
+		create: createSMBShare,
+		modify: func(_ *Server, share, body instance) *apiError {
+			copyFields(share, body, "description", "is_continuous_availability_enabled", "is_encryption_enabled",
+				"is_ABE_enabled", "is_branch_cache_enabled", "offline_availability", "umask")
+			return nil
+		},
+		delete: func(s *Server, share, _ instance) *apiError {
+			s.store.remove(smbShareCollection, str(share, "id"))
+			return nil
+		},
+		actions: map[string]actionFunc{
+			"get_acl": func(_ *Server, share, _ instance) (any, *apiError) {
+				return instance{"aces": share[keyACL]}, nil
+			},
+			"set_acl": setSMBShareACL,
+		},
+	}
+}
+
+func createSMBShare(s *Server, body instance) (string, *apiError) {
+	name, apiErr := requireName(body)
+	if apiErr != nil {
+		return "", apiErr
+	}
+	fs, apiErr := s.requireInstance(fileSystemCollection, str(body, "file_system_id"))
+	if apiErr != nil {
+		return "", apiErr
+	}
+	if apiErr = validateSharePath(str(body, "path")); apiErr != nil {
+		return "", apiErr
+	}
+	nasID := str(fs, "nas_server_id")
+	if s.nameTaken(smbShareCollection, name, "", func(inst instance) bool {
+		other, _ := s.store.get(fileSystemCollection, str(inst, "file_system_id"))
+		return str(other, "nas_server_id") == nasID
+	}) {
+		return "", errNameInUse(http.StatusUnprocessableEntity, "SMB share", name)
+	}
+	share := instance{
+		"name":                               name,
+		"description":                        str(body, "description"),
+		"file_system_id":                     str(fs, "id"),
+		"path":                               str(body, "path"),
+		"is_continuous_availability_enabled": false,
+		"is_encryption_enabled":              false,
+		"is_ABE_enabled":                     false,
+		"is_branch_cache_enabled":            false,
+		"offline_availability":               "Manual",
+		"umask":                              "022",
+		keyACL: []any{instance{
+			"trustee_type": "WellKnown",
+			"trustee_name": "Everyone",
+			"access_level": "Full",
+			"access_type":  "Allow",
+		}},
+	}
+	copyFields(share, body, "is_continuous_availability_enabled", "is_encryption_enabled", "is_ABE_enabled",
+		"is_branch_cache_enabled", "offline_availability", "umask")
+	return s.store.insert(smbShareCollection, share), nil
+}
+
+// setSMBShareACL replaces entries with aces or adds and removes entries with add_aces and remove_aces
+func setSMBShareACL(_ *Server, share, body instance) (any, *apiError) {
+	aces, _ := share[keyACL].([]any)
+	if v, ok := body["aces"]; ok {
+		aces, _ = v.([]any)
+	}
+	removed, _ := body["remove_aces"].([]any)
+	for _, r := range removed {
+		i := indexOfACE(aces, r)
+		if i < 0 {
+			return nil, errBadRequest("The ACL entry of %s is not present in SMB share %s.",
+				str(r.(instance), "trustee_name"), str(share, "id"))
+		}
+		aces = append(aces[:i:i], aces[i+1:]...)
+	}
+	added, _ := body["add_aces"].([]any)
+	for _, a := range added {
+		if indexOfACE(aces, a) >= 0 {
+			return nil, errBadRequest("The ACL entry of %s is already present in SMB share %s.",
+				str(a.(instance), "trustee_name"), str(share, "id"))
+		}
+		aces = append(aces, a)
+	}
+	share[keyACL] = aces
+	return nil, nil
+}
+
+// indexOfACE finds entry of the same trustee and access type
+func indexOfACE(aces []any, ace any) int {
+	target, _ := ace.(instance)
+	for i, v := range aces {
+		entry, _ := v.(instance)
+		if str(entry, "trustee_name") == str(target, "trustee_name") &&
+			str(entry, "trustee_type") == str(target, "trustee_type") &&
+			str(entry, "access_type") == str(target, "access_type") {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,550 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// query arguments which are not filters
+var reservedArgs = map[string]bool{"select": true, "order": true, "offset": true, "limit": true, "is_async": true}
+
+// selectField is an element of select argument, relations have children, e.g. volume(id,name)
+type selectField struct {
+	name     string
+	children []selectField
+	relation bool
+}
+
+// parseSelect parses select argument, instances are returned with id only without select like on arrays
+func parseSelect(s string) ([]selectField, error) {
+	if s == "" {
+		return []selectField{{name: "id"}}, nil
+	}
+	parts, err := splitTopLevel(s)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]selectField, 0, len(parts))
+	for _, p := range parts {
+		name, rest, nested := strings.Cut(p, "(")
+		field := selectField{name: strings.TrimSpace(name)}
+		if nested {
+			if !strings.HasSuffix(rest, ")") {
+				return nil, fmt.Errorf("unbalanced parentheses in %q", p)
+			}
+			field.relation = true
+			if field.children, err = parseSelect(strings.TrimSuffix(rest, ")")); err != nil {
+				return nil, err
+			}
+		}
+		if field.name == "" {
+			return nil, fmt.Errorf("empty field in %q", s)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// project returns the fields of the instance, related instances are resolved for nested fields.
+// Fields which the instance doesn't have are skipped.
+func (s *Server) project(res *resource, inst instance, fields []selectField) instance {
+	out := instance{}
+	for _, f := range fields {
+		if f.name == "*" {
+			for k, v := range inst {
+				if !strings.HasPrefix(k, "_") {
+					out[k] = v
+				}
+			}
+			continue
+		}
+		rel, isRelation := res.relations[f.name]
+		switch {
+		case isRelation:
+			children := f.children
+			if !f.relation {
+				children = []selectField{{name: "id"}}
+			}
+			related := rel.resolve(s.store, inst)
+			relRes := s.resources[rel.collection]
+			if rel.many {
+				list := make([]instance, 0, len(related))
+				for _, r := range related {
+					list = append(list, s.project(relRes, r, children))
+				}
+				out[f.name] = list
+			} else if len(related) != 0 {
+				out[f.name] = s.project(relRes, related[0], children)
+			} else {
+				out[f.name] = nil
+			}
+		case !strings.HasPrefix(f.name, "_"):
+			if v, ok := inst[f.name]; ok {
+				out[f.name] = v
+			}
+		}
+	}
+	return out
+}
+
+// filter is a condition of PowerStore filter syntax, e.g. name=not.like.csi-* or or=(size.gt.1,name.eq.a)
+type filter struct {
+	property string
+	operator string
+	value    string
+	// parsed values of in and cs operators
+	list     []string
+	negate   bool
+	children []filter
+}
+
+// parseFilters parses all filter arguments of the query, they are combined with and
+func parseFilters(q url.Values) ([]filter, error) {
+	var res []filter
+	for key, values := range q {
+		if reservedArgs[key] {
+			continue
+		}
+		for _, v := range values {
+			var (
+				f   filter
+				err error
+			)
+			switch key {
+			case "and", "or", "not.and", "not.or":
+				negate := strings.HasPrefix(key, "not.")
+				f, err = parseGroup(strings.TrimPrefix(key, "not."), negate, v)
+			default:
+				f, err = parseCondition(key, v)
+			}
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, f)
+		}
+	}
+	return res, nil
+}
+
+func parseGroup(operator string, negate bool, expr string) (filter, error) {
+	if !strings.HasPrefix(expr, "(") || !strings.HasSuffix(expr, ")") {
+		return filter{}, fmt.Errorf("invalid %s group %q", operator, expr)
+	}
+	parts, err := splitTopLevel(expr[1 : len(expr)-1])
+	if err != nil {
+		return filter{}, err
+	}
+	f := filter{operator: operator, negate: negate}
+	for _, p := range parts {
+		child, err := parseGroupElement(p)
+		if err != nil {
+			return filter{}, err
+		}
+		f.children = append(f.children, child)
+	}
+	return f, nil
+}
+
+// parseGroupElement parses element of and/or group, e.g. name.eq.a, not.and(...) or or(...)
+func parseGroupElement(e string) (filter, error) {
+	negate := false
+	rest := e
+	if strings.HasPrefix(rest, "not.and(") || strings.HasPrefix(rest, "not.or(") {
+		negate = true
+		rest = strings.TrimPrefix(rest, "not.")
+	}
+	for _, op := range []string{"and", "or"} {
+		if strings.HasPrefix(rest, op+"(") {
+			return parseGroup(op, negate, rest[len(op):])
+		}
+	}
+	property, expr, ok := strings.Cut(e, ".")
+	if !ok {
+		return filter{}, fmt.Errorf("invalid filter %q", e)
+	}
+	return parseCondition(property, expr)
+}
+
+// parseCondition parses [not.]operator.value of a property
+func parseCondition(property, expr string) (filter, error) {
+	f := filter{property: property}
+	for strings.HasPrefix(expr, "not.") {
+		f.negate = !f.negate
+		expr = strings.TrimPrefix(expr, "not.")
+	}
+	operator, value, ok := strings.Cut(expr, ".")
+	if !ok {
+		return filter{}, fmt.Errorf("invalid filter %s=%s", property, expr)
+	}
+	f.operator = operator
+	switch operator {
+	case "eq", "neq", "gt", "gte", "lt", "lte", "like", "ilike":
+		f.value = unquote(value)
+	case "is":
+		if value != "null" && value != "true" && value != "false" {
+			return filter{}, fmt.Errorf("invalid value of is operator %q", value)
+		}
+		f.value = value
+	case "in", "cs":
+		open, closing := "(", ")"
+		if operator == "cs" {
+			open, closing = "{", "}"
+		}
+		if !strings.HasPrefix(value, open) || !strings.HasSuffix(value, closing) {
+			return filter{}, fmt.Errorf("invalid list %q", value)
+		}
+		items, err := splitTopLevel(value[1 : len(value)-1])
+		if err != nil {
+			return filter{}, err
+		}
+		for _, item := range items {
+			f.list = append(f.list, unquote(item))
+		}
+	default:
+		return filter{}, fmt.Errorf("unknown filter operator %q", operator)
+	}
+	return f, nil
+}
+
+// splitTopLevel splits s by commas which are not inside parentheses, braces or quotes
+func splitTopLevel(s string) ([]string, error) {
+	var (
+		parts   []string
+		depth   int
+		quoted  bool
+		escaped bool
+		start   int
+	)
+	for i, c := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '(' || c == '{':
+			depth++
+		case c == ')' || c == '}':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses in %q", s)
+			}
+		case c == ',' && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	if depth != 0 || quoted {
+		return nil, fmt.Errorf("unbalanced parentheses or quotes in %q", s)
+	}
+	if s != "" {
+		parts = append(parts, s[start:])
+	}
+	return parts, nil
+}
+
+func unquote(s string) string {
+	if len(s) < 2 || !strings.HasPrefix(s, `"`) || !strings.HasSuffix(s, `"`) {
+		return s
+	}
+	return strings.NewReplacer(`\\`, `\`, `\"`, `"`).Replace(s[1 : len(s)-1])
+}
+
+func (f filter) match(inst instance) bool {
+	var res bool
+	switch f.operator {
+	case "and":
+		res = true
+		for _, child := range f.children {
+			if !child.match(inst) {
+				res = false
+				break
+			}
+		}
+	case "or":
+		for _, child := range f.children {
+			if child.match(inst) {
+				res = true
+				break
+			}
+		}
+	default:
+		res = f.matchValue(lookup(inst, f.property))
+	}
+	return res != f.negate
+}
+
+func (f filter) matchValue(v any) bool {
+	switch f.operator {
+	case "is":
+		if f.value == "null" {
+			return v == nil
+		}
+		b, ok := v.(bool)
+		return ok && strconv.FormatBool(b) == f.value
+	case "cs":
+		values := make([]string, 0)
+		if list, ok := v.([]any); ok {
+			for _, item := range list {
+				values = append(values, format(item))
+			}
+		}
+		for _, item := range f.list {
+			if !contains(values, item) {
+				return false
+			}
+		}
+		return true
+	}
+	if v == nil {
+		return false
+	}
+	switch f.operator {
+	case "eq":
+		return format(v) == f.value
+	case "neq":
+		return format(v) != f.value
+	case "gt":
+		return compare(v, f.value) > 0
+	case "gte":
+		return compare(v, f.value) >= 0
+	case "lt":
+		return compare(v, f.value) < 0
+	case "lte":
+		return compare(v, f.value) <= 0
+	case "like", "ilike":
+		return likePattern(f.value, f.operator == "ilike").MatchString(format(v))
+	case "in":
+		return contains(f.list, format(v))
+	}
+	return false
+}
+
+func likePattern(pattern string, caseInsensitive bool) *regexp.Regexp {
+	var b strings.Builder
+	if caseInsensitive {
+		b.WriteString("(?i)")
+	}
+	b.WriteString("^")
+	for _, c := range pattern {
+		switch c {
+		case '*', '%':
+			b.WriteString(".*")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// lookup returns value of the property, nested values are addressed with -> and ->>,
+// e.g. protection_data->>source_id
+func lookup(inst instance, property string) any {
+	path := strings.Split(strings.ReplaceAll(property, "->>", "->"), "->")
+	var v any = inst
+	for _, key := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
+
+// format renders value the way it appears in filters
+func format(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return x
+	case bool:
+		return strconv.FormatBool(x)
+	case json.Number:
+		return x.String()
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case int:
+		return strconv.Itoa(x)
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// compare compares values as numbers when both are numeric and as strings otherwise
+func compare(a, b any) int {
+	fa, okA := floatOf(a)
+	fb, okB := floatOf(b)
+	if okA && okB {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(format(a), format(b))
+}
+
+type orderField struct {
+	property string
+	desc     bool
+}
+
+// parseOrder parses order argument, e.g. name,size.desc
+func parseOrder(s string) ([]orderField, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var res []orderField
+	for _, p := range strings.Split(s, ",") {
+		parts := strings.Split(p, ".")
+		f := orderField{property: parts[0]}
+		for _, modifier := range parts[1:] {
+			switch modifier {
+			case "asc", "nullsfirst", "nullslast":
+			case "desc":
+				f.desc = true
+			default:
+				return nil, fmt.Errorf("invalid order %q", p)
+			}
+		}
+		res = append(res, f)
+	}
+	return res, nil
+}
+
+// sortInstances orders instances, unset values are placed last
+func sortInstances(items []instance, order []orderField) {
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, o := range order {
+			a, b := lookup(items[i], o.property), lookup(items[j], o.property)
+			switch {
+			case a == nil && b == nil:
+				continue
+			case a == nil:
+				return false
+			case b == nil:
+				return true
+			}
+			c := compare(a, b)
+			if c == 0 {
+				continue
+			}
+			return (c < 0) != o.desc
+		}
+		return false
+	})
+}
+
+func parsePage(q url.Values) (offset, limit int, err error) {
+	limit = DefaultPageSize
+	if v := q.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("invalid offset %q", v)
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 1 || limit > MaxPageSize {
+			return 0, 0, fmt.Errorf("invalid limit %q, it must be between 1 and %d", v, MaxPageSize)
+		}
+	}
+	return offset, limit, nil
+}
+
+// list serves collection query, partial pages are returned with 206 and Content-Range first-last/total
+func (s *Server) list(w http.ResponseWriter, r *http.Request, res *resource) {
+	q := r.URL.Query()
+	fields, err := parseSelect(q.Get("select"))
+	if err != nil {
+		writeError(w, errBadRequest("Invalid select: %s.", err.Error()))
+		return
+	}
+	filters, err := parseFilters(q)
+	if err != nil {
+		writeError(w, errBadRequest("Invalid filter: %s.", err.Error()))
+		return
+	}
+	order, err := parseOrder(q.Get("order"))
+	if err != nil {
+		writeError(w, errBadRequest("Invalid order: %s.", err.Error()))
+		return
+	}
+	offset, limit, err := parsePage(q)
+	if err != nil {
+		writeError(w, errBadRequest("Invalid range: %s.", err.Error()))
+		return
+	}
+
+	items := s.store.find(res.name, func(inst instance) bool {
+		for _, f := range filters {
+			if !f.match(inst) {
+				return false
+			}
+		}
+		return true
+	})
+	sortInstances(items, order)
+	total := len(items)
+	if offset > 0 && offset >= total {
+		writeError(w, newError(http.StatusRequestedRangeNotSatisfiable,
+			"The requested range %d-%d is not satisfiable, the collection has %d instances.", offset, offset+limit-1, total))
+		return
+	}
+	page := items[offset:min(offset+limit, total)]
+	out := make([]instance, 0, len(page))
+	for _, inst := range page {
+		out = append(out, s.project(res, inst, fields))
+	}
+	status := http.StatusOK
+	if len(page) != 0 {
+		w.Header().Set("Content-Range", fmt.Sprintf("%d-%d/%d", offset, offset+len(page)-1, total))
+		if len(page) < total {
+			status = http.StatusPartialContent
+		}
+	}
+	writeJSON(w, status, out)
+}
+
+func (s *Server) get(r *http.Request, res *resource, id string) (int, any, *apiError) {
+	fields, err := parseSelect(r.URL.Query().Get("select"))
+	if err != nil {
+		return 0, nil, errBadRequest("Invalid select: %s.", err.Error())
+	}
+	inst, ok := s.store.get(res.name, id)
+	if !ok {
+		return 0, nil, errNotFound(res.name, id)
+	}
+	return http.StatusOK, s.project(res, inst, fields), nil
+}
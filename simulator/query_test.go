@@ -0,0 +1,89 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/dell/gopowerstore/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter_Match(t *testing.T) {
+	inst := instance{
+		"name":            "vol.1",
+		"size":            int64(4096),
+		"parent_id":       nil,
+		"is_replicated":   true,
+		"purposes":        []any{"Storage_Iscsi_Target", "Replication"},
+		"protection_data": instance{"source_id": "src-1"},
+	}
+	tests := []struct {
+		name   string
+		filter api.Filter
+		want   bool
+	}{
+		{"eq", api.Eq("name", "vol.1"), true},
+		{"neq", api.Neq("name", "vol.1"), false},
+		{"gt number", api.Gt("size", 1024), true},
+		{"lte number", api.Le("size", 1024), false},
+		{"like", api.Like("name", "vol*"), true},
+		{"ilike", api.ILike("name", "VOL.*"), true},
+		{"like literal dot", api.Like("name", "vol_1"), false},
+		{"in quoted", api.In("name", "a", "vol.1"), true},
+		{"contains", api.Contains("purposes", "Replication"), true},
+		{"contains missing", api.Contains("purposes", "Replication", "Other"), false},
+		{"is null", api.IsNull("parent_id"), true},
+		{"is true", api.Raw("is_replicated", "is.true"), true},
+		{"nested path", api.Eq("protection_data->>source_id", "src-1"), true},
+		{"not", api.Not(api.Eq("name", "vol.1")), false},
+		{"or", api.Or(api.Eq("name", "a"), api.Eq("name", "vol.1")), true},
+		{"not or", api.Not(api.Or(api.IsNull("parent_id"), api.Not(api.In("size", 1, 2)))), false},
+		{"nested and", api.Or(api.Eq("name", "a"), api.And(api.Gt("size", 1), api.Lt("size", 5))), false},
+		{"not and", api.Not(api.And(api.Eq("name", "vol.1"), api.Eq("size", 1))), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := url.ParseQuery((&api.QueryParams{}).Filter(tt.filter).Encode())
+			require.NoError(t, err)
+			filters, err := parseFilters(q)
+			require.NoError(t, err)
+			match := true
+			for _, f := range filters {
+				match = match && f.match(inst)
+			}
+			assert.Equal(t, tt.want, match)
+		})
+	}
+}
+
+func TestParseSelect(t *testing.T) {
+	fields, err := parseSelect("id,volume(id,appliance_id),*")
+	require.NoError(t, err)
+	assert.Equal(t, []selectField{
+		{name: "id"},
+		{name: "volume", relation: true, children: []selectField{{name: "id"}, {name: "appliance_id"}}},
+		{name: "*"},
+	}, fields)
+
+	_, err = parseSelect("volume(id")
+	assert.Error(t, err)
+}
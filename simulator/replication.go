@@ -0,0 +1,79 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package simulator
+
+const (
+	replicationSessionCollection = "replication_session"
+
+	rsStateOK         = "OK"
+	rsStatePaused     = "Paused"
+	rsStateFailedOver = "Failed_Over"
+	rsRoleSource      = "Source"
+	rsRoleDestination = "Destination"
+)
+
+// replicationSessionResource serves sessions added with Server.Insert, arrays create them
+// when a protection policy with a replication rule is assigned
+func replicationSessionResource() *resource {
+	return &resource{
+		name: replicationSessionCollection,
+		actions: map[string]actionFunc{
+			"pause": func(_ *Server, rs, _ instance) (any, *apiError) {
+				return nil, transition(rs, rsStatePaused, rsStateOK)
+			},
+			"resume": func(_ *Server, rs, _ instance) (any, *apiError) {
+				return nil, transition(rs, rsStateOK, rsStatePaused)
+			},
+			"sync": func(_ *Server, rs, _ instance) (any, *apiError) {
+				if str(rs, "role") == rsRoleDestination {
+					return nil, errBadRequest("The replication session %s can't be synchronized from destination.",
+						str(rs, "id"))
+				}
+				return nil, transition(rs, rsStateOK, rsStateOK)
+			},
+			"failover": func(_ *Server, rs, _ instance) (any, *apiError) {
+				if str(rs, "role") == rsRoleDestination {
+					return nil, errBadRequest("Unable to failover replication session %s from destination.", str(rs, "id"))
+				}
+				if apiErr := transition(rs, rsStateFailedOver, rsStateOK, rsStatePaused); apiErr != nil {
+					return nil, apiErr
+				}
+				rs["role"] = rsRoleDestination
+				return nil, nil
+			},
+			"reprotect": func(_ *Server, rs, _ instance) (any, *apiError) {
+				if apiErr := transition(rs, rsStateOK, rsStateFailedOver); apiErr != nil {
+					return nil, apiErr
+				}
+				rs["role"] = rsRoleSource
+				return nil, nil
+			},
+		},
+	}
+}
+
+// transition moves the session to the state if it is in one of allowed states
+func transition(rs instance, state string, allowed ...string) *apiError {
+	current := str(rs, "state")
+	if !contains(allowed, current) {
+		return errBadRequest("The replication session %s can't move from state %s to %s.", str(rs, "id"), current, state)
+	}
+	rs["state"] = state
+	return nil
+}
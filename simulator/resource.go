@@ -0,0 +1,204 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// relation resolves instances related to an instance, e.g. volume of a host volume mapping
+type relation struct {
+	collection string
+	many       bool
+	resolve    func(st *store, inst instance) []instance
+}
+
+// hasOne is a relation to the instance which id is stored in key
+func hasOne(collection, key string) relation {
+	return relation{collection: collection, resolve: func(st *store, inst instance) []instance {
+		if related, ok := st.get(collection, str(inst, key)); ok {
+			return []instance{related}
+		}
+		return nil
+	}}
+}
+
+// hasMany is a relation to instances which store id of the instance in key
+func hasMany(collection, key string) relation {
+	return relation{collection: collection, many: true, resolve: func(st *store, inst instance) []instance {
+		return st.find(collection, byField(key, str(inst, "id")))
+	}}
+}
+
+type (
+	createFunc func(s *Server, body instance) (string, *apiError)
+	modifyFunc func(s *Server, inst, body instance) *apiError
+	deleteFunc func(s *Server, inst, body instance) *apiError
+	actionFunc func(s *Server, inst, body instance) (any, *apiError)
+)
+
+// resource describes a collection endpoint, nil handlers make the method unsupported
+type resource struct {
+	name      string
+	relations map[string]relation
+	create    createFunc
+	modify    modifyFunc
+	delete    deleteFunc
+	actions   map[string]actionFunc
+}
+
+func newResources() map[string]*resource {
+	res := map[string]*resource{}
+	for _, r := range []*resource{
+		volumeResource(),
+		hostResource(),
+		hostGroupResource(),
+		hostVolumeMappingResource(),
+		volumeGroupResource(),
+		nasServerResource(),
+		nfsServerResource(),
+		fileSystemResource(),
+		nfsExportResource(),
+		smbShareResource(),
+		replicationSessionResource(),
+		clusterResource(),
+		softwareInstalledResource(),
+	} {
+		res[r.name] = r
+	}
+	return res
+}
+
+type createdResponse struct {
+	ID string `json:"id"`
+}
+
+func (s *Server) create(r *http.Request, res *resource) (int, any, *apiError) {
+	if res.create == nil {
+		return 0, nil, errMethodNotAllowed(r.Method, res.name)
+	}
+	body, apiErr := decodeBody(r)
+	if apiErr != nil {
+		return 0, nil, apiErr
+	}
+	id, apiErr := res.create(s, body)
+	if apiErr != nil {
+		return 0, nil, apiErr
+	}
+	return http.StatusCreated, createdResponse{ID: id}, nil
+}
+
+func (s *Server) modify(r *http.Request, res *resource, id string) (int, any, *apiError) {
+	if res.modify == nil {
+		return 0, nil, errMethodNotAllowed(r.Method, res.name)
+	}
+	inst, body, apiErr := s.target(r, res, id)
+	if apiErr != nil {
+		return 0, nil, apiErr
+	}
+	return http.StatusNoContent, nil, res.modify(s, inst, body)
+}
+
+func (s *Server) delete(r *http.Request, res *resource, id string) (int, any, *apiError) {
+	if res.delete == nil {
+		return 0, nil, errMethodNotAllowed(r.Method, res.name)
+	}
+	inst, body, apiErr := s.target(r, res, id)
+	if apiErr != nil {
+		return 0, nil, apiErr
+	}
+	return http.StatusNoContent, nil, res.delete(s, inst, body)
+}
+
+func (s *Server) action(r *http.Request, res *resource, id, name string) (int, any, *apiError) {
+	fn, ok := res.actions[name]
+	if !ok {
+		return 0, nil, errNotFound("endpoint", res.name+"/"+id+"/"+name)
+	}
+	inst, body, apiErr := s.target(r, res, id)
+	if apiErr != nil {
+		return 0, nil, apiErr
+	}
+	resp, apiErr := fn(s, inst, body)
+	if apiErr != nil {
+		return 0, nil, apiErr
+	}
+	switch resp.(type) {
+	case nil:
+		return http.StatusNoContent, nil, nil
+	case createdResponse:
+		return http.StatusCreated, resp, nil
+	}
+	return http.StatusOK, resp, nil
+}
+
+// target returns the instance addressed by the request and the decoded request body
+func (s *Server) target(r *http.Request, res *resource, id string) (instance, instance, *apiError) {
+	inst, ok := s.store.get(res.name, id)
+	if !ok {
+		return nil, nil, errNotFound(res.name, id)
+	}
+	body, apiErr := decodeBody(r)
+	return inst, body, apiErr
+}
+
+func decodeBody(r *http.Request) (instance, *apiError) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, errBadRequest("Failed to read request body: %s.", err.Error())
+	}
+	body := instance{}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return body, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return nil, errBadRequest("Invalid request body: %s.", err.Error())
+	}
+	return body, nil
+}
+
+// requireName returns the name from the body or an error if it is empty
+func requireName(body instance) (string, *apiError) {
+	name := str(body, "name")
+	if name == "" {
+		return "", errBadRequest("The name is required.")
+	}
+	return name, nil
+}
+
+// requireInstance returns the referenced instance or not found error
+func (s *Server) requireInstance(collection, id string) (instance, *apiError) {
+	inst, ok := s.store.get(collection, id)
+	if !ok {
+		return nil, errNotFound(collection, id)
+	}
+	return inst, nil
+}
+
+// nameTaken returns true if another instance of the collection matching scope has the name
+func (s *Server) nameTaken(collection, name, exceptID string, scope func(instance) bool) bool {
+	return len(s.store.find(collection, func(inst instance) bool {
+		return str(inst, "name") == name && str(inst, "id") != exceptID && (scope == nil || scope(inst))
+	})) != 0
+}
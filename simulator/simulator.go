@@ -0,0 +1,487 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package simulator serves a stateful in-memory PowerStore REST API over httptest.
+//
+// The simulator keeps volumes, snapshots, hosts, host groups, host volume mappings, volume groups,
+// NAS servers, file systems, NFS exports, SMB shares, replication sessions and the cluster with its
+// installed software. It rejects requests which an array would reject, e.g. deleting a volume which
+// is still mapped to a host. Collections support select, PostgREST-like filters, order and offset/limit
+// pagination with Content-Range headers, so multi-step flows can be tested through a real gopowerstore
+// client:
+//
+//	sim := simulator.New(simulator.Options{})
+//	defer sim.Close()
+//	client, err := gopowerstore.NewClientWithArgs(sim.URL(), sim.Username(), sim.Password(),
+//		gopowerstore.NewClientOptions().SetDefaultTimeout(10*time.Second))
+//
+// Errors are reported in PowerStore format. Not found errors carry the PowerStore error code, other errors
+// carry messages which the client classifies, so errors.Is matches them with the api error conditions.
+package simulator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dell/gopowerstore/api"
+)
+
+const (
+	// BasePath is the path under which the simulator serves the REST API
+	BasePath = "/api/rest"
+	// DefaultUsername is the user accepted when Options.Username is empty
+	DefaultUsername = "admin"
+	// DefaultPassword is the password accepted when Options.Password is empty
+	DefaultPassword = "Password123!" // #nosec G101
+	// DefaultPageSize is the number of instances returned by collection queries without limit
+	DefaultPageSize = 100
+	// MaxPageSize is the largest limit accepted by collection queries
+	MaxPageSize = 2000
+	// DefaultClusterName is the cluster name used when Options.ClusterName is empty
+	DefaultClusterName = "PowerStore-Simulator"
+	// DefaultSoftwareVersion is the software version used when Options.SoftwareVersion is empty
+	DefaultSoftwareVersion = "4.1.0.0"
+
+	defaultIdleTimeout = time.Hour
+	loginSessionURL    = "login_session"
+	logoutURL          = "logout"
+	authCookie         = "auth_cookie"
+	tokenHeader        = "DELL-EMC-TOKEN" // #nosec G101
+)
+
+// Options holds settings of the simulator
+type Options struct {
+	// credentials accepted by the simulator, DefaultUsername and DefaultPassword are used when empty
+	Username string
+	Password string
+	// idle time after which login sessions expire, one hour when zero
+	IdleTimeout time.Duration
+	// serve https with a self-signed certificate, clients need ClientOptions.SetInsecure(true)
+	TLS bool
+	// largest number of file systems of a NAS server, no limit when zero
+	FileSystemLimit int
+	// name and global id of the cluster, a random global id is generated when empty
+	ClusterName string
+	GlobalID    string
+	// release version of the installed software, DefaultSoftwareVersion is used when empty
+	SoftwareVersion string
+}
+
+// Fault makes the simulator delay or fail requests matching Method and Endpoint
+type Fault struct {
+	// http method to match, any method when empty
+	Method string
+	// endpoint path below BasePath to match, e.g. "volume" matches "volume" and "volume/{id}/snapshot",
+	// any endpoint when empty
+	Endpoint string
+	// delay of the response
+	Delay time.Duration
+	// status of the error response, the request is served normally after Delay when zero
+	Status int
+	// entries of the error response body
+	Messages []api.ErrorMessage
+	// headers of the error response, e.g. Retry-After
+	Header http.Header
+	// close the connection without response
+	Drop bool
+	// number of requests the fault applies to, every matching request when zero
+	Times int
+}
+
+// Request is a request received by the simulator
+type Request struct {
+	Method string
+	// path below BasePath, e.g. "volume/{id}/snapshot"
+	Endpoint string
+	Query    url.Values
+}
+
+type session struct {
+	token    string
+	lastUsed time.Time
+}
+
+// Server is an in-memory PowerStore array served over httptest
+type Server struct {
+	opts      Options
+	server    *httptest.Server
+	resources map[string]*resource
+
+	mu       sync.Mutex
+	store    *store
+	sessions map[string]*session
+	faults   []*Fault
+	latency  time.Duration
+	requests []Request
+}
+
+// New starts a simulator, call Close to stop it
+func New(opts Options) *Server {
+	if opts.Username == "" {
+		opts.Username = DefaultUsername
+	}
+	if opts.Password == "" {
+		opts.Password = DefaultPassword
+	}
+	if opts.IdleTimeout <= 0 {
+		opts.IdleTimeout = defaultIdleTimeout
+	}
+	if opts.ClusterName == "" {
+		opts.ClusterName = DefaultClusterName
+	}
+	if opts.GlobalID == "" {
+		opts.GlobalID = "PS" + newHex(12)
+	}
+	if opts.SoftwareVersion == "" {
+		opts.SoftwareVersion = DefaultSoftwareVersion
+	}
+	s := &Server{
+		opts:      opts,
+		resources: newResources(),
+		sessions:  map[string]*session{},
+	}
+	s.server = httptest.NewUnstartedServer(s)
+	if opts.TLS {
+		s.server.StartTLS()
+	} else {
+		s.server.Start()
+	}
+	s.store = s.newStore()
+	return s
+}
+
+// URL returns the API URL to pass to gopowerstore clients
+func (s *Server) URL() string {
+	return s.server.URL + BasePath
+}
+
+// Username returns the user accepted by the simulator
+func (s *Server) Username() string {
+	return s.opts.Username
+}
+
+// Password returns the password accepted by the simulator
+func (s *Server) Password() string {
+	return s.opts.Password
+}
+
+// Close stops the simulator
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// GlobalID returns the global id of the simulated cluster
+func (s *Server) GlobalID() string {
+	return s.opts.GlobalID
+}
+
+// Reset drops all instances except the cluster and installed software, sessions, faults and recorded requests
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store = s.newStore()
+	s.sessions = map[string]*session{}
+	s.faults = nil
+	s.latency = 0
+	s.requests = nil
+}
+
+// Insert adds an instance to a collection as is, e.g. a replication session, and returns its id.
+// The id is generated when the instance has none.
+func (s *Server) Insert(collection string, inst map[string]any) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.store.insert(collection, normalize(inst))
+}
+
+// Instance returns a copy of an instance of a collection as the API returns it with select=*
+func (s *Server) Instance(collection, id string) (map[string]any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inst, ok := s.store.get(collection, id)
+	if !ok {
+		return nil, false
+	}
+	return public(inst), true
+}
+
+// Instances returns copies of all instances of a collection in creation order, see Instance
+func (s *Server) Instances(collection string) []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := s.store.list(collection)
+	res := make([]map[string]any, 0, len(items))
+	for _, inst := range items {
+		res = append(res, public(inst))
+	}
+	return res
+}
+
+// SetLatency delays every response by d
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// InjectFault adds a fault, faults are applied in the order they were added and only the first
+// matching fault applies to a request
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// ExpireSessions drops all login sessions, the next request of a client with a session is rejected with 403
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sess := range s.sessions {
+		sess.lastUsed = time.Time{}
+	}
+}
+
+// Requests returns requests received by the simulator
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	endpoint, ok := strings.CutPrefix(r.URL.Path, BasePath+"/")
+	if !ok {
+		writeError(w, errNotFound("endpoint", r.URL.Path))
+		return
+	}
+	endpoint = strings.Trim(endpoint, "/")
+
+	fault, delay := s.receive(r, endpoint)
+	if !sleep(r.Context(), delay) {
+		return
+	}
+	if fault != nil {
+		if fault.Drop {
+			dropConnection(w)
+			return
+		}
+		if fault.Status != 0 {
+			for k, values := range fault.Header {
+				w.Header()[k] = values
+			}
+			writeJSON(w, fault.Status, errorBody{Messages: fault.Messages})
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if endpoint == loginSessionURL {
+		s.login(w, r)
+		return
+	}
+	sess, apiErr := s.authenticate(r)
+	if apiErr != nil {
+		writeError(w, apiErr)
+		return
+	}
+	if endpoint == logoutURL && r.Method == http.MethodPost {
+		if sess != nil {
+			delete(s.sessions, sessionID(r))
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	s.route(w, r, endpoint)
+}
+
+// receive records the request and returns the fault which applies to it with the total delay
+func (s *Server) receive(r *http.Request, endpoint string) (*Fault, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, Request{Method: r.Method, Endpoint: endpoint, Query: r.URL.Query()})
+	for i, f := range s.faults {
+		if !f.matches(r.Method, endpoint) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f, s.latency + f.Delay
+	}
+	return nil, s.latency
+}
+
+func (f *Fault) matches(method, endpoint string) bool {
+	if f.Method != "" && !strings.EqualFold(f.Method, method) {
+		return false
+	}
+	return f.Endpoint == "" || endpoint == f.Endpoint || strings.HasPrefix(endpoint, f.Endpoint+"/")
+}
+
+// login creates a session for valid basic auth credentials
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, errMethodNotAllowed(r.Method, loginSessionURL))
+		return
+	}
+	if !s.validCredentials(r) {
+		writeError(w, errUnauthorized())
+		return
+	}
+	id := newID()
+	sess := &session{token: newHex(32), lastUsed: time.Now()}
+	s.sessions[id] = sess
+	http.SetCookie(w, &http.Cookie{Name: authCookie, Value: id, Path: "/", HttpOnly: true})
+	w.Header().Set(tokenHeader, sess.token)
+	writeJSON(w, http.StatusOK, []instance{{
+		"id":                          id,
+		"user":                        s.opts.Username,
+		"role_ids":                    []any{"1"},
+		"idle_timeout":                int64(s.opts.IdleTimeout / time.Second),
+		"is_password_change_required": false,
+		"is_built_in_user":            true,
+	}})
+}
+
+// authenticate accepts requests with a valid session or, for GET requests, valid basic auth credentials.
+// Modifying requests need a session and its token like on arrays.
+func (s *Server) authenticate(r *http.Request) (*session, *apiError) {
+	if id := sessionID(r); id != "" {
+		sess, ok := s.sessions[id]
+		if !ok || time.Since(sess.lastUsed) > s.opts.IdleTimeout {
+			delete(s.sessions, id)
+			return nil, errForbidden("session has expired")
+		}
+		if r.Method != http.MethodGet && r.Header.Get(tokenHeader) != sess.token {
+			return nil, errForbidden("missing or invalid " + tokenHeader + " header")
+		}
+		sess.lastUsed = time.Now()
+		return sess, nil
+	}
+	if !s.validCredentials(r) {
+		return nil, errUnauthorized()
+	}
+	if r.Method != http.MethodGet {
+		return nil, errForbidden("missing or invalid " + tokenHeader + " header")
+	}
+	return nil, nil
+}
+
+func (s *Server) validCredentials(r *http.Request) bool {
+	user, password, ok := r.BasicAuth()
+	return ok && user == s.opts.Username && password == s.opts.Password
+}
+
+func sessionID(r *http.Request) string {
+	cookie, err := r.Cookie(authCookie)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// route serves collection, instance and action endpoints
+func (s *Server) route(w http.ResponseWriter, r *http.Request, endpoint string) {
+	parts := strings.Split(endpoint, "/")
+	res, ok := s.resources[parts[0]]
+	if !ok || len(parts) > 3 {
+		writeError(w, errNotFound("endpoint", endpoint))
+		return
+	}
+	var (
+		status int
+		body   any
+		apiErr *apiError
+	)
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		s.list(w, r, res)
+		return
+	case len(parts) == 2 && r.Method == http.MethodGet:
+		status, body, apiErr = s.get(r, res, parts[1])
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		status, body, apiErr = s.create(r, res)
+	case len(parts) == 2 && r.Method == http.MethodPatch:
+		status, body, apiErr = s.modify(r, res, parts[1])
+	case len(parts) == 2 && r.Method == http.MethodDelete:
+		status, body, apiErr = s.delete(r, res, parts[1])
+	case len(parts) == 3 && r.Method == http.MethodPost:
+		status, body, apiErr = s.action(r, res, parts[1], parts[2])
+	default:
+		apiErr = errMethodNotAllowed(r.Method, endpoint)
+	}
+	if apiErr != nil {
+		writeError(w, apiErr)
+		return
+	}
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+	writeJSON(w, status, body)
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func dropConnection(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		panic(http.ErrAbortHandler)
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+	_ = conn.Close()
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
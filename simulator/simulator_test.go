@@ -0,0 +1,479 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dell/gopowerstore"
+	"github.com/dell/gopowerstore/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, sim *Server) gopowerstore.Client {
+	c, err := gopowerstore.NewClientWithArgs(sim.URL(), sim.Username(), sim.Password(),
+		gopowerstore.NewClientOptions().SetDefaultTimeout(10*time.Second))
+	require.NoError(t, err)
+	return c
+}
+
+func newTestServer(t *testing.T) (*Server, gopowerstore.Client) {
+	sim := New(Options{})
+	t.Cleanup(sim.Close)
+	return sim, newTestClient(t, sim)
+}
+
+func asAPIError(t *testing.T, err error) *gopowerstore.APIError {
+	var apiErr gopowerstore.APIError
+	require.True(t, errors.As(err, &apiErr), "unexpected error %v", err)
+	return &apiErr
+}
+
+func newVolume(t *testing.T, c gopowerstore.Client, name string) string {
+	size := int64(1024 * 1024 * 1024)
+	resp, err := c.CreateVolume(context.Background(), &gopowerstore.VolumeCreate{Name: &name, Size: &size})
+	require.NoError(t, err)
+	return resp.ID
+}
+
+func newHost(t *testing.T, c gopowerstore.Client, name, iqn string) string {
+	osType := gopowerstore.OSTypeEnumLinux
+	portType := gopowerstore.InitiatorProtocolTypeEnumISCSI
+	resp, err := c.CreateHost(context.Background(), &gopowerstore.HostCreate{
+		Name:       &name,
+		OsType:     &osType,
+		Initiators: &[]gopowerstore.InitiatorCreateModify{{PortName: &iqn, PortType: &portType}},
+	})
+	require.NoError(t, err)
+	return resp.ID
+}
+
+func TestServer_VolumeLifecycle(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestServer(t)
+
+	volID := newVolume(t, c, "vol-1")
+	hostID := newHost(t, c, "host-1", "iqn.1994-05.com.redhat:1")
+	_, err := c.AttachVolumeToHost(ctx, hostID, &gopowerstore.HostVolumeAttach{VolumeID: &volID})
+	require.NoError(t, err)
+
+	mappings, err := c.GetHostVolumeMappingByVolumeID(ctx, volID)
+	require.NoError(t, err)
+	require.Len(t, mappings, 1)
+	assert.Equal(t, hostID, mappings[0].HostID)
+	assert.Equal(t, int64(1), mappings[0].LogicalUnitNumber)
+
+	snapName := "snap-1"
+	snap, err := c.CreateSnapshot(ctx, &gopowerstore.SnapshotCreate{Name: &snapName}, volID)
+	require.NoError(t, err)
+	_, err = c.CreateSnapshot(ctx, &gopowerstore.SnapshotCreate{Name: &snapName}, volID)
+	assert.True(t, asAPIError(t, err).SnapshotNameIsAlreadyUse())
+	assert.ErrorIs(t, err, api.ErrNameAlreadyInUse)
+	snapshots, err := c.GetSnapshotsByVolumeID(ctx, volID)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, snap.ID, snapshots[0].ID)
+	assert.Equal(t, gopowerstore.VolumeTypeEnumSnapshot, snapshots[0].Type)
+
+	_, err = c.DeleteVolume(ctx, nil, volID)
+	assert.True(t, asAPIError(t, err).VolumeAttachedToHost())
+	assert.ErrorIs(t, err, api.ErrVolumeAttachedToHost)
+
+	_, err = c.DetachVolumeFromHost(ctx, hostID, &gopowerstore.HostVolumeDetach{VolumeID: &volID})
+	require.NoError(t, err)
+	_, err = c.DetachVolumeFromHost(ctx, hostID, &gopowerstore.HostVolumeDetach{VolumeID: &volID})
+	assert.True(t, asAPIError(t, err).HostIsNotAttachedToVolume())
+	assert.ErrorIs(t, err, api.ErrHostNotAttachedToVolume)
+
+	_, err = c.DeleteVolume(ctx, nil, volID)
+	require.NoError(t, err)
+	_, err = c.GetVolume(ctx, volID)
+	assert.True(t, asAPIError(t, err).NotFound())
+	_, err = c.GetSnapshot(ctx, snap.ID)
+	assert.True(t, asAPIError(t, err).NotFound(), "snapshots are deleted with the volume")
+}
+
+func TestServer_VolumeConstraints(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestServer(t)
+	volID := newVolume(t, c, "vol-1")
+
+	name := "vol-1"
+	size := int64(1024 * 1024 * 1024)
+	_, err := c.CreateVolume(ctx, &gopowerstore.VolumeCreate{Name: &name, Size: &size})
+	assert.True(t, asAPIError(t, err).VolumeNameIsAlreadyUse())
+	assert.ErrorIs(t, err, api.ErrNameAlreadyInUse)
+
+	name, size = "vol-2", 1000
+	_, err = c.CreateVolume(ctx, &gopowerstore.VolumeCreate{Name: &name, Size: &size})
+	assert.Equal(t, http.StatusBadRequest, asAPIError(t, err).StatusCode)
+
+	_, err = c.ModifyVolume(ctx, &gopowerstore.VolumeModify{Size: 8192}, volID)
+	assert.Equal(t, http.StatusUnprocessableEntity, asAPIError(t, err).StatusCode)
+
+	snapName := "snap-1"
+	snap, err := c.CreateSnapshot(ctx, &gopowerstore.SnapshotCreate{Name: &snapName}, volID)
+	require.NoError(t, err)
+	hostID := newHost(t, c, "host-1", "iqn.1994-05.com.redhat:1")
+	_, err = c.AttachVolumeToHost(ctx, hostID, &gopowerstore.HostVolumeAttach{VolumeID: &snap.ID})
+	assert.Equal(t, http.StatusUnprocessableEntity, asAPIError(t, err).StatusCode)
+
+	cloneName := "clone-1"
+	clone, err := c.CreateVolumeFromSnapshot(ctx, &gopowerstore.VolumeClone{Name: &cloneName}, snap.ID)
+	require.NoError(t, err)
+	vol, err := c.GetVolume(ctx, clone.ID)
+	require.NoError(t, err)
+	assert.Equal(t, gopowerstore.VolumeTypeEnumClone, vol.Type)
+	assert.Equal(t, volID, vol.ProtectionData.SourceID)
+	assert.Equal(t, snap.ID, vol.ProtectionData.ParentID)
+
+	_, err = c.GetVolume(ctx, "missing")
+	assert.True(t, asAPIError(t, err).NotFound())
+	assert.ErrorIs(t, err, api.ErrNotFound)
+}
+
+func TestServer_HostGroups(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestServer(t)
+	hostID := newHost(t, c, "host-1", "iqn.1994-05.com.redhat:1")
+	osType := gopowerstore.OSTypeEnumLinux
+	name, iqn := "host-2", "iqn.1994-05.com.redhat:1"
+	_, err := c.CreateHost(ctx, &gopowerstore.HostCreate{
+		Name: &name, OsType: &osType, Initiators: &[]gopowerstore.InitiatorCreateModify{{PortName: &iqn}},
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, asAPIError(t, err).StatusCode, "initiator is used by host-1")
+
+	group, err := c.CreateHostGroup(ctx, &gopowerstore.HostGroupCreate{Name: "group-1", HostIDs: []string{hostID}})
+	require.NoError(t, err)
+	volID := newVolume(t, c, "vol-1")
+	_, err = c.AttachVolumeToHostGroup(ctx, group.ID, &gopowerstore.HostVolumeAttach{VolumeID: &volID})
+	require.NoError(t, err)
+
+	hg, err := c.GetHostGroup(ctx, group.ID)
+	require.NoError(t, err)
+	require.Len(t, hg.Hosts, 1)
+	assert.Equal(t, "host-1", hg.Hosts[0].Name)
+	require.Len(t, hg.MappedHostGroups, 1)
+	assert.Equal(t, volID, hg.MappedHostGroups[0].VolumeID)
+
+	_, err = c.DeleteHost(ctx, nil, hostID)
+	assert.Equal(t, http.StatusUnprocessableEntity, asAPIError(t, err).StatusCode, "host is a member of group-1")
+	_, err = c.DeleteHostGroup(ctx, group.ID)
+	assert.Equal(t, http.StatusUnprocessableEntity, asAPIError(t, err).StatusCode, "volume is attached to group-1")
+	_, err = c.DetachVolumeFromHostGroup(ctx, group.ID, &gopowerstore.HostVolumeDetach{VolumeID: &volID})
+	require.NoError(t, err)
+	_, err = c.DeleteHostGroup(ctx, group.ID)
+	require.NoError(t, err)
+	host, err := c.GetHost(ctx, hostID)
+	require.NoError(t, err)
+	assert.Empty(t, host.HostGroupID)
+}
+
+func TestServer_VolumeGroups(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestServer(t)
+	vol1, vol2 := newVolume(t, c, "vol-1"), newVolume(t, c, "vol-2")
+
+	group, err := c.CreateVolumeGroup(ctx, &gopowerstore.VolumeGroupCreate{Name: "vg-1", VolumeIDs: []string{vol1}})
+	require.NoError(t, err)
+	_, err = c.AddMembersToVolumeGroup(ctx, &gopowerstore.VolumeGroupMembers{VolumeIDs: []string{vol2}}, group.ID)
+	require.NoError(t, err)
+	vg, err := c.GetVolumeGroup(ctx, group.ID)
+	require.NoError(t, err)
+	assert.Len(t, vg.Volumes, 2)
+
+	snap, err := c.CreateVolumeGroupSnapshot(ctx, group.ID, &gopowerstore.VolumeGroupSnapshotCreate{Name: "vg-snap"})
+	require.NoError(t, err)
+	snapGroup, err := c.GetVolumeGroupSnapshot(ctx, snap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, gopowerstore.VolumeTypeEnumSnapshot, snapGroup.Type)
+	assert.Len(t, snapGroup.Volumes, 2)
+
+	_, err = c.DeleteVolume(ctx, nil, vol1)
+	assert.Equal(t, http.StatusUnprocessableEntity, asAPIError(t, err).StatusCode, "vol-1 is a member of vg-1")
+	_, err = c.RemoveMembersFromVolumeGroup(ctx, &gopowerstore.VolumeGroupMembers{VolumeIDs: []string{vol1}}, group.ID)
+	require.NoError(t, err)
+	_, err = c.RemoveMembersFromVolumeGroup(ctx, &gopowerstore.VolumeGroupMembers{VolumeIDs: []string{vol1}}, group.ID)
+	assert.True(t, asAPIError(t, err).VolumeAlreadyRemovedFromVolumeGroup())
+	assert.ErrorIs(t, err, api.ErrVolumeNotInVolumeGroup)
+
+	_, err = c.DeleteVolumeGroup(ctx, group.ID)
+	assert.Equal(t, http.StatusUnprocessableEntity, asAPIError(t, err).StatusCode, "vg-1 is not empty")
+}
+
+func TestServer_FileStorage(t *testing.T) {
+	ctx := context.Background()
+	sim := New(Options{FileSystemLimit: 1})
+	defer sim.Close()
+	c := newTestClient(t, sim)
+
+	nas, err := c.CreateNAS(ctx, &gopowerstore.NASCreate{Name: "nas-1"})
+	require.NoError(t, err)
+	fs, err := c.CreateFS(ctx, &gopowerstore.FsCreate{Name: "fs-1", NASServerID: nas.ID, Size: 3 * 1024 * 1024 * 1024})
+	require.NoError(t, err)
+	_, err = c.CreateFS(ctx, &gopowerstore.FsCreate{Name: "fs-1", NASServerID: nas.ID, Size: 3 * 1024 * 1024 * 1024})
+	assert.True(t, asAPIError(t, err).FSNameIsAlreadyUse())
+	assert.ErrorIs(t, err, api.ErrNameAlreadyInUse)
+	_, err = c.CreateFS(ctx, &gopowerstore.FsCreate{Name: "fs-2", NASServerID: nas.ID, Size: 3 * 1024 * 1024 * 1024})
+	assert.True(t, asAPIError(t, err).FSCreationLimitReached())
+
+	export, err := c.CreateNFSExport(ctx, &gopowerstore.NFSExportCreate{
+		Name: "export-1", FileSystemID: fs.ID, Path: "/fs-1", ReadWriteHosts: []string{"10.0.0.1"},
+	})
+	require.NoError(t, err)
+	_, err = c.ModifyNFSExport(ctx, &gopowerstore.NFSExportModify{AddRWHosts: []string{"10.0.0.1"}}, export.ID)
+	assert.True(t, asAPIError(t, err).HostAlreadyPresentInNFSExport())
+	assert.ErrorIs(t, err, api.ErrHostAlreadyPresentInNFSExport)
+	_, err = c.ModifyNFSExport(ctx, &gopowerstore.NFSExportModify{RemoveRWHosts: []string{"10.0.0.2"}}, export.ID)
+	assert.True(t, asAPIError(t, err).HostAlreadyRemovedFromNFSExport())
+	assert.ErrorIs(t, err, api.ErrHostAlreadyRemovedFromNFSExport)
+
+	share, err := c.CreateSMBShare(ctx, &gopowerstore.SMBShareCreate{Name: "share-1", FileSystemID: fs.ID, Path: "/fs-1"})
+	require.NoError(t, err)
+	_, err = c.SetSMBShareACL(ctx, share.ID, &gopowerstore.ModifySMBShareACL{
+		AddAces: []gopowerstore.SMBShareAce{{
+			TrusteeType: "User", TrusteeName: "dom\\user", AccessLevel: "Read", AccessType: "Allow",
+		}},
+	})
+	require.NoError(t, err)
+	acl, err := c.GetSMBShareACL(ctx, share.ID)
+	require.NoError(t, err)
+	assert.Len(t, acl.Aces, 2)
+
+	_, err = c.DeleteFS(ctx, fs.ID)
+	assert.Equal(t, http.StatusUnprocessableEntity, asAPIError(t, err).StatusCode, "fs-1 has an export and a share")
+	_, err = c.DeleteNAS(ctx, nas.ID)
+	assert.Equal(t, http.StatusUnprocessableEntity, asAPIError(t, err).StatusCode, "nas-1 has a file system")
+	_, err = c.DeleteNFSExport(ctx, export.ID)
+	require.NoError(t, err)
+	_, err = c.DeleteSMBShare(ctx, share.ID)
+	require.NoError(t, err)
+	_, err = c.DeleteFS(ctx, fs.ID)
+	require.NoError(t, err)
+}
+
+func TestServer_ReplicationSession(t *testing.T) {
+	ctx := context.Background()
+	sim, c := newTestServer(t)
+	volID := newVolume(t, c, "vol-1")
+	id := sim.Insert(replicationSessionCollection, map[string]any{
+		"state":             rsStateOK,
+		"role":              rsRoleSource,
+		"resource_type":     "volume",
+		"local_resource_id": volID,
+	})
+
+	_, err := c.DeleteVolume(ctx, nil, volID)
+	assert.Equal(t, http.StatusUnprocessableEntity, asAPIError(t, err).StatusCode, "vol-1 is replicated")
+
+	_, err = c.ExecuteActionOnReplicationSession(ctx, id, gopowerstore.RsActionPause, nil)
+	require.NoError(t, err)
+	_, err = c.ExecuteActionOnReplicationSession(ctx, id, gopowerstore.RsActionFailover, nil)
+	require.NoError(t, err)
+	rs, err := c.GetReplicationSessionByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, gopowerstore.RsStateFailedOver, rs.State)
+	assert.Equal(t, rsRoleDestination, rs.Role)
+
+	_, err = c.ExecuteActionOnReplicationSession(ctx, id, gopowerstore.RsActionFailover, nil)
+	assert.True(t, asAPIError(t, err).UnableToFailoverFromDestination())
+	assert.ErrorIs(t, err, api.ErrFailoverFromDestination)
+	_, err = c.ExecuteActionOnReplicationSession(ctx, id, gopowerstore.RsActionReprotect, nil)
+	require.NoError(t, err)
+}
+
+func TestServer_Pagination(t *testing.T) {
+	ctx := context.Background()
+	sim, c := newTestServer(t)
+	for i := 5; i > 0; i-- {
+		newVolume(t, c, fmt.Sprintf("vol-%d", i))
+	}
+
+	var names []string
+	for vol, err := range gopowerstore.Paginate[gopowerstore.Volume](ctx, c, gopowerstore.RequestConfig{
+		Method:      "GET",
+		Endpoint:    volumeCollection,
+		QueryParams: c.APIClient().QueryParams().Select("id", "name").Order("name.desc"),
+	}, 2) {
+		require.NoError(t, err)
+		names = append(names, vol.Name)
+	}
+	assert.Equal(t, []string{"vol-5", "vol-4", "vol-3", "vol-2", "vol-1"}, names)
+
+	var pages []string
+	for _, r := range sim.Requests() {
+		if r.Method == http.MethodGet && r.Endpoint == volumeCollection {
+			pages = append(pages, r.Query.Get("offset"))
+		}
+	}
+	assert.Equal(t, []string{"0", "2", "4"}, pages)
+
+	var page []gopowerstore.Volume
+	meta, err := c.APIClient().Query(ctx, gopowerstore.RequestConfig{
+		Method:      "GET",
+		Endpoint:    volumeCollection,
+		QueryParams: c.APIClient().QueryParams().Offset(1).Limit(2),
+	}, &page)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, meta.Status)
+	assert.Equal(t, api.PaginationInfo{First: 1, Last: 2, Total: 5, IsPaginate: true}, meta.Pagination)
+
+	_, err = c.APIClient().Query(ctx, gopowerstore.RequestConfig{
+		Method:      "GET",
+		Endpoint:    volumeCollection,
+		QueryParams: c.APIClient().QueryParams().Offset(5),
+	}, &page)
+	assert.ErrorIs(t, err, api.ErrBadRange)
+}
+
+func TestServer_FiltersAndSelect(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestServer(t)
+	for _, name := range []string{"csi-a", "csi-b", "data"} {
+		newVolume(t, c, name)
+	}
+
+	var vols []map[string]any
+	_, err := c.APIClient().Query(ctx, gopowerstore.RequestConfig{
+		Method:   "GET",
+		Endpoint: volumeCollection,
+		QueryParams: c.APIClient().QueryParams().Select("name", "protection_data").Order("name.desc").
+			Filter(api.Or(api.Like("name", "csi-*"), api.Eq("name", "data")), api.Not(api.Eq("name", "csi-b"))),
+	}, &vols)
+	require.NoError(t, err)
+	require.Len(t, vols, 2)
+	assert.Equal(t, map[string]any{"name": "data", "protection_data": map[string]any{
+		"source_id": nil, "parent_id": nil, "creator_type": "User", "expiration_timestamp": nil,
+	}}, vols[0])
+	assert.Equal(t, "csi-a", vols[1]["name"])
+
+	_, err = c.APIClient().Query(ctx, gopowerstore.RequestConfig{
+		Method:      "GET",
+		Endpoint:    volumeCollection,
+		QueryParams: c.APIClient().QueryParams().Filter(api.Raw("name", "between.a")),
+	}, &vols)
+	var errMsg *api.ErrorMsg
+	require.ErrorAs(t, err, &errMsg)
+	assert.Equal(t, http.StatusBadRequest, errMsg.StatusCode)
+}
+
+func TestServer_Sessions(t *testing.T) {
+	ctx := context.Background()
+	sim, c := newTestServer(t)
+	newVolume(t, c, "vol-1")
+
+	sim.ExpireSessions()
+	newVolume(t, c, "vol-2")
+	logins := 0
+	for _, r := range sim.Requests() {
+		if r.Endpoint == loginSessionURL {
+			logins++
+		}
+	}
+	assert.Equal(t, 2, logins, "the client logs in again after the session expired")
+
+	bad, err := gopowerstore.NewClientWithArgs(sim.URL(), sim.Username(), "wrong",
+		gopowerstore.NewClientOptions().SetDefaultTimeout(10*time.Second))
+	require.NoError(t, err)
+	_, err = bad.GetVolumes(ctx)
+	assert.Equal(t, http.StatusUnauthorized, asAPIError(t, err).StatusCode)
+}
+
+func TestServer_Faults(t *testing.T) {
+	ctx := context.Background()
+	sim, c := newTestServer(t)
+	volID := newVolume(t, c, "vol-1")
+
+	sim.InjectFault(Fault{
+		Method:   http.MethodGet,
+		Endpoint: volumeCollection,
+		Status:   http.StatusServiceUnavailable,
+		Messages: []api.ErrorMessage{{Code: "0xE0000000FFFF", Severity: "Error", Message: "busy"}},
+		Times:    1,
+	})
+	_, err := c.GetVolume(ctx, volID)
+	apiErr := asAPIError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Equal(t, "busy", apiErr.Message)
+	_, err = c.GetVolume(ctx, volID)
+	assert.NoError(t, err, "the fault applies once")
+
+	sim.InjectFault(Fault{Endpoint: hostCollection, Drop: true})
+	_, err = c.GetHosts(ctx)
+	assert.Error(t, err)
+	sim.ClearFaults()
+	_, err = c.GetHosts(ctx)
+	assert.NoError(t, err)
+
+	sim.SetLatency(200 * time.Millisecond)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = c.GetVolume(timeoutCtx, volID)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestServer_Cluster(t *testing.T) {
+	ctx := context.Background()
+	sim := New(Options{ClusterName: "PS-1", SoftwareVersion: "3.6.0.0"})
+	t.Cleanup(sim.Close)
+	c := newTestClient(t, sim)
+
+	cluster, err := c.GetCluster(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "PS-1", cluster.Name)
+	assert.Equal(t, sim.GlobalID(), cluster.GlobalID)
+	assert.Regexp(t, "^PS[0-9a-f]{12}$", cluster.GlobalID)
+
+	software, err := c.GetSoftwareInstalled(ctx)
+	require.NoError(t, err)
+	require.Len(t, software, 1)
+	assert.True(t, software[0].IsCluster)
+	assert.Equal(t, "3.6.0.0", software[0].ReleaseVersion)
+}
+
+func TestServer_Failover(t *testing.T) {
+	ctx := context.Background()
+	sim, _ := newTestServer(t)
+	target, err := url.Parse(sim.URL())
+	require.NoError(t, err)
+	target.Path = ""
+	// the first management address serves the simulator until it goes down, connections aren't kept alive,
+	// so the requests after it fail to connect
+	proxy := httptest.NewUnstartedServer(httputil.NewSingleHostReverseProxy(target))
+	proxy.Config.SetKeepAlivesEnabled(false)
+	proxy.Start()
+
+	c, err := gopowerstore.NewClientWithArgs(proxy.URL+BasePath+","+sim.URL(), sim.Username(), sim.Password(),
+		gopowerstore.NewClientOptions().SetDefaultTimeout(10*time.Second))
+	require.NoError(t, err)
+	proxy.Close()
+	newVolume(t, c, "vol-1")
+	cluster, err := c.GetCluster(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sim.GlobalID(), cluster.GlobalID)
+}
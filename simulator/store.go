@@ -0,0 +1,261 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// instance is a resource as it is returned by the API, keys starting with "_" are internal
+// and are never returned
+type instance = map[string]any
+
+type collection struct {
+	items map[string]instance
+	// ids in creation order
+	ids []string
+}
+
+type store struct {
+	collections map[string]*collection
+	// sequence of namespace ids of volumes
+	nsid int64
+}
+
+func newStore() *store {
+	return &store{collections: map[string]*collection{}}
+}
+
+func (st *store) collection(name string) *collection {
+	c, ok := st.collections[name]
+	if !ok {
+		c = &collection{items: map[string]instance{}}
+		st.collections[name] = c
+	}
+	return c
+}
+
+func (st *store) get(name, id string) (instance, bool) {
+	inst, ok := st.collection(name).items[id]
+	return inst, ok
+}
+
+func (st *store) list(name string) []instance {
+	c := st.collection(name)
+	res := make([]instance, 0, len(c.ids))
+	for _, id := range c.ids {
+		res = append(res, c.items[id])
+	}
+	return res
+}
+
+// find returns instances of the collection which match fn in creation order
+func (st *store) find(name string, fn func(instance) bool) []instance {
+	var res []instance
+	for _, inst := range st.list(name) {
+		if fn(inst) {
+			res = append(res, inst)
+		}
+	}
+	return res
+}
+
+// insert adds the instance and returns its id, the id is generated when the instance has none
+func (st *store) insert(name string, inst instance) string {
+	id := str(inst, "id")
+	if id == "" {
+		id = newID()
+		inst["id"] = id
+	}
+	c := st.collection(name)
+	if _, ok := c.items[id]; !ok {
+		c.ids = append(c.ids, id)
+	}
+	c.items[id] = inst
+	return id
+}
+
+func (st *store) remove(name, id string) {
+	c := st.collection(name)
+	if _, ok := c.items[id]; !ok {
+		return
+	}
+	delete(c.items, id)
+	for i, v := range c.ids {
+		if v == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+}
+
+// byField returns a matcher of instances which key equals value
+func byField(key, value string) func(instance) bool {
+	return func(inst instance) bool {
+		return value != "" && str(inst, key) == value
+	}
+}
+
+func newHex(n int) string {
+	b := make([]byte, n/2)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func newID() string {
+	h := newHex(32)
+	return fmt.Sprintf("%s-%s-%s-%s-%s", h[:8], h[8:12], h[12:16], h[16:20], h[20:])
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05.000Z07:00")
+}
+
+// normalize returns a deep copy of the instance with values as they are decoded from JSON
+func normalize(inst instance) instance {
+	data, err := json.Marshal(inst)
+	if err != nil {
+		panic(err)
+	}
+	var res instance
+	if err := json.Unmarshal(data, &res); err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// public returns a copy of the instance without internal keys
+func public(inst instance) instance {
+	res := normalize(inst)
+	for k := range res {
+		if strings.HasPrefix(k, "_") {
+			delete(res, k)
+		}
+	}
+	return res
+}
+
+func str(inst instance, key string) string {
+	s, _ := inst[key].(string)
+	return s
+}
+
+// strOrNil returns nil for empty strings, arrays return null for unset references
+func strOrNil(inst instance, key string) any {
+	return nullable(str(inst, key))
+}
+
+func nullable(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func strList(v any) []string {
+	var res []string
+	switch list := v.(type) {
+	case []any:
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				res = append(res, s)
+			}
+		}
+	case []string:
+		res = append(res, list...)
+	}
+	return res
+}
+
+func anyList(values []string) []any {
+	res := make([]any, 0, len(values))
+	for _, v := range values {
+		res = append(res, v)
+	}
+	return res
+}
+
+func intOf(v any) (int64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	case float64:
+		return int64(n), n == float64(int64(n))
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	}
+	return 0, false
+}
+
+func floatOf(v any) (float64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case float64:
+		return n, true
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func boolOf(v any) (bool, bool) {
+	b, ok := v.(bool)
+	return b, ok
+}
+
+// copyFields copies keys present in body to the instance
+func copyFields(inst, body instance, keys ...string) {
+	for _, k := range keys {
+		if v, ok := body[k]; ok {
+			if n, isNumber := v.(json.Number); isNumber {
+				if i, err := n.Int64(); err == nil {
+					v = i
+				}
+			}
+			inst[k] = v
+		}
+	}
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
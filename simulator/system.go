@@ -0,0 +1,64 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"net/url"
+	"strings"
+)
+
+const (
+	clusterCollection           = "cluster"
+	softwareInstalledCollection = "software_installed"
+)
+
+// clusterResource serves the single cluster of the simulator
+func clusterResource() *resource {
+	return &resource{name: clusterCollection}
+}
+
+// softwareInstalledResource serves the software installed on the cluster
+func softwareInstalledResource() *resource {
+	return &resource{name: softwareInstalledCollection}
+}
+
+// newStore returns a store with the cluster and its installed software
+func (s *Server) newStore() *store {
+	st := newStore()
+	host := s.server.URL
+	if u, err := url.Parse(s.server.URL); err == nil {
+		host = u.Hostname()
+	}
+	st.insert(clusterCollection, instance{
+		"id":                 "0",
+		"global_id":          s.opts.GlobalID,
+		"name":               s.opts.ClusterName,
+		"management_address": host,
+		"state":              "Configured",
+		"system_time":        timestamp(),
+		"nvm_subsystem_nqn":  "nqn.1988-11.com.dell:powerstore:00:" + newHex(20),
+	})
+	st.insert(softwareInstalledCollection, instance{
+		"is_cluster":      true,
+		"release_version": s.opts.SoftwareVersion,
+		"build_version":   s.opts.SoftwareVersion,
+		"build_id":        strings.ReplaceAll(s.opts.SoftwareVersion, ".", ""),
+	})
+	return st
+}
@@ -0,0 +1,215 @@
+/*
+ *
+ * Copyright © 2025 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package simulator
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func volumeGroupResource() *resource {
+	return &resource{
+		name: volumeGroupCollection,
+		relations: map[string]relation{
+			"volumes": hasMany(volumeCollection, keyVolumeGroup),
+		},
+		create: createVolumeGroup,
+		modify: modifyVolumeGroup,
+		delete: deleteVolumeGroup,
+		actions: map[string]actionFunc{
+			"add_members": func(s *Server, group, body instance) (any, *apiError) {
+				if isSnapshot(group) {
+					return nil, errUnprocessable("Members of snapshot %s can't be changed.", str(group, "id"))
+				}
+				return nil, s.addVolumesToGroup(str(group, "id"), strList(body["volume_ids"]))
+			},
+			"remove_members": removeVolumeGroupMembers,
+			"snapshot":       snapshotVolumeGroup,
+		},
+	}
+}
+
+// addVolumesToGroup checks that volumes exist and don't belong to any group before adding them
+func (s *Server) addVolumesToGroup(groupID string, volumeIDs []string) *apiError {
+	volumes := make([]instance, 0, len(volumeIDs))
+	for _, id := range volumeIDs {
+		vol, apiErr := s.requireInstance(volumeCollection, id)
+		if apiErr != nil {
+			return apiErr
+		}
+		if isSnapshot(vol) {
+			return errUnprocessable("The snapshot %s can't be added to a volume group.", id)
+		}
+		if current := str(vol, keyVolumeGroup); current != "" {
+			return errUnprocessable("The volume %s is already a member of volume group %s.", id, current)
+		}
+		volumes = append(volumes, vol)
+	}
+	for _, vol := range volumes {
+		vol[keyVolumeGroup] = groupID
+	}
+	return nil
+}
+
+func (s *Server) newVolumeGroup(name, groupType string, body instance) instance {
+	group := instance{
+		"name":                         name,
+		"description":                  str(body, "description"),
+		"type":                         groupType,
+		"protection_policy_id":         strOrNil(body, "protection_policy_id"),
+		"is_write_order_consistent":    false,
+		"is_replication_destination":   false,
+		"is_importing":                 false,
+		"is_protectable":               true,
+		"placement_rule":               "Same_Appliance",
+		"creation_timestamp":           timestamp(),
+		"migration_session_id":         nil,
+		"metro_replication_session_id": nil,
+		"protection_data": instance{
+			"source_id":            nil,
+			"parent_id":            nil,
+			"creator_type":         "User",
+			"expiration_timestamp": strOrNil(body, "expiration_timestamp"),
+		},
+	}
+	copyFields(group, body, "is_write_order_consistent")
+	return group
+}
+
+func createVolumeGroup(s *Server, body instance) (string, *apiError) {
+	name, apiErr := requireName(body)
+	if apiErr != nil {
+		return "", apiErr
+	}
+	if s.nameTaken(volumeGroupCollection, name, "", func(inst instance) bool { return !isSnapshot(inst) }) {
+		return "", errNameInUse(http.StatusUnprocessableEntity, "volume group", name)
+	}
+	group := s.newVolumeGroup(name, volumeTypePrimary, body)
+	id := newID()
+	group["id"] = id
+	if apiErr = s.addVolumesToGroup(id, strList(body["volume_ids"])); apiErr != nil {
+		return "", apiErr
+	}
+	return s.store.insert(volumeGroupCollection, group), nil
+}
+
+func modifyVolumeGroup(s *Server, group, body instance) *apiError {
+	id := str(group, "id")
+	if name := str(body, "name"); name != "" && name != str(group, "name") {
+		if s.nameTaken(volumeGroupCollection, name, id, func(inst instance) bool {
+			return isSnapshot(inst) == isSnapshot(group) && sourceOf(inst) == sourceOf(group)
+		}) {
+			return errNameInUse(http.StatusUnprocessableEntity, "volume group", name)
+		}
+		group["name"] = name
+	}
+	copyFields(group, body, "description", "is_write_order_consistent")
+	if _, ok := body["protection_policy_id"]; ok {
+		group["protection_policy_id"] = strOrNil(body, "protection_policy_id")
+	}
+	if _, ok := body["expiration_timestamp"]; ok {
+		group["protection_data"].(instance)["expiration_timestamp"] = strOrNil(body, "expiration_timestamp")
+	}
+	return nil
+}
+
+// deleteVolumeGroup deletes the group, members of a primary group are deleted only with delete_members,
+// snapshots of members are deleted with a snapshot group
+func deleteVolumeGroup(s *Server, group, body instance) *apiError {
+	id := str(group, "id")
+	members := s.store.find(volumeCollection, byField(keyVolumeGroup, id))
+	if len(s.store.find(replicationSessionCollection, byField("local_resource_id", id))) != 0 {
+		return errUnprocessable("The volume group %s has a replication session.", id)
+	}
+	if !isSnapshot(group) && len(members) != 0 {
+		if deleteMembers, _ := boolOf(body["delete_members"]); !deleteMembers {
+			return errUnprocessable("The volume group %s is not empty, remove its members before deletion.", id)
+		}
+		for _, vol := range members {
+			if len(s.store.find(mappingCollection, byField("volume_id", str(vol, "id")))) != 0 {
+				return errUnprocessable("The volume %s is attached to one or more hosts, detach it before deletion.",
+					str(vol, "id"))
+			}
+		}
+	}
+	for _, vol := range members {
+		delete(vol, keyVolumeGroup)
+		if apiErr := s.deleteVolume(vol); apiErr != nil {
+			return apiErr
+		}
+	}
+	for _, snap := range s.store.find(volumeGroupCollection, func(inst instance) bool {
+		return isSnapshot(inst) && sourceOf(inst) == id
+	}) {
+		if apiErr := deleteVolumeGroup(s, snap, nil); apiErr != nil {
+			return apiErr
+		}
+	}
+	s.store.remove(volumeGroupCollection, id)
+	return nil
+}
+
+func removeVolumeGroupMembers(s *Server, group, body instance) (any, *apiError) {
+	id := str(group, "id")
+	if isSnapshot(group) {
+		return nil, errUnprocessable("Members of snapshot %s can't be changed.", id)
+	}
+	ids := strList(body["volume_ids"])
+	volumes := make([]instance, 0, len(ids))
+	for _, volID := range ids {
+		vol, ok := s.store.get(volumeCollection, volID)
+		if !ok || str(vol, keyVolumeGroup) != id {
+			return nil, errUnprocessable("The volume %s is not part of volume group %s.", volID, id)
+		}
+		volumes = append(volumes, vol)
+	}
+	for _, vol := range volumes {
+		delete(vol, keyVolumeGroup)
+	}
+	return nil, nil
+}
+
+// snapshotVolumeGroup creates a snapshot group with snapshots of all members
+func snapshotVolumeGroup(s *Server, group, body instance) (any, *apiError) {
+	id := str(group, "id")
+	if isSnapshot(group) {
+		return nil, errUnprocessable("The snapshot %s can't be snapshotted.", id)
+	}
+	name := str(body, "name")
+	if name == "" {
+		name = fmt.Sprintf("%s.%s", str(group, "name"), timestamp())
+	}
+	if s.nameTaken(volumeGroupCollection, name, "", func(inst instance) bool {
+		return isSnapshot(inst) && sourceOf(inst) == id
+	}) {
+		return nil, errNameInUse(http.StatusBadRequest, "snapshot", name)
+	}
+	snapGroup := s.newVolumeGroup(name, volumeTypeSnapshot, body)
+	snapGroup["is_write_order_consistent"] = group["is_write_order_consistent"]
+	data := snapGroup["protection_data"].(instance)
+	data["source_id"] = id
+	data["parent_id"] = id
+	snapGroupID := s.store.insert(volumeGroupCollection, snapGroup)
+	for _, vol := range s.store.find(volumeCollection, byField(keyVolumeGroup, id)) {
+		snap := s.newSnapshot(vol, fmt.Sprintf("%s.%s", name, str(vol, "name")), body)
+		snap[keyVolumeGroup] = snapGroupID
+		s.store.insert(volumeCollection, snap)
+	}
+	return createdResponse{ID: snapGroupID}, nil
+}
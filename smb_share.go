@@ -89,35 +89,25 @@ func (c *ClientIMPL) GetSMBShare(ctx context.Context, id string) (resp SMBShare,
 
 // GetSMBShares returns a collection of smb shares based on args
 func (c *ClientIMPL) GetSMBShares(ctx context.Context, args map[string]string) ([]SMBShare, error) {
-	qp := c.APIClient().QueryParamsWithFields(&SMBShare{})
-	for k, v := range args {
-		qp = qp.RawArg(k, v)
-	}
+	return c.GetSMBSharesByFilters(ctx, api.RawFilters(args)...)
+}
 
-	var result []SMBShare
-	err := c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []SMBShare
-		qp.Order("name")
-		qp.Offset(offset).Limit(paginationDefaultPageSize)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    smbShareURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			result = append(result, page...)
-		}
-		return meta, err
+// GetSMBSharesByFilters returns a collection of smb shares matching filters
+func (c *ClientIMPL) GetSMBSharesByFilters(ctx context.Context, filters ...api.Filter) ([]SMBShare, error) {
+	qp := c.APIClient().QueryParamsWithFields(&SMBShare{}).Filter(filters...)
+	qp.Order("name")
+	return readPaginatedData[SMBShare](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    smbShareURL,
+		QueryParams: qp,
 	})
-	return result, err
 }
 
 // GetSMBShareACL returns specific smb share ACL by id
 func (c *ClientIMPL) GetSMBShareACL(ctx context.Context, id string) (resp SMBShareACL, err error) {
+	if err = c.RequireCapability(ctx, CapabilitySMBShareACL); err != nil {
+		return resp, err
+	}
 	_, err = c.APIClient().Query(
 		ctx,
 		RequestConfig{
@@ -130,6 +120,9 @@ func (c *ClientIMPL) GetSMBShareACL(ctx context.Context, id string) (resp SMBSha
 
 // SetSMBShareACL modifies specific smb share ACL by id
 func (c *ClientIMPL) SetSMBShareACL(ctx context.Context, id string, aclParams *ModifySMBShareACL) (resp EmptyResponse, err error) {
+	if err = c.RequireCapability(ctx, CapabilitySMBShareACL); err != nil {
+		return resp, err
+	}
 	_, err = c.APIClient().Query(
 		ctx,
 		RequestConfig{
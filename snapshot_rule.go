@@ -75,27 +75,13 @@ func (c *ClientIMPL) GetSnapshotRuleByName(ctx context.Context, name string) (re
 
 // GetSnapshotRules returns a list of snapshot rules
 func (c *ClientIMPL) GetSnapshotRules(ctx context.Context) ([]SnapshotRule, error) {
-	var result []SnapshotRule
-	err := c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []SnapshotRule
-		qp := getSnapshotRuleDefaultQueryParams(c)
-		qp.Order("name")
-		qp.Offset(offset).Limit(paginationDefaultPageSize)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    snapshotRuleURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			result = append(result, page...)
-		}
-		return meta, err
+	qp := getSnapshotRuleDefaultQueryParams(c)
+	qp.Order("name")
+	return readPaginatedData[SnapshotRule](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    snapshotRuleURL,
+		QueryParams: qp,
 	})
-	return result, err
 }
 
 // CreateSnapshotRule creates new snapshot rule
@@ -20,8 +20,6 @@ package gopowerstore
 
 import (
 	"context"
-	"strconv"
-	"strings"
 
 	"github.com/dell/gopowerstore/api"
 
@@ -39,59 +37,58 @@ func getSoftwareInstalledDefaultQueryParams(c Client) api.QueryParamsEncoder {
 func (c *ClientIMPL) GetSoftwareInstalled(
 	ctx context.Context,
 ) (resp []SoftwareInstalled, err error) {
-	err = c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []SoftwareInstalled
-		qp := getSoftwareInstalledDefaultQueryParams(c)
-		qp.Limit(paginationDefaultPageSize)
-		qp.Offset(offset)
-		qp.Order("id")
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    apiSoftwareInstalledURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			resp = append(resp, page...)
-		}
-		return meta, err
+	qp := getSoftwareInstalledDefaultQueryParams(c)
+	qp.Order("id")
+	return readPaginatedData[SoftwareInstalled](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    apiSoftwareInstalledURL,
+		QueryParams: qp,
 	})
-	return resp, err
 }
 
-func (c *ClientIMPL) GetSoftwareMajorMinorVersion(
-	ctx context.Context,
-) (majorMinorVersion float32, err error) {
+// GetArrayVersion returns version of the software installed on the cluster, zero version if the cluster
+// doesn't report it. The version is remembered by the api client to prune select fields the array doesn't support
+func (c *ClientIMPL) GetArrayVersion(ctx context.Context) (api.ArrayVersion, error) {
 	resp, err := c.GetSoftwareInstalled(ctx)
 	if err != nil {
 		log.Errorf("couldn't find the softwares installed on the Powerstore array %s", err.Error())
-		return 0.0, err
+		return api.ArrayVersion{}, err
 	}
 
+	var version api.ArrayVersion
 	for _, softwareInstalled := range resp {
 		if softwareInstalled.IsCluster {
-			softwareVersion := softwareInstalled.BuildVersion
-			versions := strings.Split(softwareVersion, ".")
-
-			if len(versions) > 2 {
-				var majorVersion, minorVersion int
-
-				if majorVersion, err = strconv.Atoi(versions[0]); err != nil {
-					log.Errorf("couldn't get the software major version installed on the PowerStore array: %s", err.Error())
-					return 0.0, err
-				}
-
-				if minorVersion, err = strconv.Atoi(versions[1]); err != nil {
-					log.Errorf("couldn't get the software minor version installed on the PowerStore array: %s", err.Error())
-					return 0.0, err
-				}
-
-				majorMinorVersion = float32(majorVersion) + float32(minorVersion)*0.1
+			if version, err = api.ParseArrayVersion(softwareInstalled.BuildVersion); err != nil {
+				log.Errorf("couldn't get the software version installed on the PowerStore array: %s", err.Error())
+				return api.ArrayVersion{}, err
 			}
 		}
 	}
-	return majorMinorVersion, nil
+	if vs, ok := c.API.(arrayVersionSetter); ok {
+		vs.SetVersion(version)
+	}
+	return version, nil
+}
+
+// GetSoftwareMajorMinorVersion returns major.minor version of the software installed on the cluster, e.g. 3.6
+//
+// Deprecated: use GetArrayVersion, float versions can't represent minor versions above 9
+func (c *ClientIMPL) GetSoftwareMajorMinorVersion(
+	ctx context.Context,
+) (majorMinorVersion float32, err error) {
+	version, err := c.GetArrayVersion(ctx)
+	if err != nil || version.IsZero() {
+		return 0.0, err
+	}
+	return version.MajorMinor(), nil
+}
+
+// arrayVersionSetter is implemented by api clients which prune select fields by array version
+type arrayVersionSetter interface {
+	SetVersion(version api.ArrayVersion)
+}
+
+// arrayVersionGetter is implemented by api clients which remember the array version
+type arrayVersionGetter interface {
+	Version() api.ArrayVersion
 }
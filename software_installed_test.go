@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/dell/gopowerstore/api"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
 )
@@ -47,3 +48,22 @@ func TestClientIMPL_GetSoftwareInstalled(t *testing.T) {
 	assert.Len(t, softwareInstalled, 2)
 	assert.Equal(t, softwareInstalledID1, softwareInstalled[0].ID)
 }
+
+func TestClientIMPL_GetArrayVersion(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	defer C.APIClient().(arrayVersionSetter).SetVersion(api.ArrayVersion{})
+	respData := `[{"id": "1", "is_cluster": false, "build_version": "3.0.0.0"},
+		{"id": "2", "is_cluster": true, "build_version": "3.10.0.1-1234"}]`
+	httpmock.RegisterResponder("GET", softwareInstalledMockURL,
+		httpmock.NewStringResponder(200, respData))
+	version, err := C.GetArrayVersion(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, api.ArrayVersion{Major: 3, Minor: 10, Build: 1}, version)
+	assert.Equal(t, version, C.APIClient().(arrayVersionGetter).Version())
+
+	httpmock.RegisterResponder("GET", softwareInstalledMockURL,
+		httpmock.NewStringResponder(200, `[{"id": "2", "is_cluster": true, "build_version": "x.y"}]`))
+	_, err = C.GetArrayVersion(context.Background())
+	assert.NotNil(t, err)
+}
@@ -20,7 +20,6 @@ package gopowerstore
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/dell/gopowerstore/api"
 )
@@ -38,17 +37,13 @@ func getStorageContainerDefaultQueryParams(c Client) api.QueryParamsEncoder {
 func (c *ClientIMPL) CreateStorageContainer(ctx context.Context,
 	createParams *StorageContainer,
 ) (resp CreateResponse, err error) {
-	customHeader := http.Header{}
-	customHeader.Add("DELL-VISIBILITY", "Partner")
-	apiClient := c.APIClient()
-	apiClient.SetCustomHTTPHeaders(customHeader)
-
 	_, err = c.APIClient().Query(
 		ctx,
 		RequestConfig{
 			Method:   "POST",
 			Endpoint: storageContainerURL,
 			Body:     createParams,
+			Headers:  visibilityHeaders("Partner"),
 		},
 		&resp)
 	return resp, WrapErr(err)
@@ -56,18 +51,14 @@ func (c *ClientIMPL) CreateStorageContainer(ctx context.Context,
 
 // GetStorageContainer get existing StorageContainer with ID
 func (c *ClientIMPL) GetStorageContainer(ctx context.Context, id string) (resp StorageContainer, err error) {
-	customHeader := http.Header{}
-	customHeader.Add("DELL-VISIBILITY", "Partner")
-	apiClient := c.APIClient()
-	apiClient.SetCustomHTTPHeaders(customHeader)
-
-	_, err = apiClient.Query(
+	_, err = c.APIClient().Query(
 		ctx,
 		RequestConfig{
 			Method:      "GET",
 			Endpoint:    storageContainerURL,
 			ID:          id,
 			QueryParams: getStorageContainerDefaultQueryParams(c),
+			Headers:     visibilityHeaders("Partner"),
 		},
 		&resp)
 	return resp, WrapErr(err)
@@ -75,11 +66,6 @@ func (c *ClientIMPL) GetStorageContainer(ctx context.Context, id string) (resp S
 
 // DeleteStorageContainer deletes existing StorageContainer
 func (c *ClientIMPL) DeleteStorageContainer(ctx context.Context, id string) (resp EmptyResponse, err error) {
-	customHeader := http.Header{}
-	customHeader.Add("DELL-VISIBILITY", "Partner")
-	apiClient := c.APIClient()
-	apiClient.SetCustomHTTPHeaders(customHeader)
-
 	_, err = c.APIClient().Query(
 		ctx,
 		RequestConfig{
@@ -87,6 +73,7 @@ func (c *ClientIMPL) DeleteStorageContainer(ctx context.Context, id string) (res
 			Endpoint: storageContainerURL,
 			ID:       id,
 			Body:     nil,
+			Headers:  visibilityHeaders("Partner"),
 		},
 		&resp)
 	return resp, WrapErr(err)
@@ -94,18 +81,14 @@ func (c *ClientIMPL) DeleteStorageContainer(ctx context.Context, id string) (res
 
 // ModifyStorageContainer updates existing storage container
 func (c *ClientIMPL) ModifyStorageContainer(ctx context.Context, modifyParams *StorageContainer, id string) (resp EmptyResponse, err error) {
-	customHeader := http.Header{}
-	customHeader.Add("DELL-VISIBILITY", "Partner")
-	apiClient := c.APIClient()
-	apiClient.SetCustomHTTPHeaders(customHeader)
-
-	_, err = apiClient.Query(
+	_, err = c.APIClient().Query(
 		ctx,
 		RequestConfig{
 			Method:   "PATCH",
 			Endpoint: storageContainerURL,
 			ID:       id,
 			Body:     modifyParams,
+			Headers:  visibilityHeaders("Partner"),
 		},
 		&resp)
 	return resp, WrapErr(err)
@@ -79,28 +79,14 @@ func (c *ClientIMPL) GetVolumeByName(ctx context.Context, name string) (resp Vol
 
 // GetVolumes returns a list of volumes
 func (c *ClientIMPL) GetVolumes(ctx context.Context) ([]Volume, error) {
-	var result []Volume
-	err := c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []Volume
-		qp := getVolumeDefaultQueryParams(c)
-		qp.RawArg("type", fmt.Sprintf("not.eq.%s", VolumeTypeEnumSnapshot))
-		qp.Order("name")
-		qp.Offset(offset).Limit(paginationDefaultPageSize)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    volumeURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			result = append(result, page...)
-		}
-		return meta, err
+	qp := getVolumeDefaultQueryParams(c)
+	qp.RawArg("type", fmt.Sprintf("not.eq.%s", VolumeTypeEnumSnapshot))
+	qp.Order("name")
+	return readPaginatedData[Volume](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    volumeURL,
+		QueryParams: qp,
 	})
-	return result, err
 }
 
 // GetSnapshot query and return specific snapshot by it's id
@@ -144,55 +130,27 @@ func (c *ClientIMPL) GetSnapshotByName(ctx context.Context, snapName string) (re
 
 // GetSnapshots returns all snapshots
 func (c *ClientIMPL) GetSnapshots(ctx context.Context) ([]Volume, error) {
-	var result []Volume
-	err := c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []Volume
-		qp := getVolumeDefaultQueryParams(c)
-		qp.RawArg("type", fmt.Sprintf("eq.%s", VolumeTypeEnumSnapshot))
-		qp.Order("name")
-		qp.Offset(offset).Limit(paginationDefaultPageSize)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    volumeURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			result = append(result, page...)
-		}
-		return meta, err
+	qp := getVolumeDefaultQueryParams(c)
+	qp.RawArg("type", fmt.Sprintf("eq.%s", VolumeTypeEnumSnapshot))
+	qp.Order("name")
+	return readPaginatedData[Volume](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    volumeURL,
+		QueryParams: qp,
 	})
-	return result, err
 }
 
 // GetSnapshotsByVolumeID returns a list of snapshots for specific volume
 func (c *ClientIMPL) GetSnapshotsByVolumeID(ctx context.Context, volID string) ([]Volume, error) {
-	var result []Volume
-	err := c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []Volume
-		qp := getVolumeDefaultQueryParams(c)
-		qp.RawArg("protection_data->>source_id", fmt.Sprintf("eq.%s", volID))
-		qp.RawArg("type", fmt.Sprintf("eq.%s", VolumeTypeEnumSnapshot))
-		qp.Order("name")
-		qp.Offset(offset).Limit(paginationDefaultPageSize)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    volumeURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			result = append(result, page...)
-		}
-		return meta, err
+	qp := getVolumeDefaultQueryParams(c)
+	qp.RawArg("protection_data->>source_id", fmt.Sprintf("eq.%s", volID))
+	qp.RawArg("type", fmt.Sprintf("eq.%s", VolumeTypeEnumSnapshot))
+	qp.Order("name")
+	return readPaginatedData[Volume](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    volumeURL,
+		QueryParams: qp,
 	})
-	return result, err
 }
 
 // CreateVolume creates new volume
@@ -359,6 +317,9 @@ func (c *ClientIMPL) GetApplianceByName(ctx context.Context, name string) (resp
 // the remote PowerStore system and optional remote PowerStore appliance provided in config.
 // Returns the metro replication session ID and any errors.
 func (c *ClientIMPL) ConfigureMetroVolume(ctx context.Context, id string, config *MetroConfig) (resp MetroSessionResponse, err error) {
+	if err = c.RequireCapability(ctx, CapabilityMetroVolume); err != nil {
+		return resp, err
+	}
 	_, err = c.APIClient().Query(
 		ctx,
 		RequestConfig{
@@ -77,28 +77,14 @@ func (c *ClientIMPL) GetVolumeGroupByName(ctx context.Context, name string) (res
 
 // GetVolumeGroups returns a list of volume groups
 func (c *ClientIMPL) GetVolumeGroups(ctx context.Context) ([]VolumeGroup, error) {
-	var result []VolumeGroup
-	err := c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []VolumeGroup
-		volumegroup := VolumeGroup{}
-		qp := c.APIClient().QueryParamsWithFields(&volumegroup)
-		qp.Order("name")
-		qp.Offset(offset).Limit(paginationDefaultPageSize)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    volumeGroupURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			result = append(result, page...)
-		}
-		return meta, err
+	volumegroup := VolumeGroup{}
+	qp := c.APIClient().QueryParamsWithFields(&volumegroup)
+	qp.Order("name")
+	return readPaginatedData[VolumeGroup](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    volumeGroupURL,
+		QueryParams: qp,
 	})
-	return result, err
 }
 
 // CreateVolumeGroup creates new volume group
@@ -253,28 +239,14 @@ func (c *ClientIMPL) GetVolumeGroupSnapshot(ctx context.Context, snapID string)
 
 // GetVolumeGroupSnapshots returns all volume group snapshots
 func (c *ClientIMPL) GetVolumeGroupSnapshots(ctx context.Context) ([]VolumeGroup, error) {
-	var result []VolumeGroup
-	err := c.readPaginatedData(func(offset int) (api.RespMeta, error) {
-		var page []VolumeGroup
-		qp := getVolumeGroupDefaultQueryParams(c)
-		qp.RawArg("type", fmt.Sprintf("eq.%s", VolumeTypeEnumSnapshot))
-		qp.Order("name")
-		qp.Offset(offset).Limit(paginationDefaultPageSize)
-		meta, err := c.APIClient().Query(
-			ctx,
-			RequestConfig{
-				Method:      "GET",
-				Endpoint:    volumeGroupURL,
-				QueryParams: qp,
-			},
-			&page)
-		err = WrapErr(err)
-		if err == nil {
-			result = append(result, page...)
-		}
-		return meta, err
+	qp := getVolumeGroupDefaultQueryParams(c)
+	qp.RawArg("type", fmt.Sprintf("eq.%s", VolumeTypeEnumSnapshot))
+	qp.Order("name")
+	return readPaginatedData[VolumeGroup](ctx, c, RequestConfig{
+		Method:      "GET",
+		Endpoint:    volumeGroupURL,
+		QueryParams: qp,
 	})
-	return result, err
 }
 
 // GetVolumeGroupSnapshotByName fetches volume group snapshots by name
@@ -303,6 +275,9 @@ func (c *ClientIMPL) GetVolumeGroupSnapshotByName(ctx context.Context, name stri
 // ConfigureMetroVolumeGroup configures the volume group provided by id for metro replication using the
 // configuration supplied by config and returns a MetroSessionResponse containing a replication session ID.
 func (c *ClientIMPL) ConfigureMetroVolumeGroup(ctx context.Context, id string, config *MetroConfig) (session MetroSessionResponse, err error) {
+	if err = c.RequireCapability(ctx, CapabilityMetroVolumeGroup); err != nil {
+		return session, err
+	}
 	_, err = c.APIClient().Query(
 		ctx,
 		RequestConfig{
@@ -16,6 +16,8 @@
 
 package gopowerstore
 
+import "github.com/dell/gopowerstore/api"
+
 // VGPlacementRuleEnum - This is set during creation, and determines resource balancer recommendations.
 type VGPlacementRuleEnum string
 
@@ -62,9 +64,9 @@ type VolumeGroup struct {
 	// For a primary or a clone volume group, this property determines whether snapshot sets of the group will be write order consistent.
 	IsWriteOrderConsistent bool `json:"is_write_order_consistent,omitempty"`
 	// Volumes provides list of volumes associated to the volume group
-	Volumes []Volume `json:"volumes"`
+	Volumes []Volume `json:"volumes" select:"all"`
 	// ProtectionPolicy provides snapshot details of the volume or volumeGroup
-	ProtectionPolicy ProtectionPolicy `json:"protection_policy"`
+	ProtectionPolicy ProtectionPolicy `json:"protection_policy" select:"all"`
 	// CreationTimeStamp provides volume group creation time
 	CreationTimeStamp string `json:"creation_timestamp,omitempty"`
 	// IsReplicationDestination indicates whether this volume group is a replication destination.
@@ -82,18 +84,18 @@ type VolumeGroup struct {
 	// Type of volume.
 	Type VolumeTypeEnum `json:"type,omitempty"`
 	// Protection data associated with a resource.
-	ProtectionData ProtectionData `json:"protection_data,omitempty"`
+	ProtectionData ProtectionData `json:"protection_data,omitempty" select:"explicit"`
 	// A list of locations. The list of locations includes the move to the current appliance.
-	LocationHistory []LocationHistory `json:"location_history,omitempty"`
+	LocationHistory []LocationHistory `json:"location_history,omitempty" select:"explicit"`
 	//  This resource type has queriable associations from virtual_volume, volume, volume_group, replication_session
-	MigrationSession MigrationSession `json:"migration_session,omitempty"`
+	MigrationSession MigrationSession `json:"migration_session,omitempty" select:"all"`
 	// Unique identifier of the replication session assigned to the volume group if it has been configured as a metro volume group between two PowerStore clusters.
 	MetroReplicationSessionID string `json:"metro_replication_session_id,omitempty"`
 }
 
 // Fields returns fields which must be requested to fill struct
 func (v *VolumeGroup) Fields() []string {
-	return []string{"*", "volumes(*)", "protection_policy(*)", "protection_data", "location_history", "migration_session(*)"}
+	return api.SelectAllFields(v)
 }
 
 type VolumeGroups struct {
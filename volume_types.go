@@ -380,7 +380,7 @@ type VirtualVolume struct {
 
 // Fields returns fields which must be requested to fill struct
 func (v *Volume) Fields() []string {
-	return []string{"*"}
+	return api.SelectAllFields(v)
 }
 
 // Fields returns fields which must be requested to fill struct